@@ -0,0 +1,213 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+)
+
+// the outcomes of a ceremony
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// ErrBrokenChain is wrapped by the errors of an audit log which was tampered with
+var ErrBrokenChain = errors.New("the audit log chain is broken")
+
+// errUnterminatedLine is wrapped by the error of a log whose last line isn't terminated
+var errUnterminatedLine = errors.New("the line is not terminated")
+
+// Entry is a ceremony recorded in the audit log
+type Entry struct {
+	Seq           uint64        `json:"seq"`
+	Time          time.Time     `json:"time"`
+	Operation     string        `json:"operation"`
+	MsgID         string        `json:"msg_id"`
+	PoolPubKey    string        `json:"pool_pub_key,omitempty"`
+	Algo          string        `json:"algo,omitempty"`
+	MessageHashes []string      `json:"message_hashes,omitempty"` // hex encoded SHA256 of the signed messages
+	Participants  []string      `json:"participants,omitempty"`
+	Leader        string        `json:"leader,omitempty"`
+	Outcome       string        `json:"outcome"`
+	Error         string        `json:"error,omitempty"`
+	Blame         *blame.Blame  `json:"blame,omitempty"`
+	JoinPartyTime time.Duration `json:"join_party_time"`
+	Duration      time.Duration `json:"duration"`
+	// PrevHash is the hash of the previous entry, it is empty for the first one
+	PrevHash string `json:"prev_hash"`
+}
+
+// record is a line of the audit log, the hash is the hex encoded SHA256 of the entry exactly as it is written, the
+// entry has the hash of the previous line, so no line can be changed, dropped or reordered without breaking the chain
+type record struct {
+	Entry json.RawMessage `json:"entry"`
+	Hash  string          `json:"hash"`
+}
+
+// Log is an append only audit log of JSON lines
+type Log struct {
+	lock     sync.Mutex
+	file     *os.File
+	seq      uint64
+	lastHash string
+}
+
+// Open opens the audit log at the given path, it is created if it doesn't exist, it fails if the chain of the
+// existing entries is broken. An unterminated last line is the record of a write the node didn't finish, it is cut
+// off, Verify still reports it.
+func Open(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("fail to open the audit log: %w", err)
+	}
+	l := &Log{file: file}
+	count, err := Verify(file, func(e Entry, hash string) error {
+		l.seq = e.Seq
+		l.lastHash = hash
+		return nil
+	})
+	if errors.Is(err, errUnterminatedLine) {
+		// the entries before the line are verified, so the log goes on after the last of them
+		var dropped int64
+		dropped, err = truncateLastLine(file)
+		if err == nil {
+			log.Warn().Str("path", path).Int("entries", count).Int64("bytes", dropped).Msg("drop the unterminated last record of the audit log")
+		}
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// truncateLastLine cuts the unterminated last line off the file, it returns the number of the dropped bytes
+func truncateLastLine(file *os.File) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("fail to stat the audit log: %w", err)
+	}
+	end := info.Size()
+	buf := make([]byte, 4096)
+	for end > 0 {
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+		n, err := file.ReadAt(buf[:end-start], start)
+		if err != nil && err != io.EOF {
+			return 0, fmt.Errorf("fail to read the audit log: %w", err)
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = start + int64(i) + 1
+			break
+		}
+		end = start
+	}
+	if err := file.Truncate(end); err != nil {
+		return 0, fmt.Errorf("fail to truncate the audit log: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return 0, fmt.Errorf("fail to sync the audit log: %w", err)
+	}
+	return info.Size() - end, nil
+}
+
+// Append adds the entry to the log, it sets the sequence number and the previous hash, the time too if it is not set
+func (l *Log) Append(e Entry) (Entry, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	e.Seq = l.seq + 1
+	e.PrevHash = l.lastHash
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	buf, err := json.Marshal(e)
+	if err != nil {
+		return Entry{}, fmt.Errorf("fail to marshal the audit entry: %w", err)
+	}
+	hash := hashEntry(buf)
+	line, err := json.Marshal(record{Entry: buf, Hash: hash})
+	if err != nil {
+		return Entry{}, fmt.Errorf("fail to marshal the audit record: %w", err)
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return Entry{}, fmt.Errorf("fail to write the audit entry: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return Entry{}, fmt.Errorf("fail to sync the audit log: %w", err)
+	}
+	l.seq = e.Seq
+	l.lastHash = hash
+	return e, nil
+}
+
+// Close closes the file of the log
+func (l *Log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.file.Close()
+}
+
+func hashEntry(entry []byte) string {
+	hash := sha256.Sum256(entry)
+	return hex.EncodeToString(hash[:])
+}
+
+// Verify checks the chain of the audit log read from r, the visitor is called with every entry and its hash in order,
+// it returns the number of entries
+func Verify(r io.Reader, visit func(e Entry, hash string) error) (int, error) {
+	reader := bufio.NewReader(r)
+	count := 0
+	lastHash := ""
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return count, fmt.Errorf("fail to read the audit log: %w", err)
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			if err == io.EOF {
+				// a line is only complete once it is terminated, e.g. the node stopped in the middle of a write
+				return count, fmt.Errorf("%w: line %d is truncated: %w", ErrBrokenChain, count+1, errUnterminatedLine)
+			}
+			var rec record
+			if err := json.Unmarshal(line, &rec); err != nil {
+				return count, fmt.Errorf("%w: fail to unmarshal line %d: %s", ErrBrokenChain, count+1, err)
+			}
+			if hashEntry(rec.Entry) != rec.Hash {
+				return count, fmt.Errorf("%w: the hash of line %d doesn't match its entry", ErrBrokenChain, count+1)
+			}
+			var e Entry
+			if err := json.Unmarshal(rec.Entry, &e); err != nil {
+				return count, fmt.Errorf("%w: fail to unmarshal the entry of line %d: %s", ErrBrokenChain, count+1, err)
+			}
+			if e.Seq != uint64(count+1) || e.PrevHash != lastHash {
+				return count, fmt.Errorf("%w: line %d doesn't follow the previous one", ErrBrokenChain, count+1)
+			}
+			count++
+			lastHash = rec.Hash
+			if visit != nil {
+				if err := visit(e, rec.Hash); err != nil {
+					return count, err
+				}
+			}
+		}
+		if err == io.EOF {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type LogTestSuite struct{}
+
+var _ = Suite(&LogTestSuite{})
+
+func writeTestLog(c *C, path string) []Entry {
+	l, err := Open(path)
+	c.Assert(err, IsNil)
+	var entries []Entry
+	for _, el := range []Entry{
+		{Operation: "keygen", MsgID: "a", Participants: []string{"A", "B", "C"}, Leader: "A", Outcome: OutcomeSuccess},
+		{Operation: "keysign", MsgID: "b", PoolPubKey: "pool", MessageHashes: []string{"00"}, Outcome: OutcomeSuccess},
+		{
+			Operation:  "keysign",
+			MsgID:      "c",
+			PoolPubKey: "pool",
+			Outcome:    OutcomeFailure,
+			Blame:      &blame.Blame{FailReason: blame.TssTimeout, BlameNodes: []blame.Node{{Pubkey: "B", BlameData: []byte("data")}}},
+		},
+	} {
+		e, err := l.Append(el)
+		c.Assert(err, IsNil)
+		entries = append(entries, e)
+	}
+	c.Assert(l.Close(), IsNil)
+	return entries
+}
+
+func (LogTestSuite) TestAppendAndVerify(c *C) {
+	path := filepath.Join(c.MkDir(), "audit.log")
+	entries := writeTestLog(c, path)
+	c.Assert(entries[0].Seq, Equals, uint64(1))
+	c.Assert(entries[0].PrevHash, Equals, "")
+	c.Assert(entries[2].Seq, Equals, uint64(3))
+
+	// the log goes on where it stopped once it is opened again
+	l, err := Open(path)
+	c.Assert(err, IsNil)
+	e, err := l.Append(Entry{Operation: "keyregroup", MsgID: "d", Outcome: OutcomeSuccess})
+	c.Assert(err, IsNil)
+	c.Assert(e.Seq, Equals, uint64(4))
+	c.Assert(l.Close(), IsNil)
+
+	buf, err := ioutil.ReadFile(path)
+	c.Assert(err, IsNil)
+	var visited []Entry
+	count, err := Verify(bytes.NewReader(buf), func(e Entry, _ string) error {
+		visited = append(visited, e)
+		return nil
+	})
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 4)
+	c.Assert(visited[3].PrevHash, Not(Equals), "")
+	c.Assert(visited[2].Blame.BlameNodes[0].BlameData, DeepEquals, []byte("data"))
+	c.Assert(visited[1].MessageHashes, DeepEquals, []string{"00"})
+}
+
+func (LogTestSuite) TestTamperedLog(c *C) {
+	path := filepath.Join(c.MkDir(), "audit.log")
+	writeTestLog(c, path)
+	buf, err := ioutil.ReadFile(path)
+	c.Assert(err, IsNil)
+	lines := bytes.SplitAfter(buf, []byte("\n"))
+	testCases := []struct {
+		name   string
+		tamper func() []byte
+	}{
+		{
+			name: "changed entry",
+			tamper: func() []byte {
+				return bytes.Replace(buf, []byte(`"outcome":"failure"`), []byte(`"outcome":"success"`), 1)
+			},
+		},
+		{
+			name: "dropped entry",
+			tamper: func() []byte {
+				return bytes.Join([][]byte{lines[0], lines[2]}, nil)
+			},
+		},
+		{
+			name: "reordered entries",
+			tamper: func() []byte {
+				return bytes.Join([][]byte{lines[1], lines[0], lines[2]}, nil)
+			},
+		},
+		{
+			name: "truncated entry",
+			tamper: func() []byte {
+				return buf[:len(buf)-10]
+			},
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		_, err := Verify(bytes.NewReader(tc.tamper()), nil)
+		c.Assert(errors.Is(err, ErrBrokenChain), Equals, true)
+	}
+
+	// the node doesn't append to a broken log
+	c.Assert(ioutil.WriteFile(path, testCases[0].tamper(), os.ModePerm), IsNil)
+	_, err = Open(path)
+	c.Assert(errors.Is(err, ErrBrokenChain), Equals, true)
+}
+
+func (LogTestSuite) TestUnterminatedRecord(c *C) {
+	path := filepath.Join(c.MkDir(), "audit.log")
+	writeTestLog(c, path)
+	buf, err := ioutil.ReadFile(path)
+	c.Assert(err, IsNil)
+	// the node stopped in the middle of writing the fourth record
+	c.Assert(ioutil.WriteFile(path, append(append([]byte(nil), buf...), []byte(`{"entry":{"seq":4`)...), os.ModePerm), IsNil)
+	f, err := os.Open(path)
+	c.Assert(err, IsNil)
+	_, err = Verify(f, nil)
+	c.Assert(errors.Is(err, ErrBrokenChain), Equals, true)
+	c.Assert(f.Close(), IsNil)
+
+	// the log is opened without the partial record and goes on after the last full one
+	l, err := Open(path)
+	c.Assert(err, IsNil)
+	truncated, err := ioutil.ReadFile(path)
+	c.Assert(err, IsNil)
+	c.Assert(truncated, DeepEquals, buf)
+	e, err := l.Append(Entry{Operation: "keysign", MsgID: "d", Outcome: OutcomeSuccess})
+	c.Assert(err, IsNil)
+	c.Assert(e.Seq, Equals, uint64(4))
+	c.Assert(l.Close(), IsNil)
+	f, err = os.Open(path)
+	c.Assert(err, IsNil)
+	defer f.Close()
+	count, err := Verify(f, nil)
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 4)
+}
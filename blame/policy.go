@@ -179,6 +179,9 @@ func (m *Manager) TssMissingShareBlame(rounds int, algo messages.Algo) ([]Node,
 			case messages.EDDSAKEYSIGN:
 				// currently, EDDSA do not have proof, so all the communication is broadcast.
 				isUnicast = false
+			case messages.SCHNORRKEYSIGN:
+				// like EDDSA, all the communication of the schnorr signing is broadcast.
+				isUnicast = false
 
 			default:
 				m.logger.Error().Msgf("fail to find the algorithm for this keygen/keysign, set unicast as false by default")
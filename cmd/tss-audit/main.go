@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/HyperCore-Team/go-tss/audit"
+)
+
+const usage = `usage: tss-audit verify [flags] <audit log>
+
+verify checks the hash chain of the audit log written by tss, it exits with 1 if the log was tampered with, the
+entries which match the filters are exported as JSON lines if -export is set
+`
+
+// exportedEntry is an exported entry of the audit log with its hash
+type exportedEntry struct {
+	audit.Entry
+	Hash string `json:"hash"`
+}
+
+// filter selects the exported entries, the empty fields match every entry
+type filter struct {
+	operation string
+	pool      string
+	msgID     string
+	outcome   string
+	since     time.Time
+	until     time.Time
+}
+
+func (f filter) matches(e audit.Entry) bool {
+	switch {
+	case f.operation != "" && e.Operation != f.operation:
+		return false
+	case f.pool != "" && e.PoolPubKey != f.pool:
+		return false
+	case f.msgID != "" && e.MsgID != f.msgID:
+		return false
+	case f.outcome != "" && e.Outcome != f.outcome:
+		return false
+	case !f.since.IsZero() && e.Time.Before(f.since):
+		return false
+	case !f.until.IsZero() && !e.Time.Before(f.until):
+		return false
+	}
+	return true
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "verify" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	var f filter
+	flags.StringVar(&f.operation, "operation", "", "only export the entries of the operation: keygen, keysign, keyregroup or refresh")
+	flags.StringVar(&f.pool, "pool", "", "only export the entries of the pool pub key")
+	flags.StringVar(&f.msgID, "msgid", "", "only export the entry of the message id")
+	flags.StringVar(&f.outcome, "outcome", "", "only export the entries with the outcome: success or failure")
+	since := flags.String("since", "", "only export the entries recorded at or after the RFC3339 time")
+	until := flags.String("until", "", "only export the entries recorded before the RFC3339 time")
+	export := flags.String("export", "", "file to export the matching entries to, - for stdout")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(os.Args[2:])
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	var err error
+	if f.since, err = parseTime(*since); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid since: %s\n", err)
+		os.Exit(2)
+	}
+	if f.until, err = parseTime(*until); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid until: %s\n", err)
+		os.Exit(2)
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fail to open the audit log: %s\n", err)
+		os.Exit(2)
+	}
+	defer file.Close()
+
+	var out io.Writer
+	switch *export {
+	case "":
+	case "-":
+		out = os.Stdout
+	default:
+		exportFile, err := os.Create(*export)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "fail to create the export file: %s\n", err)
+			os.Exit(2)
+		}
+		defer exportFile.Close()
+		out = exportFile
+	}
+	var encoder *json.Encoder
+	if out != nil {
+		encoder = json.NewEncoder(out)
+	}
+	matched := 0
+	count, err := audit.Verify(file, func(e audit.Entry, hash string) error {
+		if !f.matches(e) {
+			return nil
+		}
+		matched++
+		if encoder == nil {
+			return nil
+		}
+		return encoder.Encode(exportedEntry{Entry: e, Hash: hash})
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s, %d entries are verified before it\n", err, count)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "the chain of %d entries is intact, %d match the filters\n", count, matched)
+}
@@ -16,6 +16,8 @@ import (
 
 	"github.com/HyperCore-Team/tss-lib/eddsa/keygen"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/HyperCore-Team/go-tss/storage"
 )
 
 type (
@@ -27,7 +29,7 @@ type (
 	}
 )
 
-func getTssSecretFile(file string) (KeygenLocalState, error) {
+func getTssSecretFile(file, passphrase string) (KeygenLocalState, error) {
 	_, err := os.Stat(file)
 	if err != nil {
 		return KeygenLocalState{}, err
@@ -36,6 +38,10 @@ func getTssSecretFile(file string) (KeygenLocalState, error) {
 	if err != nil {
 		return KeygenLocalState{}, fmt.Errorf("file to read from file(%s): %w", file, err)
 	}
+	buf, err = storage.DecryptLocalState(buf, []byte(passphrase))
+	if err != nil {
+		return KeygenLocalState{}, fmt.Errorf("fail to decrypt file(%s): %w", file, err)
+	}
 	var localState KeygenLocalState
 	if err := json.Unmarshal(buf, &localState); nil != err {
 		return KeygenLocalState{}, fmt.Errorf("fail to unmarshal KeygenLocalState: %w", err)
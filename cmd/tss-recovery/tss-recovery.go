@@ -17,12 +17,13 @@ func main() {
 	threshold := n - 1
 	export := flag.String("export", "", "path to export keyfile")
 	password := flag.String("password", "", "encryption password for keyfile")
+	passphrase := flag.String("passphrase", "", "passphrase of the encrypted local state files")
 	flag.Parse()
 	files := flag.Args()
 
 	allSecret := make([]KeygenLocalState, len(files))
 	for i, f := range files {
-		tssSecret, err := getTssSecretFile(f)
+		tssSecret, err := getTssSecretFile(f, *passphrase)
 		if err != nil {
 			fmt.Printf("---%v\n", err)
 		}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// the names of the routes, they are used to authorize the credentials
+const (
+	routeKeygen         = "keygen"
+	routeKeysign        = "keysign"
+	routeKeysignBatch   = "keysign/batch"
+	routeKeyRegroup     = "keyregroup"
+	routeConfirmRegroup = "keyregroup/confirm"
+	routeRefresh        = "refresh"
+	routeJobs           = "jobs"
+	routePools          = "pools"
+	routeDeletePool     = "pools/delete"
+	routeSignatures     = "signatures"
+	routePreParams      = "preparams"
+	routePing           = "ping"
+	routeP2pID          = "p2pid"
+	routeMetrics        = "metrics"
+	// routeAll authorizes all the routes
+	routeAll = "*"
+)
+
+// the headers of a request authenticated with a HMAC, the signature is the hex encoded HMAC-SHA256 of
+// method + "\n" + request uri + "\n" + timestamp + "\n" + hex encoded SHA256 of the body
+const (
+	hmacKeyHeader       = "X-Tss-Key"
+	hmacTimestampHeader = "X-Tss-Timestamp"
+	hmacSignatureHeader = "X-Tss-Signature"
+	// hmacMaxSkew is how old or new the timestamp of a HMAC authenticated request may be
+	hmacMaxSkew = 5 * time.Minute
+)
+
+var (
+	errUnauthenticated = errors.New("request is not authenticated")
+	knownRoutes        = map[string]bool{
+		routeKeygen: true, routeKeysign: true, routeKeysignBatch: true, routeKeyRegroup: true, routeConfirmRegroup: true, routeRefresh: true,
+		routeJobs: true, routePools: true, routeDeletePool: true, routeSignatures: true, routePreParams: true, routeP2pID: true,
+		routeMetrics: true, routeAll: true,
+	}
+)
+
+// Credential authenticates the clients of the http api, they present the token as a bearer token, sign the request
+// with the HMAC secret or present a client certificate with the common name. A client may only use the given routes,
+// and only for the given pools if there are any.
+type Credential struct {
+	Name       string   `json:"name"`
+	Token      string   `json:"token,omitempty"`
+	HMACSecret string   `json:"hmac_secret,omitempty"`
+	CommonName string   `json:"common_name,omitempty"`
+	Routes     []string `json:"routes"`
+	Pools      []string `json:"pools,omitempty"`
+}
+
+// AuthConfig is the authentication of the http api, the api is open if nothing is set. If a client CA is set, the
+// clients have to present a certificate signed by it.
+type AuthConfig struct {
+	TLSCertFile  string
+	TLSKeyFile   string
+	ClientCAFile string
+	Credentials  []Credential
+}
+
+// LoadCredentials reads the credentials from the given json file
+func LoadCredentials(path string) ([]Credential, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read the credentials file: %w", err)
+	}
+	var credentials []Credential
+	if err := json.Unmarshal(buf, &credentials); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal the credentials file: %w", err)
+	}
+	return credentials, nil
+}
+
+func (c *Credential) allowsRoute(route string) bool {
+	for _, el := range c.Routes {
+		if el == routeAll || el == route {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Credential) allowsPool(poolPubKey string) bool {
+	if len(c.Pools) == 0 {
+		return true
+	}
+	for _, el := range c.Pools {
+		if el == poolPubKey {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticator finds the credential of a request
+type authenticator struct {
+	// tokens are keyed by the hash of the token, so the lookup doesn't tell how much of a token is right
+	tokens      map[[sha256.Size]byte]*Credential
+	hmacSecrets map[string]*Credential
+	commonNames map[string]*Credential
+}
+
+func newAuthenticator(credentials []Credential) (*authenticator, error) {
+	a := &authenticator{
+		tokens:      make(map[[sha256.Size]byte]*Credential),
+		hmacSecrets: make(map[string]*Credential),
+		commonNames: make(map[string]*Credential),
+	}
+	names := make(map[string]bool)
+	for i := range credentials {
+		c := &credentials[i]
+		if c.Name == "" || names[c.Name] {
+			return nil, fmt.Errorf("the credential name (%s) is empty or used twice", c.Name)
+		}
+		names[c.Name] = true
+		if c.Token == "" && c.HMACSecret == "" && c.CommonName == "" {
+			return nil, fmt.Errorf("credential %s has neither a token, a hmac secret nor a common name", c.Name)
+		}
+		if len(c.Routes) == 0 {
+			return nil, fmt.Errorf("credential %s doesn't allow any route", c.Name)
+		}
+		for _, el := range c.Routes {
+			if !knownRoutes[el] {
+				return nil, fmt.Errorf("credential %s allows the unknown route %s", c.Name, el)
+			}
+		}
+		if c.Token != "" {
+			key := sha256.Sum256([]byte(c.Token))
+			if _, ok := a.tokens[key]; ok {
+				return nil, fmt.Errorf("the token of credential %s is used twice", c.Name)
+			}
+			a.tokens[key] = c
+		}
+		if c.HMACSecret != "" {
+			a.hmacSecrets[c.Name] = c
+		}
+		if c.CommonName != "" {
+			if _, ok := a.commonNames[c.CommonName]; ok {
+				return nil, fmt.Errorf("the common name of credential %s is used twice", c.Name)
+			}
+			a.commonNames[c.CommonName] = c
+		}
+	}
+	return a, nil
+}
+
+func (a *authenticator) authenticate(r *http.Request) (*Credential, error) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		return a.authenticateToken(header)
+	}
+	if r.Header.Get(hmacKeyHeader) != "" {
+		return a.authenticateHMAC(r)
+	}
+	return a.authenticateCertificate(r.TLS)
+}
+
+// authenticateToken finds the credential of the bearer token in the authorization header
+func (a *authenticator) authenticateToken(header string) (*Credential, error) {
+	token := strings.TrimPrefix(header, "Bearer ")
+	if token == header {
+		return nil, errUnauthenticated
+	}
+	c, ok := a.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	return c, nil
+}
+
+// authenticateCertificate finds the credential of the verified client certificate
+func (a *authenticator) authenticateCertificate(state *tls.ConnectionState) (*Credential, error) {
+	if state != nil && len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 0 {
+		if c, ok := a.commonNames[state.VerifiedChains[0][0].Subject.CommonName]; ok {
+			return c, nil
+		}
+	}
+	return nil, errUnauthenticated
+}
+
+func (a *authenticator) authenticateHMAC(r *http.Request) (*Credential, error) {
+	c, err := a.hmacCredential(r.Header.Get(hmacKeyHeader), r.Header.Get(hmacTimestampHeader))
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	if r.Body != nil {
+		body, err = ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read the request body: %w", err)
+		}
+		// the handler decodes the body again
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	if err := verifyHMAC(c, r.Header.Get(hmacSignatureHeader), r.Method, r.URL.RequestURI(), r.Header.Get(hmacTimestampHeader), body); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// hmacCredential returns the credential of the HMAC key if the timestamp is recent, the signature is checked by
+// verifyHMAC once the body is read
+func (a *authenticator) hmacCredential(key, timestamp string) (*Credential, error) {
+	c, ok := a.hmacSecrets[key]
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, errUnauthenticated
+	}
+	skew := time.Since(time.Unix(unix, 0))
+	if skew > hmacMaxSkew || skew < -hmacMaxSkew {
+		return nil, errUnauthenticated
+	}
+	return c, nil
+}
+
+func verifyHMAC(c *Credential, hexSignature, method, requestURI, timestamp string, body []byte) error {
+	signature, err := hex.DecodeString(hexSignature)
+	if err != nil {
+		return errUnauthenticated
+	}
+	if !hmac.Equal(signature, signRequest([]byte(c.HMACSecret), method, requestURI, timestamp, body)) {
+		return errUnauthenticated
+	}
+	return nil
+}
+
+// signRequest returns the HMAC of the request which the client sends in the X-Tss-Signature header
+func signRequest(secret []byte, method, requestURI, timestamp string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(method + "\n" + requestURI + "\n" + timestamp + "\n" + hex.EncodeToString(bodyHash[:])))
+	return mac.Sum(nil)
+}
+
+type credentialKey struct{}
+
+// requestCredential returns the credential of the request, it is nil if the api is open
+func requestCredential(r *http.Request) *Credential {
+	return contextCredential(r.Context())
+}
+
+// contextCredential returns the credential of the http request or the grpc call of the context
+func contextCredential(ctx context.Context) *Credential {
+	c, _ := ctx.Value(credentialKey{}).(*Credential)
+	return c
+}
+
+// allowsPool tells whether the client of the request may use the given pool
+func allowsPool(r *http.Request, poolPubKey string) bool {
+	return contextAllowsPool(r.Context(), poolPubKey)
+}
+
+// contextAllowsPool tells whether the client of the http request or the grpc call may use the given pool
+func contextAllowsPool(ctx context.Context, poolPubKey string) bool {
+	c := contextCredential(ctx)
+	return c == nil || c.allowsPool(poolPubKey)
+}
+
+// authMiddleware authenticates the requests and makes sure the client may use the route, the pools are checked by
+// the handlers as they are in the body of the requests
+func (t *TssHttpServer) authMiddleware() mux.MiddlewareFunc {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := ""
+			if current := mux.CurrentRoute(r); current != nil {
+				route = current.GetName()
+			}
+			if t.auth == nil || route == routePing {
+				handler.ServeHTTP(w, r)
+				return
+			}
+			c, err := t.auth.authenticate(r)
+			if err != nil {
+				t.logger.Error().Err(err).Msgf("fail to authenticate the request to %s", r.URL.Path)
+				t.writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
+				return
+			}
+			if !c.allowsRoute(route) {
+				t.logger.Error().Msgf("credential %s may not use %s", c.Name, route)
+				t.writeError(w, http.StatusForbidden, CodeForbidden, fmt.Sprintf("the client may not use %s", route))
+				return
+			}
+			handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), credentialKey{}, c)))
+		})
+	}
+}
+
+// tlsConfig returns the tls configuration of the server, it is nil if no certificate is set
+func (conf AuthConfig) tlsConfig() (*tls.Config, error) {
+	if conf.TLSCertFile == "" && conf.TLSKeyFile == "" {
+		if conf.ClientCAFile != "" {
+			return nil, errors.New("the client CA needs the tls certificate of the server")
+		}
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(conf.TLSCertFile, conf.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("fail to load the tls certificate: %w", err)
+	}
+	tlsConf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if conf.ClientCAFile != "" {
+		buf, err := ioutil.ReadFile(conf.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read the client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(buf) {
+			return nil, errors.New("no certificate is found in the client CA file")
+		}
+		tlsConf.ClientCAs = pool
+		tlsConf.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConf, nil
+}
+
+// isLoopback tells whether the server only listens on the loopback
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/tss"
+)
+
+type AuthTestSuite struct{}
+
+var _ = Suite(&AuthTestSuite{})
+
+func keySignBody(poolPubKey string) string {
+	return `{"pool_pub_key":"` + poolPubKey + `","messages":["aGVsbG93b3JsZA=="],"block_height":10,"tss_version":"0.14.0","algo":"ecdsa"}`
+}
+
+// hmacRequest returns a request signed with the given secret at the given time
+func hmacRequest(key, secret, method, target, body string, at time.Time) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	req.Header.Set(hmacKeyHeader, key)
+	req.Header.Set(hmacTimestampHeader, timestamp)
+	req.Header.Set(hmacSignatureHeader, hex.EncodeToString(signRequest([]byte(secret), method, req.URL.RequestURI(), timestamp, []byte(body))))
+	return req
+}
+
+func bearerRequest(token, method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func (AuthTestSuite) TestAuthMiddleware(c *C) {
+	const otherPubKey = "A1oYI5jmGmnnTwdnfMdwn3cdN2E0pkp+s0HFkDKoOJAe"
+	credentials := []Credential{
+		{Name: "admin", Token: "admin-token", Routes: []string{routeAll}},
+		{Name: "signer", Token: "signer-token", Routes: []string{routeKeysign, routePools}, Pools: []string{testPubKey}},
+		{Name: "hmac", HMACSecret: "hmac-secret", Routes: []string{routeKeysign}},
+	}
+	testCases := []struct {
+		name          string
+		reqProvider   func() *http.Request
+		resultChecker func(c *C, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ping is open",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/ping", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+			},
+		},
+		{
+			name: "request without credential should return status unauthorized",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/keysign", strings.NewReader(keySignBody(testPubKey)))
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusUnauthorized)
+			},
+		},
+		{
+			name: "unknown token should return status unauthorized",
+			reqProvider: func() *http.Request {
+				return bearerRequest("whatever", http.MethodPost, "/keysign", keySignBody(testPubKey))
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusUnauthorized)
+			},
+		},
+		{
+			name: "token which may sign for the pool",
+			reqProvider: func() *http.Request {
+				return bearerRequest("signer-token", http.MethodPost, "/keysign", keySignBody(testPubKey))
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+			},
+		},
+		{
+			name: "token which may not sign for the pool should return status forbidden",
+			reqProvider: func() *http.Request {
+				return bearerRequest("signer-token", http.MethodPost, "/keysign", keySignBody(otherPubKey))
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusForbidden)
+			},
+		},
+		{
+			name: "token which may not use the route should return status forbidden",
+			reqProvider: func() *http.Request {
+				return bearerRequest("signer-token", http.MethodPost, "/keygen", testKeygenBody)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusForbidden)
+			},
+		},
+		{
+			name: "the pools are filtered by the credential",
+			reqProvider: func() *http.Request {
+				return bearerRequest("signer-token", http.MethodGet, "/pools", "")
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+				var pools []tss.PoolInfo
+				c.Assert(json.Unmarshal(w.Body.Bytes(), &pools), IsNil)
+				c.Assert(pools, HasLen, 1)
+				c.Assert(pools[0].PubKey, Equals, testPubKey)
+			},
+		},
+		{
+			name: "token which may use all the routes and pools",
+			reqProvider: func() *http.Request {
+				return bearerRequest("admin-token", http.MethodGet, "/pools", "")
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+				var pools []tss.PoolInfo
+				c.Assert(json.Unmarshal(w.Body.Bytes(), &pools), IsNil)
+				c.Assert(pools, HasLen, 2)
+			},
+		},
+		{
+			name: "request signed with the hmac secret",
+			reqProvider: func() *http.Request {
+				return hmacRequest("hmac", "hmac-secret", http.MethodPost, "/keysign", keySignBody(otherPubKey), time.Now())
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+			},
+		},
+		{
+			name: "request signed with the wrong hmac secret should return status unauthorized",
+			reqProvider: func() *http.Request {
+				return hmacRequest("hmac", "wrong-secret", http.MethodPost, "/keysign", keySignBody(otherPubKey), time.Now())
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusUnauthorized)
+			},
+		},
+		{
+			name: "request signed too long ago should return status unauthorized",
+			reqProvider: func() *http.Request {
+				return hmacRequest("hmac", "hmac-secret", http.MethodPost, "/keysign", keySignBody(otherPubKey), time.Now().Add(-time.Hour))
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusUnauthorized)
+			},
+		},
+		{
+			name: "tampered body should return status unauthorized",
+			reqProvider: func() *http.Request {
+				req := hmacRequest("hmac", "hmac-secret", http.MethodPost, "/keysign", keySignBody(otherPubKey), time.Now())
+				signed := req.Header.Clone()
+				req = httptest.NewRequest(http.MethodPost, "/keysign", strings.NewReader(keySignBody(testPubKey)))
+				req.Header = signed
+				return req
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusUnauthorized)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		tssServer := &MockTssServer{
+			pools: []tss.PoolInfo{
+				{PubKey: testPubKey, Algo: "ecdsa", ParticipantKeys: []string{"a", "b"}, LocalPartyKey: "a", Threshold: 1},
+				{PubKey: otherPubKey, Algo: "ecdsa", ParticipantKeys: []string{"a", "b"}, LocalPartyKey: "a", Threshold: 1},
+			},
+		}
+		s, err := NewTssHttpServerWithAuth("127.0.0.1:8080", tssServer, AuthConfig{Credentials: credentials})
+		c.Assert(err, IsNil)
+		res := httptest.NewRecorder()
+		s.tssNewHandler().ServeHTTP(res, tc.reqProvider())
+		tc.resultChecker(c, res)
+	}
+}
+
+func (AuthTestSuite) TestNewTssHttpServerWithAuth(c *C) {
+	testCases := []struct {
+		name  string
+		conf  AuthConfig
+		valid bool
+	}{
+		{name: "open api", valid: true},
+		{name: "credential without name", conf: AuthConfig{Credentials: []Credential{{Token: "a", Routes: []string{routeAll}}}}},
+		{name: "credential without secret", conf: AuthConfig{Credentials: []Credential{{Name: "a", Routes: []string{routeAll}}}}},
+		{name: "credential without route", conf: AuthConfig{Credentials: []Credential{{Name: "a", Token: "a"}}}},
+		{name: "credential with unknown route", conf: AuthConfig{Credentials: []Credential{{Name: "a", Token: "a", Routes: []string{"sign"}}}}},
+		{
+			name: "token used twice",
+			conf: AuthConfig{Credentials: []Credential{
+				{Name: "a", Token: "a", Routes: []string{routeAll}},
+				{Name: "b", Token: "a", Routes: []string{routeAll}},
+			}},
+		},
+		{name: "client CA without certificate", conf: AuthConfig{ClientCAFile: "ca.pem"}},
+		{name: "missing certificate", conf: AuthConfig{TLSCertFile: "missing.pem", TLSKeyFile: "missing.key"}},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		_, err := NewTssHttpServerWithAuth("127.0.0.1:8080", &MockTssServer{}, tc.conf)
+		if tc.valid {
+			c.Assert(err, IsNil)
+		} else {
+			c.Assert(err, NotNil)
+		}
+	}
+}
+
+func (AuthTestSuite) TestOpenApiOnlyListensOnLoopback(c *C) {
+	c.Assert(isLoopback("127.0.0.1:8080"), Equals, true)
+	c.Assert(isLoopback("localhost:8080"), Equals, true)
+	c.Assert(isLoopback("[::1]:8080"), Equals, true)
+	c.Assert(isLoopback("0.0.0.0:8080"), Equals, false)
+	c.Assert(isLoopback(":8080"), Equals, false)
+
+	tssServer := &MockTssServer{}
+	s := NewTssHttpServer("0.0.0.0:8080", tssServer)
+	c.Assert(s.Start(), NotNil)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/tss"
+)
+
+// ErrorCode is the machine readable reason of a failed request, the clients should switch on it rather than on the
+// message
+type ErrorCode string
+
+const (
+	CodeInvalidRequest      ErrorCode = "invalid_request"
+	CodeMethodNotAllowed    ErrorCode = "method_not_allowed"
+	CodeUnauthorized        ErrorCode = "unauthorized"
+	CodeForbidden           ErrorCode = "forbidden"
+	CodePolicyRejected      ErrorCode = "policy_rejected"
+	CodeNotFound            ErrorCode = "not_found"
+	CodePoolNotFound        ErrorCode = "pool_not_found"
+	CodeSignaturesNotFound  ErrorCode = "signatures_not_found"
+	CodeInsufficientSigners ErrorCode = "insufficient_signers"
+	CodeQueueFull           ErrorCode = "queue_full"
+	CodeJoinPartyTimeout    ErrorCode = "join_party_timeout"
+	CodeCeremonyTimeout     ErrorCode = "ceremony_timeout"
+	CodeCeremonyFailed      ErrorCode = "ceremony_failed"
+	CodeCancelled           ErrorCode = "cancelled"
+	CodeInternal            ErrorCode = "internal"
+)
+
+// ErrorResponse is the body of every failed request, the blame is set if the ceremony failed because of some nodes,
+// the fields are set if some fields of the request are invalid
+type ErrorResponse struct {
+	Code    ErrorCode          `json:"code"`
+	Message string             `json:"message"`
+	Blame   *blame.Blame       `json:"blame,omitempty"`
+	Fields  common.FieldErrors `json:"fields,omitempty"`
+}
+
+// errorStatus maps the error of the tss server to the http status and the code of the error body
+func errorStatus(err error) (int, ErrorCode) {
+	var fieldErrs common.FieldErrors
+	switch {
+	case errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable, CodeCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, CodeCancelled
+	case errors.Is(err, tss.ErrInvalidRequest), errors.Is(err, tss.ErrInvalidPoolPubKey), errors.As(err, &fieldErrs):
+		return http.StatusBadRequest, CodeInvalidRequest
+	case errors.Is(err, tss.ErrPoolNotFound):
+		return http.StatusNotFound, CodePoolNotFound
+	case errors.Is(err, tss.ErrSignaturesNotFound):
+		return http.StatusNotFound, CodeSignaturesNotFound
+	case errors.Is(err, tss.ErrSigningRejected):
+		return http.StatusForbidden, CodePolicyRejected
+	case errors.Is(err, tss.ErrInsufficientSigners):
+		return http.StatusUnprocessableEntity, CodeInsufficientSigners
+	case errors.Is(err, tss.ErrCeremonyQueueFull):
+		return http.StatusTooManyRequests, CodeQueueFull
+	case errors.Is(err, tss.ErrJoinPartyTimeout):
+		return http.StatusServiceUnavailable, CodeJoinPartyTimeout
+	case errors.Is(err, tss.ErrCeremonyTimeout), errors.Is(err, keysign.ErrSignatureTimeout):
+		return http.StatusGatewayTimeout, CodeCeremonyTimeout
+	case errors.Is(err, tss.ErrCeremonyFailed), errors.Is(err, keysign.ErrKeysignFailed):
+		return http.StatusInternalServerError, CodeCeremonyFailed
+	}
+	return http.StatusInternalServerError, CodeInternal
+}
+
+// writeError writes the error body with the given status
+func (t *TssHttpServer) writeError(w http.ResponseWriter, status int, code ErrorCode, message string) {
+	t.writeErrorResponse(w, status, ErrorResponse{Code: code, Message: message})
+}
+
+// serverErrorResponse builds the error body of the error of the tss server and its http status, the blame of the
+// failed ceremony is kept in the body
+func serverErrorResponse(err error, b blame.Blame) (int, ErrorResponse) {
+	status, code := errorStatus(err)
+	resp := ErrorResponse{Code: code, Message: err.Error()}
+	if b.FailReason != "" || len(b.BlameNodes) > 0 {
+		resp.Blame = &b
+	}
+	errors.As(err, &resp.Fields)
+	return status, resp
+}
+
+// writeServerError writes the error of the tss server, the blame of the failed ceremony is kept in the body
+func (t *TssHttpServer) writeServerError(w http.ResponseWriter, err error, b blame.Blame) {
+	status, resp := serverErrorResponse(err, b)
+	t.writeErrorResponse(w, status, resp)
+}
+
+func (t *TssHttpServer) writeErrorResponse(w http.ResponseWriter, status int, resp ErrorResponse) {
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to marshal the error response to json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(buf); err != nil {
+		t.logger.Error().Err(err).Msg("fail to write to response")
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/p2p"
+	"github.com/HyperCore-Team/go-tss/tss"
+)
+
+type ErrorsTestSuite struct{}
+
+var _ = Suite(&ErrorsTestSuite{})
+
+func (ErrorsTestSuite) TestErrorStatus(c *C) {
+	testCases := []struct {
+		err    error
+		status int
+		code   ErrorCode
+	}{
+		{fmt.Errorf("%w: unknown algo", tss.ErrInvalidRequest), http.StatusBadRequest, CodeInvalidRequest},
+		{tss.ErrInvalidPoolPubKey, http.StatusBadRequest, CodeInvalidRequest},
+		{fmt.Errorf("no key share: %w", tss.ErrPoolNotFound), http.StatusNotFound, CodePoolNotFound},
+		{fmt.Errorf("%w: 4c9b7e1f", tss.ErrSignaturesNotFound), http.StatusNotFound, CodeSignaturesNotFound},
+		{&tss.PolicyRejectedError{Policy: "max_messages", Err: errors.New("too many")}, http.StatusForbidden, CodePolicyRejected},
+		{fmt.Errorf("%w: threshold=2 and signers=1", tss.ErrInsufficientSigners), http.StatusUnprocessableEntity, CodeInsufficientSigners},
+		{tss.ErrCeremonyQueueFull, http.StatusTooManyRequests, CodeQueueFull},
+		{fmt.Errorf("fail to form the keysign party: %w", p2p.ErrJoinPartyTimeout), http.StatusServiceUnavailable, CodeJoinPartyTimeout},
+		{blame.ErrTssTimeOut, http.StatusGatewayTimeout, CodeCeremonyTimeout},
+		{keysign.ErrSignatureTimeout, http.StatusGatewayTimeout, CodeCeremonyTimeout},
+		{fmt.Errorf("%w: invalid share", tss.ErrCeremonyFailed), http.StatusInternalServerError, CodeCeremonyFailed},
+		{keysign.ErrKeysignFailed, http.StatusInternalServerError, CodeCeremonyFailed},
+		{fmt.Errorf("keysign is cancelled: %w", context.Canceled), http.StatusServiceUnavailable, CodeCancelled},
+		{errors.New("disk failure"), http.StatusInternalServerError, CodeInternal},
+	}
+	for _, tc := range testCases {
+		status, code := errorStatus(tc.err)
+		c.Check(status, Equals, tc.status, Commentf("%s", tc.err))
+		c.Check(code, Equals, tc.code, Commentf("%s", tc.err))
+	}
+}
+
+func (ErrorsTestSuite) TestWriteServerError(c *C) {
+	s := NewTssHttpServer("127.0.0.1:8080", &MockTssServer{})
+	b := blame.NewBlame(blame.TssSyncFail, []blame.Node{{Pubkey: "A"}})
+	res := httptest.NewRecorder()
+	s.writeServerError(res, fmt.Errorf("fail to form the keygen party: %w", tss.ErrJoinPartyTimeout), b)
+	c.Assert(res.Code, Equals, http.StatusServiceUnavailable)
+	c.Assert(res.Header().Get("Content-Type"), Equals, "application/json")
+	var resp ErrorResponse
+	c.Assert(json.Unmarshal(res.Body.Bytes(), &resp), IsNil)
+	c.Assert(resp.Code, Equals, CodeJoinPartyTimeout)
+	c.Assert(resp.Blame, NotNil)
+	c.Assert(resp.Blame.FailReason, Equals, blame.TssSyncFail)
+	c.Assert(resp.Blame.BlameNodes, HasLen, 1)
+
+	// the errors which blame no node have no blame in the body
+	res = httptest.NewRecorder()
+	s.writeServerError(res, tss.ErrPoolNotFound, blame.Blame{})
+	c.Assert(res.Code, Equals, http.StatusNotFound)
+	resp = ErrorResponse{}
+	c.Assert(json.Unmarshal(res.Body.Bytes(), &resp), IsNil)
+	c.Assert(resp.Code, Equals, CodePoolNotFound)
+	c.Assert(resp.Blame, IsNil)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+// grpcRoutes are the routes of the grpc methods, a credential authorizes them like the http routes
+var grpcRoutes = map[string]string{
+	messages.TssService_Keygen_FullMethodName:     routeKeygen,
+	messages.TssService_Keysign_FullMethodName:    routeKeysign,
+	messages.TssService_KeyRegroup_FullMethodName: routeKeyRegroup,
+	messages.TssService_GetP2PID_FullMethodName:   routeP2pID,
+	messages.TssService_ListPools_FullMethodName:  routePools,
+}
+
+// grpcCall are the credentials a client presents in the metadata of a grpc call, a HMAC is checked once the request
+// is received
+type grpcCall struct {
+	credential *Credential
+	hmacKey    string
+	timestamp  string
+	signature  string
+	method     string
+}
+
+// authenticateCall finds the credential of the grpc call like the one of a http request, the token is sent in the
+// authorization metadata and the HMAC in the x-tss-* metadata. The HMAC signs the method POST, the full grpc method
+// and the deterministic protobuf encoding of the request.
+func (g *TssGrpcServer) authenticateCall(ctx context.Context, fullMethod string) (*grpcCall, error) {
+	route, ok := grpcRoutes[fullMethod]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "unknown method %s", fullMethod)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	call := &grpcCall{method: fullMethod}
+	var err error
+	switch {
+	case len(md.Get("authorization")) > 0:
+		call.credential, err = g.auth.authenticateToken(md.Get("authorization")[0])
+	case len(md.Get(hmacKeyHeader)) > 0:
+		call.hmacKey = md.Get(hmacKeyHeader)[0]
+		if len(md.Get(hmacTimestampHeader)) > 0 {
+			call.timestamp = md.Get(hmacTimestampHeader)[0]
+		}
+		if len(md.Get(hmacSignatureHeader)) > 0 {
+			call.signature = md.Get(hmacSignatureHeader)[0]
+		}
+		call.credential, err = g.auth.hmacCredential(call.hmacKey, call.timestamp)
+	default:
+		err = errUnauthenticated
+		if p, ok := peer.FromContext(ctx); ok {
+			if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+				call.credential, err = g.auth.authenticateCertificate(&info.State)
+			}
+		}
+	}
+	if err != nil {
+		g.logger.Error().Err(err).Msgf("fail to authenticate the call to %s", fullMethod)
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if !call.credential.allowsRoute(route) {
+		g.logger.Error().Msgf("credential %s may not use %s", call.credential.Name, route)
+		return nil, status.Errorf(codes.PermissionDenied, "the client may not use %s", route)
+	}
+	return call, nil
+}
+
+// verifyRequest checks the HMAC of the call with the received request
+func (c *grpcCall) verifyRequest(req interface{}) error {
+	if c.hmacKey == "" {
+		return nil
+	}
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if err := verifyHMAC(c.credential, c.signature, "POST", c.method, c.timestamp, body); err != nil {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	return nil
+}
+
+// unaryAuth authenticates the unary calls and makes sure the client may use the method, the pools are checked by the
+// methods as they are in the requests
+func (g *TssGrpcServer) unaryAuth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if g.auth == nil {
+		return handler(ctx, req)
+	}
+	call, err := g.authenticateCall(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := call.verifyRequest(req); err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, credentialKey{}, call.credential), req)
+}
+
+// streamAuth authenticates the streaming calls like unaryAuth, the HMAC is checked once the request is received
+func (g *TssGrpcServer) streamAuth(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if g.auth == nil {
+		return handler(srv, stream)
+	}
+	call, err := g.authenticateCall(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{
+		ServerStream: stream,
+		call:         call,
+		ctx:          context.WithValue(stream.Context(), credentialKey{}, call.credential),
+	})
+}
+
+// authenticatedStream carries the credential of the call in its context and checks the HMAC of the received request
+type authenticatedStream struct {
+	grpc.ServerStream
+	call *grpcCall
+	ctx  context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authenticatedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.call.verifyRequest(m)
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/keygen"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/messages"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+	"github.com/HyperCore-Team/go-tss/tss"
+)
+
+// TssGrpcServer provide the gRPC endpoint for tss server, it serves the same ceremonies as the TssHttpServer
+type TssGrpcServer struct {
+	messages.UnimplementedTssServiceServer
+	logger    zerolog.Logger
+	tssServer tss.Server
+	addr      string
+	s         *grpc.Server
+	auth      *authenticator
+	// clientCerts is set if the clients have to present a certificate signed by the client CA
+	clientCerts bool
+}
+
+// NewTssGrpcServer create a new gRPC server which listens on the given address, it only listens on the loopback as it
+// doesn't authenticate the clients
+func NewTssGrpcServer(addr string, t tss.Server) *TssGrpcServer {
+	gs, _ := NewTssGrpcServerWithAuth(addr, t, AuthConfig{})
+	return gs
+}
+
+// NewTssGrpcServerWithAuth create a gRPC server which authenticates the clients like the TssHttpServer, with the same
+// credentials and tls configuration, it may listen on other addresses than the loopback
+func NewTssGrpcServerWithAuth(addr string, t tss.Server, conf AuthConfig) (*TssGrpcServer, error) {
+	gs := &TssGrpcServer{
+		logger:    log.With().Str("module", "grpc").Logger(),
+		tssServer: t,
+		addr:      addr,
+	}
+	tlsConf, err := conf.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(gs.unaryAuth),
+		grpc.StreamInterceptor(gs.streamAuth),
+	}
+	if tlsConf != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConf)))
+		gs.clientCerts = tlsConf.ClientCAs != nil
+	}
+	if len(conf.Credentials) > 0 {
+		gs.auth, err = newAuthenticator(conf.Credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
+	gs.s = grpc.NewServer(opts...)
+	messages.RegisterTssServiceServer(gs.s, gs)
+	return gs, nil
+}
+
+// Start listens on the address of the server and serves until the server is stopped
+func (g *TssGrpcServer) Start() error {
+	if !isLoopback(g.addr) && g.auth == nil && !g.clientCerts {
+		return fmt.Errorf("refuse to listen on %s without authenticating the clients", g.addr)
+	}
+	if err := g.tssServer.Start(); err != nil {
+		return fmt.Errorf("fail to start tss server: %w", err)
+	}
+	lis, err := net.Listen("tcp", g.addr)
+	if err != nil {
+		return fmt.Errorf("fail to listen on %s: %w", g.addr, err)
+	}
+	return g.serve(lis)
+}
+
+func (g *TssGrpcServer) serve(lis net.Listener) error {
+	if err := g.s.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("fail to start grpc server: %w", err)
+	}
+	return nil
+}
+
+// Stop waits for the running calls to finish, they are aborted if they don't finish in time
+func (g *TssGrpcServer) Stop() {
+	stopped := make(chan struct{})
+	go func() {
+		g.s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		g.logger.Error().Msg("fail to stop the grpc server gracefully")
+		g.s.Stop()
+	}
+}
+
+func (g *TssGrpcServer) Keygen(req *messages.KeygenRequest, stream messages.TssService_KeygenServer) error {
+	g.logger.Info().Msg("receive key gen request")
+	algo, err := algoFromProto(req.GetAlgo())
+	if err != nil {
+		return err
+	}
+	keygenReq := keygen.NewRequest(req.GetKeys(), req.GetBlockHeight(), req.GetVersion(), algo)
+	keygenReq.Threshold = int(req.GetThreshold())
+	var resp keygen.Response
+	err = streamCeremony(stream.Context(), func(progress *messages.CeremonyProgress) error {
+		return stream.Send(&messages.KeygenUpdate{Update: &messages.KeygenUpdate_Progress{Progress: progress}})
+	}, func(ctx context.Context) (err error) {
+		resp, err = g.tssServer.KeygenWithContext(ctx, keygenReq)
+		return err
+	})
+	if err != nil {
+		g.logger.Error().Err(err).Msg("fail to key gen")
+	}
+	// the response carries the blame, so it is sent even if the keygen fails
+	if sendErr := stream.Send(&messages.KeygenUpdate{Update: &messages.KeygenUpdate_Response{Response: &messages.KeygenResponse{
+		PubKey:    resp.PubKey,
+		Status:    statusToProto(resp.Status),
+		Blame:     blameToProto(resp.Blame),
+		Threshold: int32(resp.Threshold),
+	}}}); sendErr != nil {
+		g.logger.Error().Err(sendErr).Msg("fail to send the response")
+		return sendErr
+	}
+	if err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+func (g *TssGrpcServer) Keysign(req *messages.KeysignRequest, stream messages.TssService_KeysignServer) error {
+	g.logger.Info().Msg("receive key sign request")
+	algo, err := algoFromProto(req.GetAlgo())
+	if err != nil {
+		return err
+	}
+	if !contextAllowsPool(stream.Context(), req.GetPoolPubKey()) {
+		g.logger.Error().Msgf("the client may not sign for pool %s", req.GetPoolPubKey())
+		return status.Error(codes.PermissionDenied, "the client may not use the pool")
+	}
+	msgs := make([]string, len(req.GetMessages()))
+	for i, el := range req.GetMessages() {
+		msgs[i] = base64.StdEncoding.EncodeToString(el)
+	}
+	keySignReq := keysign.NewRequest(req.GetPoolPubKey(), msgs, req.GetBlockHeight(), req.GetSignerPubKeys(), req.GetVersion(), algo)
+	keySignReq.DerivationPath = req.GetDerivationPath()
+	keySignReq.HashMode = common.HashMode(req.GetHashMode())
+	keySignReq.Encoding = keysign.Encoding(req.GetEncoding())
+	keySignReq.ChainID = req.GetChainID()
+	var resp keysign.Response
+	err = streamCeremony(stream.Context(), func(progress *messages.CeremonyProgress) error {
+		return stream.Send(&messages.KeysignUpdate{Update: &messages.KeysignUpdate_Progress{Progress: progress}})
+	}, func(ctx context.Context) (err error) {
+		resp, err = g.tssServer.KeySignWithContext(ctx, keySignReq)
+		return err
+	})
+	if err != nil {
+		g.logger.Error().Err(err).Msg("fail to key sign")
+		return grpcError(err)
+	}
+	signatures, err := signaturesToProto(resp.Signatures)
+	if err != nil {
+		g.logger.Error().Err(err).Msg("fail to decode the signatures")
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.Send(&messages.KeysignUpdate{Update: &messages.KeysignUpdate_Response{Response: &messages.KeysignResponse{
+		Signatures: signatures,
+		Status:     statusToProto(resp.Status),
+		Blame:      blameToProto(resp.Blame),
+	}}})
+}
+
+func (g *TssGrpcServer) KeyRegroup(req *messages.KeyRegroupRequest, stream messages.TssService_KeyRegroupServer) error {
+	g.logger.Info().Msg("receive key regroup request")
+	algo, err := algoFromProto(req.GetAlgo())
+	if err != nil {
+		return err
+	}
+	if !contextAllowsPool(stream.Context(), req.GetPoolPubKey()) {
+		g.logger.Error().Msgf("the client may not regroup pool %s", req.GetPoolPubKey())
+		return status.Error(codes.PermissionDenied, "the client may not use the pool")
+	}
+	keyRegroupReq := keyRegroup.NewRequest(req.GetPoolPubKey(), req.GetOldPartyKeys(), req.GetNewPartyKeys(), req.GetBlockHeight(), req.GetVersion(), algo)
+	keyRegroupReq.Threshold = int(req.GetThreshold())
+	keyRegroupReq.OldThreshold = int(req.GetOldThreshold())
+	var resp keyRegroup.Response
+	err = streamCeremony(stream.Context(), func(progress *messages.CeremonyProgress) error {
+		return stream.Send(&messages.KeyRegroupUpdate{Update: &messages.KeyRegroupUpdate_Progress{Progress: progress}})
+	}, func(ctx context.Context) (err error) {
+		resp, err = g.tssServer.KeyRegroupWithContext(ctx, keyRegroupReq)
+		return err
+	})
+	if err != nil {
+		g.logger.Error().Err(err).Msg("fail to key regroup")
+		return grpcError(err)
+	}
+	return stream.Send(&messages.KeyRegroupUpdate{Update: &messages.KeyRegroupUpdate_Response{Response: &messages.KeyRegroupResponse{
+		PubKey:      resp.PubKey,
+		PoolAddress: resp.PoolAddress,
+		Status:      statusToProto(resp.Status),
+		Blame:       blameToProto(resp.Blame),
+		Retirement:  resp.Retirement,
+	}}})
+}
+
+func (g *TssGrpcServer) GetP2PID(context.Context, *messages.GetP2PIDRequest) (*messages.GetP2PIDResponse, error) {
+	return &messages.GetP2PIDResponse{ID: g.tssServer.GetLocalPeerID()}, nil
+}
+
+func (g *TssGrpcServer) ListPools(ctx context.Context, _ *messages.ListPoolsRequest) (*messages.ListPoolsResponse, error) {
+	pools, err := g.tssServer.ListPools()
+	if err != nil {
+		g.logger.Error().Err(err).Msg("fail to list the pools")
+		return nil, grpcError(err)
+	}
+	resp := &messages.ListPoolsResponse{}
+	for _, el := range pools {
+		// the client only sees the pools it may use
+		if !contextAllowsPool(ctx, el.PubKey) {
+			continue
+		}
+		resp.Pools = append(resp.Pools, &messages.PoolInfo{
+			PubKey:          el.PubKey,
+			Algo:            algoToProto(el.Algo),
+			ParticipantKeys: el.ParticipantKeys,
+			LocalPartyKey:   el.LocalPartyKey,
+			Threshold:       int32(el.Threshold),
+		})
+	}
+	return resp, nil
+}
+
+// streamCeremony runs the ceremony and sends its progress until it is over, the progress is sent by the goroutine of
+// the call, as a stream must not be used by several goroutines at the same time
+func streamCeremony(ctx context.Context, send func(progress *messages.CeremonyProgress) error, run func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	updates := make(chan tss.Progress)
+	ceremonyCtx := tss.WithProgress(ctx, func(progress tss.Progress) {
+		select {
+		case updates <- progress:
+		case <-ctx.Done():
+		}
+	})
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ceremonyCtx)
+	}()
+	for {
+		select {
+		case progress := <-updates:
+			if err := send(progressToProto(progress)); err != nil {
+				// the client is gone, so the ceremony is cancelled
+				cancel()
+				<-done
+				return err
+			}
+		case err := <-done:
+			return err
+		}
+	}
+}
+
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, tss.ErrCeremonyQueueFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, tss.ErrPoolNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, tss.ErrInvalidRequest), errors.Is(err, tss.ErrInvalidPoolPubKey):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, tss.ErrSigningRejected):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, tss.ErrInsufficientSigners):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, tss.ErrJoinPartyTimeout):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, tss.ErrCeremonyTimeout), errors.Is(err, keysign.ErrSignatureTimeout):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, tss.ErrCeremonyFailed), errors.Is(err, keysign.ErrKeysignFailed):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func algoFromProto(algo messages.KeyAlgo) (string, error) {
+	switch algo {
+	case messages.KeyAlgo_ECDSA:
+		return "ecdsa", nil
+	case messages.KeyAlgo_EDDSA:
+		return "eddsa", nil
+	case messages.KeyAlgo_SCHNORR:
+		return "schnorr", nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "invalid algo %s", algo)
+}
+
+func algoToProto(algo string) messages.KeyAlgo {
+	switch algo {
+	case "ecdsa":
+		return messages.KeyAlgo_ECDSA
+	case "eddsa":
+		return messages.KeyAlgo_EDDSA
+	case "schnorr":
+		return messages.KeyAlgo_SCHNORR
+	}
+	return messages.KeyAlgo_UnknownAlgo
+}
+
+func statusToProto(s common.Status) messages.CeremonyStatus {
+	switch s {
+	case common.Success:
+		return messages.CeremonyStatus_Success
+	case common.Fail:
+		return messages.CeremonyStatus_Fail
+	}
+	return messages.CeremonyStatus_NA
+}
+
+func blameToProto(b blame.Blame) *messages.Blame {
+	pb := &messages.Blame{
+		FailReason: b.FailReason,
+		IsUnicast:  b.IsUnicast,
+		Round:      b.Round,
+	}
+	for _, el := range b.BlameNodes {
+		pb.BlameNodes = append(pb.BlameNodes, &messages.BlameNode{
+			PubKey:         el.Pubkey,
+			BlameData:      el.BlameData,
+			BlameSignature: el.BlameSignature,
+		})
+	}
+	return pb
+}
+
+func progressToProto(progress tss.Progress) *messages.CeremonyProgress {
+	pb := &messages.CeremonyProgress{
+		Round:  int32(progress.Round),
+		Rounds: int32(progress.Rounds),
+	}
+	switch progress.Phase {
+	case tss.PhaseQueued:
+		pb.CeremonyPhase = messages.CeremonyProgress_PhaseQueued
+	case tss.PhaseJoinParty:
+		pb.CeremonyPhase = messages.CeremonyProgress_PhaseJoinParty
+	case tss.PhaseRound:
+		pb.CeremonyPhase = messages.CeremonyProgress_PhaseRound
+	}
+	return pb
+}
+
+// signaturesToProto decodes the base64 encoded fields of the signatures
+func signaturesToProto(signatures []keysign.Signature) ([]*messages.Signature, error) {
+	var pbs []*messages.Signature
+	for _, el := range signatures {
+		var fields [6][]byte
+		for i, value := range []string{el.Msg, el.R, el.S, el.RecoveryID, el.Signature, el.Encoded} {
+			buf, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = buf
+		}
+		pbs = append(pbs, &messages.Signature{
+			Msg:        fields[0],
+			R:          fields[1],
+			S:          fields[2],
+			RecoveryID: fields[3],
+			Signature:  fields[4],
+			Encoded:    fields[5],
+		})
+	}
+	return pbs, nil
+}
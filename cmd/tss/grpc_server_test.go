@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/go-tss/tss"
+)
+
+const testPubKey = "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq"
+
+type TssGrpcServerTestSuite struct{}
+
+var _ = Suite(&TssGrpcServerTestSuite{})
+
+// startGrpcServer serves the tss server on an in-process listener, the returned func stops it
+func startGrpcServer(c *C, tssServer *MockTssServer) (messages.TssServiceClient, func()) {
+	return startGrpcServerWithAuth(c, tssServer, AuthConfig{})
+}
+
+// startGrpcServerWithAuth serves the tss server like startGrpcServer, the clients are authenticated with the given
+// configuration
+func startGrpcServerWithAuth(c *C, tssServer *MockTssServer, conf AuthConfig) (messages.TssServiceClient, func()) {
+	lis := bufconn.Listen(1024 * 1024)
+	s, err := NewTssGrpcServerWithAuth("", tssServer, conf)
+	c.Assert(err, IsNil)
+	go func() {
+		c.Check(s.serve(lis), IsNil)
+	}()
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	c.Assert(err, IsNil)
+	return messages.NewTssServiceClient(conn), func() {
+		c.Check(conn.Close(), IsNil)
+		s.Stop()
+	}
+}
+
+// ceremonyStream receives the updates of a ceremony until the stream is over, it returns the progress updates and
+// the error of the stream
+func ceremonyStream(recv func() (*messages.CeremonyProgress, bool, error)) ([]*messages.CeremonyProgress, bool, error) {
+	var progress []*messages.CeremonyProgress
+	gotResponse := false
+	for {
+		p, isResponse, err := recv()
+		if err == io.EOF {
+			return progress, gotResponse, nil
+		}
+		if err != nil {
+			return progress, gotResponse, err
+		}
+		if isResponse {
+			gotResponse = true
+			continue
+		}
+		progress = append(progress, p)
+	}
+}
+
+func (TssGrpcServerTestSuite) TestCeremonies(c *C) {
+	testCases := []struct {
+		name     string
+		setter   func(s *MockTssServer)
+		call     func(c *C, client messages.TssServiceClient) func() (*messages.CeremonyProgress, bool, error)
+		code     codes.Code
+		response bool
+	}{
+		{
+			name: "keygen",
+			call: func(c *C, client messages.TssServiceClient) func() (*messages.CeremonyProgress, bool, error) {
+				stream, err := client.Keygen(context.Background(), &messages.KeygenRequest{Keys: []string{"a", "b"}, Algo: messages.KeyAlgo_ECDSA})
+				c.Assert(err, IsNil)
+				return func() (*messages.CeremonyProgress, bool, error) {
+					update, err := stream.Recv()
+					if err != nil {
+						return nil, false, err
+					}
+					if resp := update.GetResponse(); resp != nil {
+						c.Assert(resp.GetStatus(), Equals, messages.CeremonyStatus_Success)
+						c.Assert(resp.GetPubKey(), Not(Equals), "")
+						return nil, true, nil
+					}
+					return update.GetProgress(), false, nil
+				}
+			},
+			code:     codes.OK,
+			response: true,
+		},
+		{
+			name: "failed keygen still sends the response",
+			setter: func(s *MockTssServer) {
+				s.failToKeyGen = true
+			},
+			call: func(c *C, client messages.TssServiceClient) func() (*messages.CeremonyProgress, bool, error) {
+				stream, err := client.Keygen(context.Background(), &messages.KeygenRequest{Algo: messages.KeyAlgo_EDDSA})
+				c.Assert(err, IsNil)
+				return func() (*messages.CeremonyProgress, bool, error) {
+					update, err := stream.Recv()
+					if err != nil {
+						return nil, false, err
+					}
+					return update.GetProgress(), update.GetResponse() != nil, nil
+				}
+			},
+			code:     codes.Internal,
+			response: true,
+		},
+		{
+			name: "keygen with invalid algo",
+			call: func(c *C, client messages.TssServiceClient) func() (*messages.CeremonyProgress, bool, error) {
+				stream, err := client.Keygen(context.Background(), &messages.KeygenRequest{})
+				c.Assert(err, IsNil)
+				return func() (*messages.CeremonyProgress, bool, error) {
+					update, err := stream.Recv()
+					if err != nil {
+						return nil, false, err
+					}
+					return update.GetProgress(), update.GetResponse() != nil, nil
+				}
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "keysign",
+			call: func(c *C, client messages.TssServiceClient) func() (*messages.CeremonyProgress, bool, error) {
+				stream, err := client.Keysign(context.Background(), &messages.KeysignRequest{
+					PoolPubKey: testPubKey,
+					Messages:   [][]byte{[]byte("hello")},
+					Algo:       messages.KeyAlgo_ECDSA,
+				})
+				c.Assert(err, IsNil)
+				return func() (*messages.CeremonyProgress, bool, error) {
+					update, err := stream.Recv()
+					if err != nil {
+						return nil, false, err
+					}
+					if resp := update.GetResponse(); resp != nil {
+						c.Assert(resp.GetSignatures(), HasLen, 1)
+						return nil, true, nil
+					}
+					return update.GetProgress(), false, nil
+				}
+			},
+			code:     codes.OK,
+			response: true,
+		},
+		{
+			name: "failed keysign",
+			setter: func(s *MockTssServer) {
+				s.failToKeySign = true
+			},
+			call: func(c *C, client messages.TssServiceClient) func() (*messages.CeremonyProgress, bool, error) {
+				stream, err := client.Keysign(context.Background(), &messages.KeysignRequest{Algo: messages.KeyAlgo_EDDSA})
+				c.Assert(err, IsNil)
+				return func() (*messages.CeremonyProgress, bool, error) {
+					update, err := stream.Recv()
+					if err != nil {
+						return nil, false, err
+					}
+					return update.GetProgress(), update.GetResponse() != nil, nil
+				}
+			},
+			code: codes.Internal,
+		},
+		{
+			name: "keysign rejected by the signing policy",
+			setter: func(s *MockTssServer) {
+				s.rejectKeySign = true
+			},
+			call: func(c *C, client messages.TssServiceClient) func() (*messages.CeremonyProgress, bool, error) {
+				stream, err := client.Keysign(context.Background(), &messages.KeysignRequest{Algo: messages.KeyAlgo_ECDSA})
+				c.Assert(err, IsNil)
+				return func() (*messages.CeremonyProgress, bool, error) {
+					update, err := stream.Recv()
+					if err != nil {
+						return nil, false, err
+					}
+					return update.GetProgress(), update.GetResponse() != nil, nil
+				}
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "key regroup",
+			call: func(c *C, client messages.TssServiceClient) func() (*messages.CeremonyProgress, bool, error) {
+				stream, err := client.KeyRegroup(context.Background(), &messages.KeyRegroupRequest{Algo: messages.KeyAlgo_ECDSA})
+				c.Assert(err, IsNil)
+				return func() (*messages.CeremonyProgress, bool, error) {
+					update, err := stream.Recv()
+					if err != nil {
+						return nil, false, err
+					}
+					if resp := update.GetResponse(); resp != nil {
+						c.Assert(resp.GetStatus(), Equals, messages.CeremonyStatus_Success)
+						return nil, true, nil
+					}
+					return update.GetProgress(), false, nil
+				}
+			},
+			code:     codes.OK,
+			response: true,
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		tssServer := &MockTssServer{}
+		if tc.setter != nil {
+			tc.setter(tssServer)
+		}
+		client, stop := startGrpcServer(c, tssServer)
+		progress, gotResponse, err := ceremonyStream(tc.call(c, client))
+		c.Assert(status.Code(err), Equals, tc.code)
+		c.Assert(gotResponse, Equals, tc.response)
+		if tc.code != codes.InvalidArgument {
+			c.Assert(progress, HasLen, 2)
+			c.Assert(progress[0].GetCeremonyPhase(), Equals, messages.CeremonyProgress_PhaseJoinParty)
+			c.Assert(progress[1].GetCeremonyPhase(), Equals, messages.CeremonyProgress_PhaseRound)
+			c.Assert(progress[1].GetRound(), Equals, int32(1))
+		}
+		stop()
+	}
+}
+
+func (TssGrpcServerTestSuite) TestCancelledCeremony(c *C) {
+	tssServer := &MockTssServer{}
+	client, stop := startGrpcServer(c, tssServer)
+	defer stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.Keygen(ctx, &messages.KeygenRequest{Algo: messages.KeyAlgo_ECDSA})
+	c.Assert(status.Code(err), Equals, codes.Canceled)
+}
+
+func (TssGrpcServerTestSuite) TestGetP2PIDAndListPools(c *C) {
+	tssServer := &MockTssServer{
+		pools: []tss.PoolInfo{
+			{PubKey: testPubKey, Algo: "ecdsa", ParticipantKeys: []string{"a", "b", "c"}, LocalPartyKey: "a", Threshold: 1},
+		},
+	}
+	client, stop := startGrpcServer(c, tssServer)
+	defer stop()
+
+	_, err := client.GetP2PID(context.Background(), &messages.GetP2PIDRequest{})
+	c.Assert(err, IsNil)
+
+	pools, err := client.ListPools(context.Background(), &messages.ListPoolsRequest{})
+	c.Assert(err, IsNil)
+	c.Assert(pools.GetPools(), HasLen, 1)
+	pool := pools.GetPools()[0]
+	c.Assert(pool.GetPubKey(), Equals, testPubKey)
+	c.Assert(pool.GetAlgo(), Equals, messages.KeyAlgo_ECDSA)
+	c.Assert(pool.GetParticipantKeys(), DeepEquals, []string{"a", "b", "c"})
+	c.Assert(pool.GetThreshold(), Equals, int32(1))
+}
+
+// hmacContext signs the request of the grpc method with the given secret
+func hmacContext(c *C, key, secret, method string, req proto.Message) context.Context {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	c.Assert(err, IsNil)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return metadata.AppendToOutgoingContext(context.Background(),
+		hmacKeyHeader, key,
+		hmacTimestampHeader, timestamp,
+		hmacSignatureHeader, hex.EncodeToString(signRequest([]byte(secret), "POST", method, timestamp, body)))
+}
+
+func (TssGrpcServerTestSuite) TestAuth(c *C) {
+	const otherPubKey = "A1oYI5jmGmnnTwdnfMdwn3cdN2E0pkp+s0HFkDKoOJAe"
+	tssServer := &MockTssServer{
+		pools: []tss.PoolInfo{{PubKey: testPubKey, Algo: "ecdsa"}, {PubKey: otherPubKey, Algo: "ecdsa"}},
+	}
+	client, stop := startGrpcServerWithAuth(c, tssServer, AuthConfig{Credentials: []Credential{
+		{Name: "admin", Token: "admin-token", Routes: []string{routeAll}},
+		{Name: "regroup", Token: "regroup-token", Routes: []string{routeKeyRegroup, routePools}, Pools: []string{testPubKey}},
+		{Name: "hmac", HMACSecret: "hmac-secret", Routes: []string{routePools}},
+	}})
+	defer stop()
+	bearer := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	}
+	regroup := func(ctx context.Context, poolPubKey string) error {
+		stream, err := client.KeyRegroup(ctx, &messages.KeyRegroupRequest{PoolPubKey: poolPubKey, Algo: messages.KeyAlgo_ECDSA})
+		c.Assert(err, IsNil)
+		_, _, err = ceremonyStream(func() (*messages.CeremonyProgress, bool, error) {
+			update, err := stream.Recv()
+			if err != nil {
+				return nil, false, err
+			}
+			return update.GetProgress(), update.GetResponse() != nil, nil
+		})
+		return err
+	}
+
+	_, err := client.GetP2PID(context.Background(), &messages.GetP2PIDRequest{})
+	c.Assert(status.Code(err), Equals, codes.Unauthenticated)
+	_, err = client.GetP2PID(bearer("whatever"), &messages.GetP2PIDRequest{})
+	c.Assert(status.Code(err), Equals, codes.Unauthenticated)
+	_, err = client.GetP2PID(bearer("admin-token"), &messages.GetP2PIDRequest{})
+	c.Assert(err, IsNil)
+	// the credential doesn't allow the route
+	_, err = client.GetP2PID(bearer("regroup-token"), &messages.GetP2PIDRequest{})
+	c.Assert(status.Code(err), Equals, codes.PermissionDenied)
+
+	// the client only sees the pools it may use
+	pools, err := client.ListPools(bearer("regroup-token"), &messages.ListPoolsRequest{})
+	c.Assert(err, IsNil)
+	c.Assert(pools.GetPools(), HasLen, 1)
+	c.Assert(pools.GetPools()[0].GetPubKey(), Equals, testPubKey)
+	pools, err = client.ListPools(bearer("admin-token"), &messages.ListPoolsRequest{})
+	c.Assert(err, IsNil)
+	c.Assert(pools.GetPools(), HasLen, 2)
+
+	c.Assert(regroup(bearer("regroup-token"), testPubKey), IsNil)
+	c.Assert(status.Code(regroup(bearer("regroup-token"), otherPubKey)), Equals, codes.PermissionDenied)
+	c.Assert(status.Code(regroup(context.Background(), testPubKey)), Equals, codes.Unauthenticated)
+
+	req := &messages.ListPoolsRequest{}
+	pools, err = client.ListPools(hmacContext(c, "hmac", "hmac-secret", messages.TssService_ListPools_FullMethodName, req), req)
+	c.Assert(err, IsNil)
+	c.Assert(pools.GetPools(), HasLen, 2)
+	_, err = client.ListPools(hmacContext(c, "hmac", "wrong-secret", messages.TssService_ListPools_FullMethodName, req), req)
+	c.Assert(status.Code(err), Equals, codes.Unauthenticated)
+	// the signature of another request doesn't authenticate the regroup
+	signed := &messages.KeyRegroupRequest{PoolPubKey: otherPubKey, Algo: messages.KeyAlgo_ECDSA}
+	ctx := hmacContext(c, "hmac", "hmac-secret", messages.TssService_KeyRegroup_FullMethodName, signed)
+	c.Assert(status.Code(regroup(ctx, testPubKey)), Equals, codes.PermissionDenied)
+}
+
+func (TssGrpcServerTestSuite) TestOpenGrpcApiOnlyListensOnLoopback(c *C) {
+	s := NewTssGrpcServer("0.0.0.0:8080", &MockTssServer{})
+	c.Assert(s.Start(), NotNil)
+
+	s, err := NewTssGrpcServerWithAuth("0.0.0.0:0", &MockTssServer{}, AuthConfig{Credentials: []Credential{
+		{Name: "admin", Token: "admin-token", Routes: []string{routeAll}},
+	}})
+	c.Assert(err, IsNil)
+	started := make(chan error, 1)
+	go func() {
+		started <- s.Start()
+	}()
+	s.Stop()
+	c.Assert(<-started, IsNil)
+
+	_, err = NewTssGrpcServerWithAuth("127.0.0.1:0", &MockTssServer{}, AuthConfig{ClientCAFile: "ca.pem"})
+	c.Assert(err, NotNil)
+}
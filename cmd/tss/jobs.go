@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/tss"
+)
+
+const (
+	// jobRetention is how long the status of a finished job can be polled
+	jobRetention = time.Hour
+	// callbackTimeout is how long the delivery of the result to the callback url may take
+	callbackTimeout = 10 * time.Second
+	// callbackAttempts is how often we try to deliver the result to the callback url
+	callbackAttempts = 3
+	// callbackRetryDelay is how long we wait before the result is delivered again
+	callbackRetryDelay = 2 * time.Second
+)
+
+// JobStatus is the status of a ceremony which runs in the background, the response is set once the job is done or
+// failed
+type JobStatus struct {
+	ID        string `json:"id"`
+	Operation string `json:"operation"`
+	tss.Progress
+	Response interface{} `json:"response,omitempty"`
+	Error    string      `json:"error,omitempty"`
+	// Code is the code of the error, it is the code the failed request would have in its error body
+	Code      ErrorCode `json:"code,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// owner is the name of the credential which started the job, it is empty if the api is open
+	owner string
+}
+
+func (s JobStatus) finished() bool {
+	return s.Phase == tss.PhaseDone || s.Phase == tss.PhaseFailed
+}
+
+// jobFunc runs the ceremony of a job, the status tells whether the ceremony succeeded
+type jobFunc func(ctx context.Context) (interface{}, common.Status, error)
+
+// jobManager runs the ceremonies the clients don't want to wait for, the clients poll their status or are told
+// the result on their callback url
+type jobManager struct {
+	logger zerolog.Logger
+	client *http.Client
+	lock   *sync.Mutex
+	jobs   map[string]*JobStatus
+	wg     *sync.WaitGroup
+	// ctx is cancelled once the server stops, it aborts the running jobs and the delivery of their results
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newJobManager(logger zerolog.Logger) *jobManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &jobManager{
+		logger: logger,
+		client: &http.Client{Timeout: callbackTimeout},
+		lock:   &sync.Mutex{},
+		jobs:   make(map[string]*JobStatus),
+		wg:     &sync.WaitGroup{},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func newJobID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("fail to generate the job id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// parseCallback validates the callback url given by the client, an empty url is valid
+func parseCallback(callback string) error {
+	if callback == "" {
+		return nil
+	}
+	u, err := url.ParseRequestURI(callback)
+	if err != nil {
+		return fmt.Errorf("invalid callback url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid callback url %s", callback)
+	}
+	return nil
+}
+
+// start runs the ceremony in the background and returns the status of the new job
+func (m *jobManager) start(operation, callback, owner string, run jobFunc) (JobStatus, error) {
+	id, err := newJobID()
+	if err != nil {
+		return JobStatus{}, err
+	}
+	now := time.Now().UTC()
+	job := &JobStatus{
+		ID:        id,
+		Operation: operation,
+		owner:     owner,
+		Progress:  tss.Progress{Phase: tss.PhaseQueued},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	m.lock.Lock()
+	m.prune(now)
+	m.jobs[id] = job
+	status := *job
+	m.lock.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ctx := tss.WithProgress(m.ctx, func(progress tss.Progress) {
+			m.update(id, progress)
+		})
+		resp, ceremonyStatus, err := run(ctx)
+		final := m.finish(id, resp, ceremonyStatus, err)
+		m.logger.Info().Msgf("the %s job %s is %s", operation, id, final.Phase)
+		if callback != "" {
+			m.notify(callback, final)
+		}
+	}()
+	return status, nil
+}
+
+// get returns the status of the given job
+func (m *jobManager) get(id string) (JobStatus, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return JobStatus{}, false
+	}
+	return *job, true
+}
+
+func (m *jobManager) update(id string, progress tss.Progress) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job, ok := m.jobs[id]
+	if !ok || job.finished() {
+		return
+	}
+	job.Progress = progress
+	job.UpdatedAt = time.Now().UTC()
+}
+
+func (m *jobManager) finish(id string, resp interface{}, status common.Status, err error) JobStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job := m.jobs[id]
+	job.Progress = tss.Progress{Phase: tss.PhaseDone}
+	if err != nil || status != common.Success {
+		job.Phase = tss.PhaseFailed
+	}
+	if err != nil {
+		job.Error = err.Error()
+		_, job.Code = errorStatus(err)
+	}
+	job.Response = resp
+	job.UpdatedAt = time.Now().UTC()
+	return *job
+}
+
+// prune removes the jobs which finished before the retention, the lock has to be held
+func (m *jobManager) prune(now time.Time) {
+	for id, job := range m.jobs {
+		if job.finished() && now.Sub(job.UpdatedAt) > jobRetention {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// notify posts the final status of the job to the callback url
+func (m *jobManager) notify(callback string, status JobStatus) {
+	buf, err := json.Marshal(status)
+	if err != nil {
+		m.logger.Error().Err(err).Msgf("fail to marshal the status of job %s", status.ID)
+		return
+	}
+	for i := 0; i < callbackAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-time.After(callbackRetryDelay):
+			}
+		}
+		err = m.post(callback, buf)
+		if err == nil {
+			return
+		}
+		m.logger.Error().Err(err).Msgf("fail to deliver the result of job %s to the callback url", status.ID)
+	}
+}
+
+func (m *jobManager) post(callback string, body []byte) error {
+	req, err := http.NewRequestWithContext(m.ctx, http.MethodPost, callback, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			m.logger.Error().Err(err).Msg("fail to close the callback response body")
+		}
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the callback url responds with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// stop aborts the running jobs and waits until they are over
+func (m *jobManager) stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// startJob runs the ceremony as a job if the client asks for it with the async or callback query parameter, it
+// returns false if the ceremony has to run while the client waits
+func (t *TssHttpServer) startJob(w http.ResponseWriter, r *http.Request, operation string, run jobFunc) bool {
+	query := r.URL.Query()
+	callback := query.Get("callback")
+	async := callback != ""
+	if value := query.Get("async"); value != "" {
+		var err error
+		async, err = strconv.ParseBool(value)
+		if err != nil || (!async && callback != "") {
+			t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, "invalid async, the callback url runs the ceremony as a job")
+			return true
+		}
+	}
+	if !async {
+		return false
+	}
+	if err := parseCallback(callback); err != nil {
+		t.logger.Error().Err(err).Msgf("fail to start the %s job", operation)
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+		return true
+	}
+	owner := ""
+	if c := requestCredential(r); c != nil {
+		owner = c.Name
+	}
+	status, err := t.jobs.start(operation, callback, owner, run)
+	if err != nil {
+		t.logger.Error().Err(err).Msgf("fail to start the %s job", operation)
+		t.writeServerError(w, err, blame.Blame{})
+		return true
+	}
+	t.logger.Info().Msgf("the %s runs as job %s", operation, status.ID)
+	w.WriteHeader(http.StatusAccepted)
+	t.writeJSON(w, status)
+	return true
+}
+
+func (t *TssHttpServer) getJobHandler(w http.ResponseWriter, r *http.Request) {
+	status, ok := t.jobs.get(mux.Vars(r)["id"])
+	// the jobs of other clients are not found, unless the client may use all the pools
+	if c := requestCredential(r); ok && c != nil && c.Name != status.owner && len(c.Pools) > 0 {
+		ok = false
+	}
+	if !ok {
+		t.writeError(w, http.StatusNotFound, CodeNotFound, "job not found")
+		return
+	}
+	t.writeJSON(w, status)
+}
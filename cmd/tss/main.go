@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"github.com/HyperCore-Team/go-tss/messages"
 	maddr "github.com/multiformats/go-multiaddr"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,18 +18,30 @@ import (
 	"gitlab.com/thorchain/binance-sdk/common/types"
 	"golang.org/x/term"
 
+	"github.com/HyperCore-Team/go-tss/audit"
 	"github.com/HyperCore-Team/go-tss/common"
 	"github.com/HyperCore-Team/go-tss/conversion"
 	"github.com/HyperCore-Team/go-tss/p2p"
+	"github.com/HyperCore-Team/go-tss/storage"
 	"github.com/HyperCore-Team/go-tss/tss"
 )
 
 var (
-	help       bool
-	logLevel   string
-	pretty     bool
-	baseFolder string
-	tssAddr    string
+	help         bool
+	logLevel     string
+	pretty       bool
+	baseFolder   string
+	tssAddr      string
+	grpcAddr     string
+	authFile     string
+	authConf     AuthConfig
+	signPools    string
+	signMaxMsgs  int
+	signRate     int
+	signWindow   time.Duration
+	auditFile    string
+	encryptState bool
+	stateBackend string
 )
 
 func main() {
@@ -57,6 +72,41 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	var opts tss.Options
+	if encryptState {
+		fmt.Println("input key share passphrase:")
+		passphrase, err := term.ReadPassword(syscall.Stdin)
+		if err != nil {
+			fmt.Printf("error in get the key share passphrase: %s\n", err.Error())
+			return
+		}
+		opts.StatePassphrase = passphrase
+	}
+	opts.SigningPolicy = signingPolicy()
+	if auditFile != "" {
+		if !filepath.IsAbs(auditFile) {
+			auditFile = filepath.Join(baseFolder, auditFile)
+		}
+		opts.AuditLog, err = audit.Open(auditFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	switch stateBackend {
+	case "file":
+	case "kv":
+		dbPath := filepath.Join(baseFolder, "localstate.db")
+		if len(opts.StatePassphrase) > 0 {
+			opts.StateManager, err = storage.NewEncryptedKVStateMgr(dbPath, opts.StatePassphrase)
+		} else {
+			opts.StateManager, err = storage.NewKVStateMgr(dbPath)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("unknown state backend %s", stateBackend)
+	}
 	// init tss module
 	tss, err := tss.NewTss(
 		[]maddr.Multiaddr(p2pConf.BootstrapPeers),
@@ -69,36 +119,93 @@ func main() {
 		p2pConf.ExternalIP,
 		messages.EDDSAKEYGEN,
 		make(map[string]bool),
+		opts,
 	)
 	if nil != err {
 		log.Fatal(err)
 	}
-	s := NewTssHttpServer(tssAddr, tss)
+	if authFile != "" {
+		authConf.Credentials, err = LoadCredentials(authFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	s, err := NewTssHttpServerWithAuth(tssAddr, tss, authConf)
+	if err != nil {
+		log.Fatal(err)
+	}
 	go func() {
 		if err := s.Start(); err != nil {
 			fmt.Println(err)
 		}
 	}()
+	var gs *TssGrpcServer
+	if grpcAddr != "" {
+		// the grpc api authenticates the clients with the same credentials and certificates as the http api
+		gs, err = NewTssGrpcServerWithAuth(grpcAddr, tss, authConf)
+		if err != nil {
+			log.Fatal(err)
+		}
+		go func() {
+			if err := gs.Start(); err != nil {
+				fmt.Println(err)
+			}
+		}()
+	}
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
 	<-ch
+	if gs != nil {
+		gs.Stop()
+	}
 	fmt.Println(s.Stop())
+	if opts.AuditLog != nil {
+		if err := opts.AuditLog.Close(); err != nil {
+			fmt.Printf("fail to close the audit log: %s\n", err.Error())
+		}
+	}
+	if closer, ok := opts.StateManager.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			fmt.Printf("fail to close the state backend: %s\n", err.Error())
+		}
+	}
 }
 
 // parseFlags - Parses the cli flags
 func parseFlags() (tssConf common.TssConfig, p2pConf p2p.Config) {
 	// we setup the configure for the general configuration
 	flag.StringVar(&tssAddr, "tss-port", "127.0.0.1:8080", "tss port")
+	flag.StringVar(&grpcAddr, "grpc-port", "", "tss grpc port, the grpc server is disabled if it is not set")
+	flag.StringVar(&authConf.TLSCertFile, "tls-cert", "", "tls certificate of the http and grpc api, they are served without tls if not set")
+	flag.StringVar(&authConf.TLSKeyFile, "tls-key", "", "tls private key of the http and grpc api")
+	flag.StringVar(&authConf.ClientCAFile, "tls-client-ca", "", "CA of the client certificates, the clients have to present a certificate signed by it if set")
+	flag.StringVar(&authFile, "auth-file", "", "json file of the credentials of the http and grpc api clients, the apis are open if not set")
 	flag.BoolVar(&help, "h", false, "Display Help")
 	flag.StringVar(&logLevel, "loglevel", "info", "Log Level")
 	flag.BoolVar(&pretty, "pretty-log", false, "Enables unstructured prettified logging. This is useful for local debugging")
 	flag.StringVar(&baseFolder, "home", "", "home folder to store the keygen state file")
+	flag.BoolVar(&encryptState, "encrypt-state", false, "encrypt the keygen state file with a passphrase read from stdin")
+	flag.StringVar(&signPools, "sign-pools", "", "comma separated pool pub keys which may sign, all the pools may sign if it is not set")
+	flag.IntVar(&signMaxMsgs, "sign-max-messages", 0, "how many messages a keysign request may sign, 0 doesn't limit them")
+	flag.IntVar(&signRate, "sign-rate", 0, "how many keysign requests a pool may sign in the sign-window, 0 doesn't limit them")
+	flag.DurationVar(&signWindow, "sign-window", time.Minute, "the window of the sign-rate")
+	flag.StringVar(&auditFile, "audit-log", "tss.audit.log", "audit log of the ceremonies, relative to the home folder, it is disabled if empty")
+	flag.StringVar(&stateBackend, "state-backend", "file", "where to store the keygen state: file (one file per pool) or kv (single database file)")
 
 	// we setup the Tss parameter configuration
 	flag.DurationVar(&tssConf.KeyGenTimeout, "gentimeout", 30*time.Second, "keygen timeout")
 	flag.DurationVar(&tssConf.KeySignTimeout, "signtimeout", 30*time.Second, "keysign timeout")
 	flag.DurationVar(&tssConf.PreParamTimeout, "preparamtimeout", 5*time.Minute, "pre-parameter generation timeout")
 	flag.BoolVar(&tssConf.EnableMonitor, "enablemonitor", true, "enable the tss monitor")
+	flag.Var((*retirementPolicyFlag)(&tssConf.RetirementPolicy), "retirement-policy", "what to do with the old key share once we leave a regrouped pool: archive or wipe, it is kept if not set, wipe isn't supported by the kv state backend")
+	flag.DurationVar(&tssConf.RetirementDelay, "retirement-delay", 0, "how long to wait after a regroup before the old key share is retired, 0 waits for an explicit confirmation")
+	flag.IntVar(&tssConf.MaxConcurrentCeremonies, "max-ceremonies", 1, "how many keygens, regroups and refreshes run at the same time")
+	flag.IntVar(&tssConf.PreParamsPoolSize, "preparams-pool", 2, "how many pre-parameters are generated ahead of the ECDSA keygens, a negative number generates them when a keygen needs them")
+	flag.IntVar(&tssConf.CeremonyQueueSize, "ceremony-queue", 0, "how many keygens, regroups and refreshes may wait for a free slot, 0 doesn't limit the queue")
+	flag.IntVar(&tssConf.BatchKeySignWorkers, "batch-workers", 4, "how many keysigns of a batch run at the same time, the nodes should use the same number")
+	flag.IntVar(&tssConf.SignatureCacheSize, "signature-cache", 1000, "how many signatures of the recent keysigns are kept, a restarted node answers the keysigns it finished from them, 0 keeps none")
+	flag.DurationVar(&tssConf.SignatureCacheTTL, "signature-ttl", 24*time.Hour, "how long the signatures of the keysigns are kept")
+	flag.DurationVar(&tssConf.KeySignCacheWindow, "keysign-cache-window", 10*time.Minute, "how long the signatures of a keysign answer the same request again without a new ceremony, 0 doesn't cache them")
 
 	// we setup the p2p network configuration
 	flag.StringVar(&p2pConf.RendezvousString, "rendezvous", "Asgard",
@@ -109,3 +216,38 @@ func parseFlags() (tssConf common.TssConfig, p2pConf p2p.Config) {
 	flag.Parse()
 	return
 }
+
+// signingPolicy builds the signing policy from the cli, it is nil if no policy is set
+func signingPolicy() tss.SigningPolicy {
+	var policies []tss.SigningPolicy
+	if signPools != "" {
+		policies = append(policies, tss.PoolAllowlistPolicy(strings.Split(signPools, ",")...))
+	}
+	if signMaxMsgs > 0 {
+		policies = append(policies, tss.MaxMessagesPolicy(signMaxMsgs))
+	}
+	// the rate limit goes last, so it doesn't count the requests rejected by the other policies
+	if signRate > 0 {
+		policies = append(policies, tss.NewRateLimitPolicy(signRate, signWindow))
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	return tss.Policies(policies...)
+}
+
+// retirementPolicyFlag parses the retirement policy from the cli
+type retirementPolicyFlag common.RetirementPolicy
+
+func (f *retirementPolicyFlag) String() string {
+	return string(*f)
+}
+
+func (f *retirementPolicyFlag) Set(value string) error {
+	switch common.RetirementPolicy(value) {
+	case common.RetireNone, common.RetireArchive, common.RetireWipe:
+		*f = retirementPolicyFlag(value)
+		return nil
+	}
+	return fmt.Errorf("unknown retirement policy %s", value)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 
 	"github.com/HyperCore-Team/go-tss/blame"
@@ -8,12 +9,23 @@ import (
 	"github.com/HyperCore-Team/go-tss/conversion"
 	"github.com/HyperCore-Team/go-tss/keygen"
 	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/refresh"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+	"github.com/HyperCore-Team/go-tss/tss"
 )
 
 type MockTssServer struct {
-	failToStart   bool
-	failToKeyGen  bool
-	failToKeySign bool
+	failToStart      bool
+	failToKeyGen     bool
+	failToKeySign    bool
+	failToKeyRegroup bool
+	failToRefresh    bool
+	rejectKeySign    bool
+	// cancelled records whether the context of the last ceremony was done
+	cancelled  bool
+	pools      []tss.PoolInfo
+	preParams  tss.PreParamsInfo
+	signatures []tss.SignatureInfo
 }
 
 func (mts *MockTssServer) Start() error {
@@ -30,7 +42,22 @@ func (mts *MockTssServer) GetLocalPeerID() string {
 	return conversion.GetRandomPeerID().String()
 }
 
+// reportProgress pretends the ceremony joins the party and runs its first round
+func (mts *MockTssServer) reportProgress(ctx context.Context) {
+	tss.ReportProgress(ctx, tss.Progress{Phase: tss.PhaseJoinParty})
+	tss.ReportProgress(ctx, tss.Progress{Phase: tss.PhaseRound, Round: 1, Rounds: 1})
+}
+
 func (mts *MockTssServer) Keygen(req keygen.Request) (keygen.Response, error) {
+	return mts.KeygenWithContext(context.Background(), req)
+}
+
+func (mts *MockTssServer) KeygenWithContext(ctx context.Context, req keygen.Request) (keygen.Response, error) {
+	mts.cancelled = ctx.Err() != nil
+	if mts.cancelled {
+		return keygen.Response{}, ctx.Err()
+	}
+	mts.reportProgress(ctx)
 	if mts.failToKeyGen {
 		return keygen.Response{}, errors.New("you ask for it")
 	}
@@ -38,9 +65,117 @@ func (mts *MockTssServer) Keygen(req keygen.Request) (keygen.Response, error) {
 }
 
 func (mts *MockTssServer) KeySign(req keysign.Request) (keysign.Response, error) {
+	return mts.KeySignWithContext(context.Background(), req)
+}
+
+func (mts *MockTssServer) KeySignWithContext(ctx context.Context, req keysign.Request) (keysign.Response, error) {
+	mts.cancelled = ctx.Err() != nil
+	if mts.cancelled {
+		return keysign.Response{}, ctx.Err()
+	}
+	mts.reportProgress(ctx)
 	if mts.failToKeySign {
 		return keysign.Response{}, errors.New("you ask for it")
 	}
+	if mts.rejectKeySign {
+		return keysign.Response{}, &tss.PolicyRejectedError{Policy: "pool_allowlist", Reason: "you ask for it"}
+	}
 	newSig := keysign.NewSignature("", "", "", "", "")
 	return keysign.NewResponse([]keysign.Signature{newSig}, common.Success, blame.Blame{}), nil
 }
+
+func (mts *MockTssServer) KeySignBatch(req keysign.BatchRequest) (keysign.BatchResponse, error) {
+	return mts.KeySignBatchWithContext(context.Background(), req)
+}
+
+func (mts *MockTssServer) KeySignBatchWithContext(ctx context.Context, req keysign.BatchRequest) (keysign.BatchResponse, error) {
+	if err := ctx.Err(); err != nil {
+		mts.cancelled = true
+		return keysign.BatchResponse{}, err
+	}
+	var results []keysign.BatchResult
+	for _, el := range req.Requests {
+		resp, err := mts.KeySignWithContext(ctx, el)
+		results = append(results, keysign.BatchResult{PoolPubKey: el.PoolPubKey, Response: resp, Err: err})
+	}
+	return keysign.NewBatchResponse(results), nil
+}
+
+func (mts *MockTssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, error) {
+	return mts.KeyRegroupWithContext(context.Background(), req)
+}
+
+func (mts *MockTssServer) KeyRegroupWithContext(ctx context.Context, req keyRegroup.Request) (keyRegroup.Response, error) {
+	mts.cancelled = ctx.Err() != nil
+	if mts.cancelled {
+		return keyRegroup.Response{}, ctx.Err()
+	}
+	mts.reportProgress(ctx)
+	if mts.failToKeyRegroup {
+		return keyRegroup.Response{}, errors.New("you ask for it")
+	}
+	return keyRegroup.NewResponse(conversion.GetRandomPubKey(), "", common.Success, blame.Blame{}), nil
+}
+
+func (mts *MockTssServer) ConfirmRegroup(poolPubKey string) error {
+	for _, el := range mts.pools {
+		if el.PubKey == poolPubKey {
+			return nil
+		}
+	}
+	return tss.ErrPoolNotFound
+}
+
+func (mts *MockTssServer) Refresh(req refresh.Request) (refresh.Response, error) {
+	return mts.RefreshWithContext(context.Background(), req)
+}
+
+func (mts *MockTssServer) RefreshWithContext(ctx context.Context, req refresh.Request) (refresh.Response, error) {
+	mts.cancelled = ctx.Err() != nil
+	if mts.cancelled {
+		return refresh.Response{}, ctx.Err()
+	}
+	if mts.failToRefresh {
+		return refresh.Response{}, errors.New("you ask for it")
+	}
+	return refresh.NewResponse(req.PoolPubKey, common.Success, blame.Blame{}), nil
+}
+
+func (mts *MockTssServer) ListPools() ([]tss.PoolInfo, error) {
+	return mts.pools, nil
+}
+
+func (mts *MockTssServer) PreParamsInfo() tss.PreParamsInfo {
+	return mts.preParams
+}
+
+func (mts *MockTssServer) GetPool(pubKey string) (tss.PoolInfo, error) {
+	if pubKey == "invalid" {
+		return tss.PoolInfo{}, tss.ErrInvalidPoolPubKey
+	}
+	for _, el := range mts.pools {
+		if el.PubKey == pubKey {
+			return el, nil
+		}
+	}
+	return tss.PoolInfo{}, tss.ErrPoolNotFound
+}
+
+func (mts *MockTssServer) GetSignatures(msgID string) (tss.SignatureInfo, error) {
+	for _, el := range mts.signatures {
+		if el.MsgID == msgID {
+			return el, nil
+		}
+	}
+	return tss.SignatureInfo{}, tss.ErrSignaturesNotFound
+}
+
+func (mts *MockTssServer) DeletePool(pubKey string, archive bool) error {
+	for i, el := range mts.pools {
+		if el.PubKey == pubKey {
+			mts.pools = append(mts.pools[:i], mts.pools[i+1:]...)
+			return nil
+		}
+	}
+	return tss.ErrPoolNotFound
+}
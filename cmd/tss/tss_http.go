@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -13,8 +15,12 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
 	"github.com/HyperCore-Team/go-tss/keygen"
 	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/refresh"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
 	"github.com/HyperCore-Team/go-tss/tss"
 )
 
@@ -23,13 +29,18 @@ type TssHttpServer struct {
 	logger    zerolog.Logger
 	tssServer tss.Server
 	s         *http.Server
+	jobs      *jobManager
+	// auth is nil if the api is open
+	auth *authenticator
 }
 
-// NewTssHttpServer should only listen to the loopback
+// NewTssHttpServer should only listen to the loopback, as the api is open
 func NewTssHttpServer(tssAddr string, t tss.Server) *TssHttpServer {
+	logger := log.With().Str("module", "http").Logger()
 	hs := &TssHttpServer{
-		logger:    log.With().Str("module", "http").Logger(),
+		logger:    logger,
 		tssServer: t,
+		jobs:      newJobManager(logger),
 	}
 	s := &http.Server{
 		Addr:    tssAddr,
@@ -39,21 +50,52 @@ func NewTssHttpServer(tssAddr string, t tss.Server) *TssHttpServer {
 	return hs
 }
 
+// NewTssHttpServerWithAuth create a http server which authenticates the clients with the given configuration, it may
+// listen on other addresses than the loopback
+func NewTssHttpServerWithAuth(tssAddr string, t tss.Server, conf AuthConfig) (*TssHttpServer, error) {
+	hs := NewTssHttpServer(tssAddr, t)
+	tlsConf, err := conf.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	hs.s.TLSConfig = tlsConf
+	if len(conf.Credentials) > 0 {
+		hs.auth, err = newAuthenticator(conf.Credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hs, nil
+}
+
 // NewHandler registers the API routes and returns a new HTTP handler
 func (t *TssHttpServer) tssNewHandler() http.Handler {
 	router := mux.NewRouter()
-	router.Handle("/keygen", http.HandlerFunc(t.keygenHandler)).Methods(http.MethodPost)
-	router.Handle("/keysign", http.HandlerFunc(t.keySignHandler)).Methods(http.MethodPost)
-	router.Handle("/ping", http.HandlerFunc(t.pingHandler)).Methods(http.MethodGet)
-	router.Handle("/p2pid", http.HandlerFunc(t.getP2pIDHandler)).Methods(http.MethodGet)
-	router.Handle("/metrics", promhttp.Handler())
+	// the pool pub keys in the path are base64 encoded, so they have to be url encoded by the client
+	router.UseEncodedPath()
+	router.Handle("/keygen", http.HandlerFunc(t.keygenHandler)).Methods(http.MethodPost).Name(routeKeygen)
+	router.Handle("/keysign", http.HandlerFunc(t.keySignHandler)).Methods(http.MethodPost).Name(routeKeysign)
+	router.Handle("/keysign/batch", http.HandlerFunc(t.keySignBatchHandler)).Methods(http.MethodPost).Name(routeKeysignBatch)
+	router.Handle("/keyregroup", http.HandlerFunc(t.keyRegroupHandler)).Methods(http.MethodPost).Name(routeKeyRegroup)
+	router.Handle("/keyregroup/confirm", http.HandlerFunc(t.confirmRegroupHandler)).Methods(http.MethodPost).Name(routeConfirmRegroup)
+	router.Handle("/jobs/{id}", http.HandlerFunc(t.getJobHandler)).Methods(http.MethodGet).Name(routeJobs)
+	router.Handle("/refresh", http.HandlerFunc(t.refreshHandler)).Methods(http.MethodPost).Name(routeRefresh)
+	router.Handle("/pools", http.HandlerFunc(t.listPoolsHandler)).Methods(http.MethodGet).Name(routePools)
+	router.Handle("/pools/{pubkey}", http.HandlerFunc(t.getPoolHandler)).Methods(http.MethodGet).Name(routePools)
+	router.Handle("/pools/{pubkey}", http.HandlerFunc(t.deletePoolHandler)).Methods(http.MethodDelete).Name(routeDeletePool)
+	router.Handle("/signatures/{msgid}", http.HandlerFunc(t.getSignaturesHandler)).Methods(http.MethodGet).Name(routeSignatures)
+	router.Handle("/preparams", http.HandlerFunc(t.preParamsHandler)).Methods(http.MethodGet).Name(routePreParams)
+	router.Handle("/ping", http.HandlerFunc(t.pingHandler)).Methods(http.MethodGet).Name(routePing)
+	router.Handle("/p2pid", http.HandlerFunc(t.getP2pIDHandler)).Methods(http.MethodGet).Name(routeP2pID)
+	router.Handle("/metrics", promhttp.Handler()).Name(routeMetrics)
 	router.Use(logMiddleware())
+	router.Use(t.authMiddleware())
 	return router
 }
 
 func (t *TssHttpServer) keygenHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		t.writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	defer func() {
@@ -66,13 +108,26 @@ func (t *TssHttpServer) keygenHandler(w http.ResponseWriter, r *http.Request) {
 	var keygenReq keygen.Request
 	if err := decoder.Decode(&keygenReq); nil != err {
 		t.logger.Error().Err(err).Msg("fail to decode keygen request")
-		w.WriteHeader(http.StatusBadRequest)
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("fail to decode the keygen request: %s", err))
+		return
+	}
+	if err := keygenReq.Validate(); err != nil {
+		t.logger.Error().Err(err).Msg("invalid keygen request")
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	if t.startJob(w, r, "keygen", func(ctx context.Context) (interface{}, common.Status, error) {
+		resp, err := t.tssServer.KeygenWithContext(ctx, keygenReq)
+		return resp, resp.Status, err
+	}) {
 		return
 	}
 
-	resp, err := t.tssServer.Keygen(keygenReq)
+	resp, err := t.tssServer.KeygenWithContext(r.Context(), keygenReq)
 	if err != nil {
 		t.logger.Error().Err(err).Msg("fail to key gen")
+		t.writeServerError(w, err, resp.Blame)
+		return
 	}
 	t.logger.Debug().Msgf("resp:%+v", resp)
 	buf, err := json.Marshal(resp)
@@ -89,7 +144,7 @@ func (t *TssHttpServer) keygenHandler(w http.ResponseWriter, r *http.Request) {
 
 func (t *TssHttpServer) keySignHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		t.writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	defer func() {
@@ -103,14 +158,30 @@ func (t *TssHttpServer) keySignHandler(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&keySignReq); nil != err {
 		t.logger.Error().Err(err).Msg("fail to decode key sign request")
-		w.WriteHeader(http.StatusBadRequest)
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("fail to decode the key sign request: %s", err))
+		return
+	}
+	if err := keySignReq.Validate(); err != nil {
+		t.logger.Error().Err(err).Msg("invalid key sign request")
+		t.writeServerError(w, err, blame.Blame{})
 		return
 	}
 	t.logger.Info().Msgf("request:%+v", keySignReq)
-	signResp, err := t.tssServer.KeySign(keySignReq)
+	if !allowsPool(r, keySignReq.PoolPubKey) {
+		t.logger.Error().Msgf("the client may not sign for pool %s", keySignReq.PoolPubKey)
+		t.writeError(w, http.StatusForbidden, CodeForbidden, "the client may not use the pool")
+		return
+	}
+	if t.startJob(w, r, "keysign", func(ctx context.Context) (interface{}, common.Status, error) {
+		resp, err := t.tssServer.KeySignWithContext(ctx, keySignReq)
+		return resp, resp.Status, err
+	}) {
+		return
+	}
+	signResp, err := t.tssServer.KeySignWithContext(r.Context(), keySignReq)
 	if err != nil {
 		t.logger.Error().Err(err).Msg("fail to key sign")
-		w.WriteHeader(http.StatusInternalServerError)
+		t.writeServerError(w, err, signResp.Blame)
 		return
 	}
 
@@ -126,14 +197,322 @@ func (t *TssHttpServer) keySignHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// BatchKeySignResult is the keysign of one pool of a batch, the error is set if the keysign of the pool failed
+type BatchKeySignResult struct {
+	PoolPubKey string `json:"pool_pub_key"`
+	keysign.Response
+	Error *ErrorResponse `json:"error,omitempty"`
+}
+
+// BatchKeySignResponse is the body of a batch keysign, the results are in the order of the requests of the batch
+type BatchKeySignResponse struct {
+	Results []BatchKeySignResult `json:"results"`
+	Status  common.Status        `json:"status"`
+}
+
+func newBatchKeySignResponse(resp keysign.BatchResponse) BatchKeySignResponse {
+	out := BatchKeySignResponse{Results: make([]BatchKeySignResult, len(resp.Results)), Status: resp.Status}
+	for i, el := range resp.Results {
+		out.Results[i] = BatchKeySignResult{PoolPubKey: el.PoolPubKey, Response: el.Response}
+		if el.Err != nil {
+			_, errResp := serverErrorResponse(el.Err, el.Response.Blame)
+			out.Results[i].Error = &errResp
+		}
+	}
+	return out
+}
+
+func (t *TssHttpServer) keySignBatchHandler(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if err := r.Body.Close(); nil != err {
+			t.logger.Error().Err(err).Msg("fail to close request body")
+		}
+	}()
+	t.logger.Info().Msg("receive batch key sign request")
+
+	var batchReq keysign.BatchRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&batchReq); nil != err {
+		t.logger.Error().Err(err).Msg("fail to decode batch key sign request")
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("fail to decode the batch key sign request: %s", err))
+		return
+	}
+	if err := batchReq.Validate(); err != nil {
+		t.logger.Error().Err(err).Msg("invalid batch key sign request")
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	for _, el := range batchReq.Requests {
+		if !allowsPool(r, el.PoolPubKey) {
+			t.logger.Error().Msgf("the client may not sign for pool %s", el.PoolPubKey)
+			t.writeError(w, http.StatusForbidden, CodeForbidden, fmt.Sprintf("the client may not use the pool %s", el.PoolPubKey))
+			return
+		}
+	}
+	if t.startJob(w, r, "keysign_batch", func(ctx context.Context) (interface{}, common.Status, error) {
+		resp, err := t.tssServer.KeySignBatchWithContext(ctx, batchReq)
+		return newBatchKeySignResponse(resp), resp.Status, err
+	}) {
+		return
+	}
+	batchResp, err := t.tssServer.KeySignBatchWithContext(r.Context(), batchReq)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to batch key sign")
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+
+	// the keysigns of the batch may fail on their own, their errors are in the results
+	jsonResult, err := json.MarshalIndent(newBatchKeySignResponse(batchResp), "", "	")
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to marshal response to json message")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(jsonResult)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to write response")
+	}
+}
+
+func (t *TssHttpServer) keyRegroupHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		t.writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	defer func() {
+		if err := r.Body.Close(); nil != err {
+			t.logger.Error().Err(err).Msg("fail to close request body")
+		}
+	}()
+	t.logger.Info().Msg("receive key regroup request")
+
+	var keyRegroupReq keyRegroup.Request
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&keyRegroupReq); nil != err {
+		t.logger.Error().Err(err).Msg("fail to decode key regroup request")
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("fail to decode the key regroup request: %s", err))
+		return
+	}
+	if err := keyRegroupReq.Validate(); err != nil {
+		t.logger.Error().Err(err).Msg("invalid key regroup request")
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	t.logger.Info().Msgf("request:%+v", keyRegroupReq)
+	if !allowsPool(r, keyRegroupReq.PoolPubKey) {
+		t.logger.Error().Msgf("the client may not regroup pool %s", keyRegroupReq.PoolPubKey)
+		t.writeError(w, http.StatusForbidden, CodeForbidden, "the client may not use the pool")
+		return
+	}
+	if t.startJob(w, r, "key regroup", func(ctx context.Context) (interface{}, common.Status, error) {
+		resp, err := t.tssServer.KeyRegroupWithContext(ctx, keyRegroupReq)
+		return resp, resp.Status, err
+	}) {
+		return
+	}
+	regroupResp, err := t.tssServer.KeyRegroupWithContext(r.Context(), keyRegroupReq)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to key regroup")
+		t.writeServerError(w, err, regroupResp.Blame)
+		return
+	}
+
+	buf, err := json.Marshal(regroupResp)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to marshal response to json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(buf)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to write to response")
+	}
+}
+
+func (t *TssHttpServer) confirmRegroupHandler(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if err := r.Body.Close(); nil != err {
+			t.logger.Error().Err(err).Msg("fail to close request body")
+		}
+	}()
+	var confirmReq keyRegroup.ConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&confirmReq); nil != err {
+		t.logger.Error().Err(err).Msg("fail to decode confirm regroup request")
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("fail to decode the confirm regroup request: %s", err))
+		return
+	}
+	if err := confirmReq.Validate(); err != nil {
+		t.logger.Error().Err(err).Msg("invalid confirm regroup request")
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	t.logger.Info().Msgf("receive confirm regroup request, pool: %s", confirmReq.PoolPubKey)
+	if !allowsPool(r, confirmReq.PoolPubKey) {
+		t.writeError(w, http.StatusForbidden, CodeForbidden, "the client may not use the pool")
+		return
+	}
+	if err := t.tssServer.ConfirmRegroup(confirmReq.PoolPubKey); err != nil {
+		t.logger.Error().Err(err).Msg("fail to confirm the regroup")
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (t *TssHttpServer) refreshHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		t.writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	defer func() {
+		if err := r.Body.Close(); nil != err {
+			t.logger.Error().Err(err).Msg("fail to close request body")
+		}
+	}()
+	t.logger.Info().Msg("receive key refresh request")
+
+	var refreshReq refresh.Request
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&refreshReq); nil != err {
+		t.logger.Error().Err(err).Msg("fail to decode key refresh request")
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("fail to decode the key refresh request: %s", err))
+		return
+	}
+	if err := refreshReq.Validate(); err != nil {
+		t.logger.Error().Err(err).Msg("invalid key refresh request")
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	t.logger.Info().Msgf("request:%+v", refreshReq)
+	if !allowsPool(r, refreshReq.PoolPubKey) {
+		t.logger.Error().Msgf("the client may not refresh pool %s", refreshReq.PoolPubKey)
+		t.writeError(w, http.StatusForbidden, CodeForbidden, "the client may not use the pool")
+		return
+	}
+	refreshResp, err := t.tssServer.RefreshWithContext(r.Context(), refreshReq)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to key refresh")
+		t.writeServerError(w, err, refreshResp.Blame)
+		return
+	}
+	t.writeJSON(w, refreshResp)
+}
+
+func (t *TssHttpServer) listPoolsHandler(w http.ResponseWriter, r *http.Request) {
+	pools, err := t.tssServer.ListPools()
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to list the pools")
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	// the client only sees the pools it may use
+	allowed := []tss.PoolInfo{}
+	for _, el := range pools {
+		if allowsPool(r, el.PubKey) {
+			allowed = append(allowed, el)
+		}
+	}
+	t.writeJSON(w, allowed)
+}
+
+func (t *TssHttpServer) preParamsHandler(w http.ResponseWriter, _ *http.Request) {
+	t.writeJSON(w, t.tssServer.PreParamsInfo())
+}
+
+func (t *TssHttpServer) getPoolHandler(w http.ResponseWriter, r *http.Request) {
+	pubKey, err := url.PathUnescape(mux.Vars(r)["pubkey"])
+	if err != nil {
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("invalid pool pub key: %s", err))
+		return
+	}
+	if !allowsPool(r, pubKey) {
+		t.writeError(w, http.StatusForbidden, CodeForbidden, "the client may not use the pool")
+		return
+	}
+	pool, err := t.tssServer.GetPool(pubKey)
+	if err != nil {
+		t.logger.Error().Err(err).Msgf("fail to get the pool %s", pubKey)
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	t.writeJSON(w, pool)
+}
+
+func (t *TssHttpServer) deletePoolHandler(w http.ResponseWriter, r *http.Request) {
+	pubKey, err := url.PathUnescape(mux.Vars(r)["pubkey"])
+	if err != nil {
+		t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("invalid pool pub key: %s", err))
+		return
+	}
+	archive := false
+	if value := r.URL.Query().Get("archive"); value != "" {
+		archive, err = strconv.ParseBool(value)
+		if err != nil {
+			t.writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("invalid archive: %s", err))
+			return
+		}
+	}
+	t.logger.Info().Msgf("receive delete pool request, pool: %s, archive: %t", pubKey, archive)
+	if !allowsPool(r, pubKey) {
+		t.writeError(w, http.StatusForbidden, CodeForbidden, "the client may not use the pool")
+		return
+	}
+	if err := t.tssServer.DeletePool(pubKey, archive); err != nil {
+		t.logger.Error().Err(err).Msgf("fail to delete the pool %s", pubKey)
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (t *TssHttpServer) getSignaturesHandler(w http.ResponseWriter, r *http.Request) {
+	msgID := mux.Vars(r)["msgid"]
+	info, err := t.tssServer.GetSignatures(msgID)
+	if err != nil {
+		t.logger.Error().Err(err).Msgf("fail to get the signatures of %s", msgID)
+		t.writeServerError(w, err, blame.Blame{})
+		return
+	}
+	// the client only sees the signatures of the pools it may use
+	if !allowsPool(r, info.PoolPubKey) {
+		t.writeError(w, http.StatusForbidden, CodeForbidden, "the client may not use the pool")
+		return
+	}
+	t.writeJSON(w, info)
+}
+
+func (t *TssHttpServer) writeJSON(w http.ResponseWriter, v interface{}) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to marshal response to json")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(buf)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to write to response")
+	}
+}
+
 func (t *TssHttpServer) Start() error {
 	if t.s == nil {
 		return errors.New("invalid http server instance")
 	}
+	if !isLoopback(t.s.Addr) && t.auth == nil && (t.s.TLSConfig == nil || t.s.TLSConfig.ClientCAs == nil) {
+		return fmt.Errorf("refuse to listen on %s without authenticating the clients", t.s.Addr)
+	}
 	if err := t.tssServer.Start(); err != nil {
 		return fmt.Errorf("fail to start tss server: %w", err)
 	}
-	if err := t.s.ListenAndServe(); err != nil {
+	var err error
+	if t.s.TLSConfig != nil {
+		// the certificate is in the tls configuration already
+		err = t.s.ListenAndServeTLS("", "")
+	} else {
+		err = t.s.ListenAndServe()
+	}
+	if err != nil {
 		if err != http.ErrServerClosed {
 			return fmt.Errorf("fail to start http server: %w", err)
 		}
@@ -163,6 +542,7 @@ func (t *TssHttpServer) Stop() error {
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to shutdown the Tss server gracefully")
 	}
+	t.jobs.stop()
 	t.tssServer.Stop()
 	return err
 }
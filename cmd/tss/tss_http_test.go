@@ -2,20 +2,36 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"sync"
 	"testing"
 	"time"
 
 	. "gopkg.in/check.v1"
 
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
 	"github.com/HyperCore-Team/go-tss/keygen"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/refresh"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+	"github.com/HyperCore-Team/go-tss/tss"
 )
 
 func TestPackage(t *testing.T) { TestingT(t) }
 
+// the bodies of the requests which pass the validation of the handlers
+const (
+	testKeygenBody     = `{"keys":["D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=","8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE="],"block_height":10,"tss_version":"0.14.0","algo":"ecdsa"}`
+	testKeySignBody    = `{"pool_pub_key":"AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq","messages":["aGVsbG93b3JsZA=="],"block_height":10,"tss_version":"0.14.0","algo":"ecdsa"}`
+	testKeyRegroupBody = `{"old_party_keys":["D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=","8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE="],"new_party_keys":["8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=","Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU="],"block_height":10,"tss_version":"0.14.0","algo":"ecdsa"}`
+	testRefreshBody    = `{"pool_pub_key":"AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq","block_height":10,"tss_version":"0.14.0","algo":"ecdsa"}`
+)
+
 type TssHttpServerTestSuite struct {
 }
 
@@ -59,7 +75,7 @@ func (TssHttpServerTestSuite) TestGetP2pIDHandler(c *C) {
 }
 
 func (TssHttpServerTestSuite) TestKeygenHandler(c *C) {
-	normalKeygenRequest := `{"keys":["thorpub1addwnpepqtdklw8tf3anjz7nn5fly3uvq2e67w2apn560s4smmrt9e3x52nt2svmmu3", "thorpub1addwnpepqtspqyy6gk22u37ztra4hq3hdakc0w0k60sfy849mlml2vrpfr0wvm6uz09", "thorpub1addwnpepq2ryyje5zr09lq7gqptjwnxqsy2vcdngvwd6z7yt5yjcnyj8c8cn559xe69", "thorpub1addwnpepqfjcw5l4ay5t00c32mmlky7qrppepxzdlkcwfs2fd5u73qrwna0vzag3y4j"]}`
+	normalKeygenRequest := `{"keys":["D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=", "8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=", "Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU=", "jzTMn5m27Cmt6EuCAuKnIzxNbVYY4EIywP0a9grmSok="], "block_height": 10, "tss_version": "0.14.0", "algo": "ecdsa"}`
 	testCases := []struct {
 		name          string
 		reqProvider   func() *http.Request
@@ -84,6 +100,27 @@ func (TssHttpServerTestSuite) TestKeygenHandler(c *C) {
 				c.Assert(w.Code, Equals, http.StatusBadRequest)
 			},
 		},
+		{
+			name: "invalid request should return status bad request with the invalid fields",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/keygen",
+					bytes.NewBufferString(`{"keys":["thorpub1addwnpepqtdklw8tf3anjz7nn5fly3uvq2e67w2apn560s4smmrt9e3x52nt2svmmu3"],"tss_version":"0.14.0","algo":"rsa"}`))
+			},
+			setter: func(s *MockTssServer) {
+				s.failToKeyGen = true
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusBadRequest)
+				var resp ErrorResponse
+				c.Assert(json.Unmarshal(w.Body.Bytes(), &resp), IsNil)
+				c.Assert(resp.Code, Equals, CodeInvalidRequest)
+				var fields []string
+				for _, el := range resp.Fields {
+					fields = append(fields, el.Field)
+				}
+				c.Assert(fields, DeepEquals, []string{"keys", "keys[0]", "algo"})
+			},
+		},
 		{
 			name: "fail to keygen should return status internal server error",
 			reqProvider: func() *http.Request {
@@ -94,7 +131,11 @@ func (TssHttpServerTestSuite) TestKeygenHandler(c *C) {
 				s.failToKeyGen = true
 			},
 			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
-				c.Assert(w.Code, Equals, http.StatusOK)
+				c.Assert(w.Code, Equals, http.StatusInternalServerError)
+				var resp ErrorResponse
+				c.Assert(json.Unmarshal(w.Body.Bytes(), &resp), IsNil)
+				c.Assert(resp.Code, Equals, CodeInternal)
+				c.Assert(resp.Message, Equals, "you ask for it")
 			},
 		},
 		{
@@ -128,14 +169,17 @@ func (TssHttpServerTestSuite) TestKeygenHandler(c *C) {
 
 func (TssHttpServerTestSuite) TestKeysignHandler(c *C) {
 	var normalKeySignRequest string = `{
-    "pool_pub_key": "thorpub1addwnpepqtdklw8tf3anjz7nn5fly3uvq2e67w2apn560s4smmrt9e3x52nt2svmmu3",
-    "message": "helloworld",
+    "pool_pub_key": "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq",
+    "messages": ["aGVsbG93b3JsZA=="],
     "signer_pub_keys": [
-        "thorpub1addwnpepqtdklw8tf3anjz7nn5fly3uvq2e67w2apn560s4smmrt9e3x52nt2svmmu3",
-        "thorpub1addwnpepqtspqyy6gk22u37ztra4hq3hdakc0w0k60sfy849mlml2vrpfr0wvm6uz09",
-        "thorpub1addwnpepq2ryyje5zr09lq7gqptjwnxqsy2vcdngvwd6z7yt5yjcnyj8c8cn559xe69",
-        "thorpub1addwnpepqfjcw5l4ay5t00c32mmlky7qrppepxzdlkcwfs2fd5u73qrwna0vzag3y4j"
-    ]
+        "D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=",
+        "8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=",
+        "Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU=",
+        "jzTMn5m27Cmt6EuCAuKnIzxNbVYY4EIywP0a9grmSok="
+    ],
+    "block_height": 10,
+    "tss_version": "0.14.0",
+    "algo": "ecdsa"
 }`
 	testCases := []struct {
 		name          string
@@ -174,6 +218,19 @@ func (TssHttpServerTestSuite) TestKeysignHandler(c *C) {
 				c.Assert(w.Code, Equals, http.StatusInternalServerError)
 			},
 		},
+		{
+			name: "request rejected by the signing policy should return status forbidden",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/keysign",
+					bytes.NewBufferString(normalKeySignRequest))
+			},
+			setter: func(s *MockTssServer) {
+				s.rejectKeySign = true
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusForbidden)
+			},
+		},
 		{
 			name: "normal",
 			reqProvider: func() *http.Request {
@@ -202,3 +259,593 @@ func (TssHttpServerTestSuite) TestKeysignHandler(c *C) {
 		tc.resultChecker(c, res)
 	}
 }
+
+func (TssHttpServerTestSuite) TestKeysignBatchHandler(c *C) {
+	batchBody := `{"requests":[` + testKeySignBody + `,{"pool_pub_key":"D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=","messages":["aGVsbG93b3JsZA=="],"block_height":10,"tss_version":"0.14.0","algo":"eddsa"}]}`
+	testCases := []struct {
+		name       string
+		body       string
+		setter     func(s *MockTssServer)
+		statusCode int
+		status     common.Status
+		code       ErrorCode
+	}{
+		{name: "nil request body", statusCode: http.StatusBadRequest},
+		{name: "no requests", body: `{"requests":[]}`, statusCode: http.StatusBadRequest},
+		{
+			name:       "pool signed twice",
+			body:       `{"requests":[` + testKeySignBody + `,` + testKeySignBody + `]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{name: "normal", body: batchBody, statusCode: http.StatusOK, status: common.Success},
+		{
+			name: "failed keysigns are in the results",
+			body: batchBody,
+			setter: func(s *MockTssServer) {
+				s.rejectKeySign = true
+			},
+			statusCode: http.StatusOK,
+			status:     common.Fail,
+			code:       CodePolicyRejected,
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		tssServer := &MockTssServer{}
+		if tc.setter != nil {
+			tc.setter(tssServer)
+		}
+		s := NewTssHttpServer("127.0.0.1:8080", tssServer)
+		res := httptest.NewRecorder()
+		s.keySignBatchHandler(res, httptest.NewRequest(http.MethodPost, "/keysign/batch", bytes.NewBufferString(tc.body)))
+		c.Assert(res.Code, Equals, tc.statusCode)
+		if tc.statusCode != http.StatusOK {
+			var errResp ErrorResponse
+			c.Assert(json.Unmarshal(res.Body.Bytes(), &errResp), IsNil)
+			c.Assert(errResp.Code, Equals, CodeInvalidRequest)
+			continue
+		}
+		var resp BatchKeySignResponse
+		c.Assert(json.Unmarshal(res.Body.Bytes(), &resp), IsNil)
+		c.Assert(resp.Status, Equals, tc.status)
+		c.Assert(resp.Results, HasLen, 2)
+		c.Assert(resp.Results[1].PoolPubKey, Equals, "D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=")
+		for _, el := range resp.Results {
+			if tc.code == "" {
+				c.Assert(el.Error, IsNil)
+				c.Assert(el.Signatures, HasLen, 1)
+				continue
+			}
+			c.Assert(el.Error, NotNil)
+			c.Assert(el.Error.Code, Equals, tc.code)
+		}
+	}
+
+	// the client has to be allowed to use every pool of the batch
+	s, err := NewTssHttpServerWithAuth("127.0.0.1:8080", &MockTssServer{}, AuthConfig{Credentials: []Credential{
+		{Name: "sweeper", Token: "secret", Routes: []string{routeKeysignBatch}, Pools: []string{"AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq"}},
+	}})
+	c.Assert(err, IsNil)
+	req := httptest.NewRequest(http.MethodPost, "/keysign/batch", bytes.NewBufferString(batchBody))
+	req.Header.Set("Authorization", "Bearer secret")
+	res := httptest.NewRecorder()
+	s.tssNewHandler().ServeHTTP(res, req)
+	c.Assert(res.Code, Equals, http.StatusForbidden)
+	req = httptest.NewRequest(http.MethodPost, "/keysign/batch", bytes.NewBufferString(`{"requests":[`+testKeySignBody+`]}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	res = httptest.NewRecorder()
+	s.tssNewHandler().ServeHTTP(res, req)
+	c.Assert(res.Code, Equals, http.StatusOK)
+}
+
+func (TssHttpServerTestSuite) TestKeyRegroupHandler(c *C) {
+	normalKeyRegroupRequest := `{
+    "pool_address": "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq",
+    "old_party_keys": [
+        "D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=",
+        "8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=",
+        "Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU="
+    ],
+    "new_party_keys": [
+        "8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=",
+        "Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU=",
+        "jzTMn5m27Cmt6EuCAuKnIzxNbVYY4EIywP0a9grmSok="
+    ],
+    "block_height": 10,
+    "tss_version": "0.14.0",
+    "algo": "ecdsa"
+}`
+	testCases := []struct {
+		name          string
+		reqProvider   func() *http.Request
+		setter        func(s *MockTssServer)
+		resultChecker func(c *C, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "method get should return status method not allowed",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/keyregroup", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusMethodNotAllowed)
+			},
+		},
+		{
+			name: "nil request body should return status bad request",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/keyregroup", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "fail to key regroup should return status internal server error",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/keyregroup",
+					bytes.NewBufferString(normalKeyRegroupRequest))
+			},
+			setter: func(s *MockTssServer) {
+				s.failToKeyRegroup = true
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "normal",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/keyregroup",
+					bytes.NewBufferString(normalKeyRegroupRequest))
+			},
+
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+				var resp keyRegroup.Response
+				c.Assert(json.Unmarshal(w.Body.Bytes(), &resp), IsNil)
+				c.Assert(resp.PubKey, Not(Equals), "")
+			},
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		tssServer := &MockTssServer{}
+		s := NewTssHttpServer("127.0.0.1:8080", tssServer)
+		c.Assert(s, NotNil)
+		if tc.setter != nil {
+			tc.setter(tssServer)
+		}
+		req := tc.reqProvider()
+		res := httptest.NewRecorder()
+		s.keyRegroupHandler(res, req)
+		tc.resultChecker(c, res)
+	}
+}
+
+func (TssHttpServerTestSuite) TestRefreshHandler(c *C) {
+	normalRefreshRequest := `{
+    "pool_pub_key": "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq",
+    "block_height": 10,
+    "tss_version": "0.14.0",
+    "algo": "ecdsa"
+}`
+	testCases := []struct {
+		name          string
+		reqProvider   func() *http.Request
+		setter        func(s *MockTssServer)
+		resultChecker func(c *C, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "method get should return status method not allowed",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/refresh", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusMethodNotAllowed)
+			},
+		},
+		{
+			name: "nil request body should return status bad request",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/refresh", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "fail to refresh should return status internal server error",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/refresh",
+					bytes.NewBufferString(normalRefreshRequest))
+			},
+			setter: func(s *MockTssServer) {
+				s.failToRefresh = true
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "normal",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/refresh",
+					bytes.NewBufferString(normalRefreshRequest))
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+				var resp refresh.Response
+				c.Assert(json.Unmarshal(w.Body.Bytes(), &resp), IsNil)
+				c.Assert(resp.PubKey, Equals, "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq")
+				c.Assert(resp.Status, Equals, common.Success)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		tssServer := &MockTssServer{}
+		s := NewTssHttpServer("127.0.0.1:8080", tssServer)
+		c.Assert(s, NotNil)
+		if tc.setter != nil {
+			tc.setter(tssServer)
+		}
+		req := tc.reqProvider()
+		res := httptest.NewRecorder()
+		s.refreshHandler(res, req)
+		tc.resultChecker(c, res)
+	}
+}
+
+func (TssHttpServerTestSuite) TestHandlersUseRequestContext(c *C) {
+	testCases := []struct {
+		name    string
+		path    string
+		body    string
+		handler func(s *TssHttpServer) http.HandlerFunc
+	}{
+		{
+			name:    "keygen",
+			path:    "/keygen",
+			body:    testKeygenBody,
+			handler: func(s *TssHttpServer) http.HandlerFunc { return s.keygenHandler },
+		},
+		{
+			name:    "keysign",
+			path:    "/keysign",
+			body:    testKeySignBody,
+			handler: func(s *TssHttpServer) http.HandlerFunc { return s.keySignHandler },
+		},
+		{
+			name:    "regroup",
+			path:    "/regroup",
+			body:    testKeyRegroupBody,
+			handler: func(s *TssHttpServer) http.HandlerFunc { return s.keyRegroupHandler },
+		},
+		{
+			name:    "refresh",
+			path:    "/refresh",
+			body:    testRefreshBody,
+			handler: func(s *TssHttpServer) http.HandlerFunc { return s.refreshHandler },
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		tssServer := &MockTssServer{}
+		s := NewTssHttpServer("127.0.0.1:8080", tssServer)
+		ctx, cancel := context.WithCancel(context.Background())
+		req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.body)).WithContext(ctx)
+		tc.handler(s)(httptest.NewRecorder(), req)
+		c.Assert(tssServer.cancelled, Equals, false)
+
+		// the client goes away, the ceremony is cancelled with it
+		cancel()
+		req = httptest.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.body)).WithContext(ctx)
+		tc.handler(s)(httptest.NewRecorder(), req)
+		c.Assert(tssServer.cancelled, Equals, true)
+	}
+}
+
+func (TssHttpServerTestSuite) TestPreParamsHandler(c *C) {
+	tssServer := &MockTssServer{
+		preParams: tss.PreParamsInfo{Size: 2, Available: 1, Generating: true},
+	}
+	s := NewTssHttpServer("127.0.0.1:8080", tssServer)
+	req := httptest.NewRequest(http.MethodGet, "/preparams", nil)
+	res := httptest.NewRecorder()
+	s.tssNewHandler().ServeHTTP(res, req)
+	c.Assert(res.Code, Equals, http.StatusOK)
+	var info tss.PreParamsInfo
+	c.Assert(json.Unmarshal(res.Body.Bytes(), &info), IsNil)
+	c.Assert(info, DeepEquals, tssServer.preParams)
+}
+
+func (TssHttpServerTestSuite) TestPoolsHandlers(c *C) {
+	pool := tss.PoolInfo{
+		PubKey:          "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq",
+		Algo:            "ecdsa",
+		ParticipantKeys: []string{"A", "B", "C"},
+		LocalPartyKey:   "A",
+		Threshold:       1,
+	}
+	poolPath := "/pools/" + url.PathEscape(pool.PubKey)
+	testCases := []struct {
+		name          string
+		reqProvider   func() *http.Request
+		resultChecker func(c *C, w *httptest.ResponseRecorder, s *MockTssServer)
+	}{
+		{
+			name: "list the pools",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/pools", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder, s *MockTssServer) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+				var pools []tss.PoolInfo
+				c.Assert(json.Unmarshal(w.Body.Bytes(), &pools), IsNil)
+				c.Assert(pools, DeepEquals, []tss.PoolInfo{pool})
+			},
+		},
+		{
+			name: "get the pool",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, poolPath, nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder, s *MockTssServer) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+				var resp tss.PoolInfo
+				c.Assert(json.Unmarshal(w.Body.Bytes(), &resp), IsNil)
+				c.Assert(resp, DeepEquals, pool)
+			},
+		},
+		{
+			name: "unknown pool should return status not found",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/pools/"+url.PathEscape(conversion.GetRandomPubKey()), nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder, s *MockTssServer) {
+				c.Assert(w.Code, Equals, http.StatusNotFound)
+			},
+		},
+		{
+			name: "invalid pool pub key should return status bad request",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/pools/invalid", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder, s *MockTssServer) {
+				c.Assert(w.Code, Equals, http.StatusBadRequest)
+			},
+		},
+		{
+			name: "delete the pool",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodDelete, poolPath+"?archive=true", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder, s *MockTssServer) {
+				c.Assert(w.Code, Equals, http.StatusOK)
+				c.Assert(s.pools, HasLen, 0)
+			},
+		},
+		{
+			name: "invalid archive flag should return status bad request",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodDelete, poolPath+"?archive=maybe", nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder, s *MockTssServer) {
+				c.Assert(w.Code, Equals, http.StatusBadRequest)
+				c.Assert(s.pools, HasLen, 1)
+			},
+		},
+		{
+			name: "post should return status method not allowed",
+			reqProvider: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, poolPath, nil)
+			},
+			resultChecker: func(c *C, w *httptest.ResponseRecorder, s *MockTssServer) {
+				c.Assert(w.Code, Equals, http.StatusMethodNotAllowed)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		tssServer := &MockTssServer{
+			pools: []tss.PoolInfo{pool},
+		}
+		s := NewTssHttpServer("127.0.0.1:8080", tssServer)
+		c.Assert(s, NotNil)
+		res := httptest.NewRecorder()
+		s.tssNewHandler().ServeHTTP(res, tc.reqProvider())
+		tc.resultChecker(c, res, tssServer)
+	}
+}
+
+func (TssHttpServerTestSuite) TestGetSignaturesHandler(c *C) {
+	info := tss.SignatureInfo{
+		MsgID:      "4c9b7e1f",
+		PoolPubKey: "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq",
+		Signatures: []keysign.Signature{keysign.NewSignature("aGVsbG8=", "AQ==", "Ag==", "AQ==", "AQI=")},
+		CreatedAt:  time.Unix(1700000000, 0).UTC(),
+	}
+	tssServer := &MockTssServer{signatures: []tss.SignatureInfo{info}}
+	s := NewTssHttpServer("127.0.0.1:8080", tssServer)
+	res := httptest.NewRecorder()
+	s.tssNewHandler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/signatures/"+info.MsgID, nil))
+	c.Assert(res.Code, Equals, http.StatusOK)
+	var resp tss.SignatureInfo
+	c.Assert(json.Unmarshal(res.Body.Bytes(), &resp), IsNil)
+	c.Assert(resp, DeepEquals, info)
+
+	res = httptest.NewRecorder()
+	s.tssNewHandler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/signatures/unknown", nil))
+	c.Assert(res.Code, Equals, http.StatusNotFound)
+	var errResp ErrorResponse
+	c.Assert(json.Unmarshal(res.Body.Bytes(), &errResp), IsNil)
+	c.Assert(errResp.Code, Equals, CodeSignaturesNotFound)
+
+	// the client only sees the signatures of the pools it may use
+	s, err := NewTssHttpServerWithAuth("127.0.0.1:8080", tssServer, AuthConfig{Credentials: []Credential{
+		{Name: "operator", Token: "secret", Routes: []string{routeSignatures}, Pools: []string{conversion.GetRandomPubKey()}},
+	}})
+	c.Assert(err, IsNil)
+	req := httptest.NewRequest(http.MethodGet, "/signatures/"+info.MsgID, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	res = httptest.NewRecorder()
+	s.tssNewHandler().ServeHTTP(res, req)
+	c.Assert(res.Code, Equals, http.StatusForbidden)
+}
+
+func (TssHttpServerTestSuite) TestConfirmRegroupHandler(c *C) {
+	testCases := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{name: "invalid body should return status bad request", body: "whatever", expectedCode: http.StatusBadRequest},
+		{name: "empty pool pub key should return status bad request", body: `{"pool_pub_key":""}`, expectedCode: http.StatusBadRequest},
+		{name: "no pending retirement should return status not found", body: `{"pool_pub_key":"A1oYI5jmGmnnTwdnfMdwn3cdN2E0pkp+s0HFkDKoOJAe"}`, expectedCode: http.StatusNotFound},
+		{name: "normal", body: `{"pool_pub_key":"AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq"}`, expectedCode: http.StatusOK},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		s := NewTssHttpServer("127.0.0.1:8080", &MockTssServer{
+			pools: []tss.PoolInfo{{PubKey: "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq", Algo: "ecdsa"}},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/keyregroup/confirm", bytes.NewBufferString(tc.body))
+		res := httptest.NewRecorder()
+		s.tssNewHandler().ServeHTTP(res, req)
+		c.Assert(res.Code, Equals, tc.expectedCode)
+	}
+}
+
+func waitForJob(c *C, s *TssHttpServer, id string) JobStatus {
+	for i := 0; i < 100; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/jobs/"+id, nil)
+		res := httptest.NewRecorder()
+		s.tssNewHandler().ServeHTTP(res, req)
+		c.Assert(res.Code, Equals, http.StatusOK)
+		var status JobStatus
+		c.Assert(json.Unmarshal(res.Body.Bytes(), &status), IsNil)
+		if status.finished() {
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Fatalf("job %s doesn't finish", id)
+	return JobStatus{}
+}
+
+func (TssHttpServerTestSuite) TestAsyncJobs(c *C) {
+	callbacks := make(chan JobStatus, 10)
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var status JobStatus
+		c.Check(json.NewDecoder(r.Body).Decode(&status), IsNil)
+		callbacks <- status
+	}))
+	defer callbackServer.Close()
+	callback := url.QueryEscape(callbackServer.URL)
+
+	testCases := []struct {
+		name       string
+		path       string
+		body       string
+		setter     func(s *MockTssServer)
+		statusCode int
+		phase      string
+		callback   bool
+	}{
+		{
+			name:       "keygen job",
+			path:       "/keygen?async=true",
+			body:       testKeygenBody,
+			statusCode: http.StatusAccepted,
+			phase:      tss.PhaseDone,
+		},
+		{
+			name: "failed keygen job",
+			path: "/keygen?async=true",
+			body: testKeygenBody,
+			setter: func(s *MockTssServer) {
+				s.failToKeyGen = true
+			},
+			statusCode: http.StatusAccepted,
+			phase:      tss.PhaseFailed,
+		},
+		{
+			name:       "keysign job with callback",
+			path:       "/keysign?callback=" + callback,
+			body:       testKeySignBody,
+			statusCode: http.StatusAccepted,
+			phase:      tss.PhaseDone,
+			callback:   true,
+		},
+		{
+			name: "failed regroup job with callback",
+			path: "/keyregroup?async=1&callback=" + callback,
+			body: testKeyRegroupBody,
+			setter: func(s *MockTssServer) {
+				s.failToKeyRegroup = true
+			},
+			statusCode: http.StatusAccepted,
+			phase:      tss.PhaseFailed,
+			callback:   true,
+		},
+		{
+			name:       "invalid async flag",
+			path:       "/keygen?async=maybe",
+			body:       testKeygenBody,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "callback without async",
+			path:       "/keygen?async=false&callback=" + callback,
+			body:       testKeygenBody,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid callback",
+			path:       "/keysign?callback=" + url.QueryEscape("ftp://localhost/result"),
+			body:       testKeySignBody,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.name)
+		tssServer := &MockTssServer{}
+		if tc.setter != nil {
+			tc.setter(tssServer)
+		}
+		s := NewTssHttpServer("127.0.0.1:8080", tssServer)
+		req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewBufferString(tc.body))
+		res := httptest.NewRecorder()
+		s.tssNewHandler().ServeHTTP(res, req)
+		c.Assert(res.Code, Equals, tc.statusCode)
+		if tc.statusCode != http.StatusAccepted {
+			continue
+		}
+		var started JobStatus
+		c.Assert(json.Unmarshal(res.Body.Bytes(), &started), IsNil)
+		c.Assert(started.ID, Not(Equals), "")
+		c.Assert(started.Phase, Equals, tss.PhaseQueued)
+
+		status := waitForJob(c, s, started.ID)
+		c.Assert(status.Phase, Equals, tc.phase)
+		c.Assert(status.Response, NotNil)
+		c.Assert(status.Error != "", Equals, tc.phase == tss.PhaseFailed)
+		// the ceremony of the job must not be cancelled once the request is served
+		c.Assert(tssServer.cancelled, Equals, false)
+		if tc.callback {
+			select {
+			case received := <-callbacks:
+				c.Assert(received.ID, Equals, started.ID)
+				c.Assert(received.Phase, Equals, tc.phase)
+			case <-time.After(5 * time.Second):
+				c.Fatal("the result isn't delivered to the callback url")
+			}
+		}
+		s.jobs.stop()
+	}
+
+	s := NewTssHttpServer("127.0.0.1:8080", &MockTssServer{})
+	res := httptest.NewRecorder()
+	s.tssNewHandler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/jobs/unknown", nil))
+	c.Assert(res.Code, Equals, http.StatusNotFound)
+}
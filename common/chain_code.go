@@ -0,0 +1,101 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+// SendChainCode sends the chain code of the pool to the given peers, the old committee tells it the new members in
+// the regroup as they can't compute it from their key shares
+func (t *TssCommon) SendChainCode(chainCode []byte, peers []peer.ID) error {
+	if len(peers) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(messages.TssChainCode{ChainCode: chainCode})
+	if err != nil {
+		return fmt.Errorf("fail to marshal the chain code: %w", err)
+	}
+	t.renderToP2P(&messages.BroadcastMsgChan{
+		WrappedMessage: messages.WrappedMessage{
+			MessageType: messages.TSSChainCodeMsg,
+			MsgID:       t.msgID,
+			Payload:     data,
+		},
+		PeersID: peers,
+	})
+	return nil
+}
+
+// processChainCode keeps the chain code the peer sent, only the members of the old committee know it
+func (t *TssCommon) processChainCode(chainCode []byte, peerID string) error {
+	if len(chainCode) != 32 {
+		return fmt.Errorf("invalid chain code length %d from peer %s", len(chainCode), peerID)
+	}
+	partyInfo := t.getPartyInfo()
+	if partyInfo == nil {
+		return errors.New("the parties of the regroup aren't set")
+	}
+	oldMember := false
+	for _, el := range partyInfo.OldPartyIDMap {
+		oldPeerID, err := conversion.GetPeerIDFromPartyID(el)
+		if err == nil && oldPeerID.String() == peerID {
+			oldMember = true
+			break
+		}
+	}
+	if !oldMember {
+		return fmt.Errorf("peer %s isn't in the old committee, its chain code is ignored", peerID)
+	}
+	t.chainCodeLock.Lock()
+	defer t.chainCodeLock.Unlock()
+	if _, ok := t.chainCodes[peerID]; ok {
+		return fmt.Errorf("duplicated chain code from peer %s ignored", peerID)
+	}
+	t.chainCodes[peerID] = chainCode
+	select {
+	case t.chainCodeUpdate <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// agreedChainCode returns the chain code sent by at least quorum members of the old committee
+func (t *TssCommon) agreedChainCode(quorum int) ([]byte, bool) {
+	t.chainCodeLock.Lock()
+	defer t.chainCodeLock.Unlock()
+	freq := make(map[string]int, len(t.chainCodes))
+	for _, el := range t.chainCodes {
+		key := hex.EncodeToString(el)
+		freq[key]++
+		if freq[key] >= quorum {
+			return el, true
+		}
+	}
+	return nil, false
+}
+
+// WaitChainCode waits until quorum members of the old committee sent the same chain code, with more than the threshold
+// of the old committee the dishonest members can't make the new members take another chain code
+func (t *TssCommon) WaitChainCode(quorum int, stopChan chan struct{}, timeout time.Duration) ([]byte, error) {
+	deadline := time.After(timeout)
+	for {
+		if chainCode, ok := t.agreedChainCode(quorum); ok {
+			return chainCode, nil
+		}
+		select {
+		case <-t.chainCodeUpdate:
+		case <-stopChan:
+			return nil, errors.New("received exit signal")
+		case <-deadline:
+			return nil, fmt.Errorf("fail to get the chain code from %d members of the old committee in %s", quorum, timeout)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package common
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/HyperCore-Team/tss-lib/tss"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+// HashMode tells how a keysign message becomes the digest which is signed
+type HashMode string
+
+const (
+	// HashModeNone signs the message as it is, it is the behaviour of the keysigns which don't give a hash mode
+	HashModeNone HashMode = ""
+	// HashModePrehashed signs a 32 bytes digest computed by the client
+	HashModePrehashed HashMode = "prehashed"
+	// HashModeSHA256 signs the SHA-256 of the message
+	HashModeSHA256 HashMode = "sha256"
+	// HashModeDoubleSHA256 signs the SHA-256 of the SHA-256 of the message, as bitcoin does
+	HashModeDoubleSHA256 HashMode = "sha256d"
+	// HashModeKeccak256 signs the legacy Keccak-256 of the message, as ethereum does
+	HashModeKeccak256 HashMode = "keccak256"
+	// HashModeSHA512 signs the SHA-512 of the message, ecdsa takes the leftmost 32 bytes of it
+	HashModeSHA512 HashMode = "sha512"
+	// HashModeRaw signs the whole message with ed25519, which hashes it itself
+	HashModeRaw HashMode = "raw"
+)
+
+// ErrInvalidHashMode is returned for a hash mode which is unknown or can't be used by the algo of the pool
+var ErrInvalidHashMode = errors.New("invalid hash mode")
+
+// Validate checks the hash mode can be used by the keysigns of the given algo
+func (m HashMode) Validate(algo messages.Algo) error {
+	switch m {
+	case HashModeNone:
+		return nil
+	case HashModeRaw:
+		if algo == messages.EDDSAKEYSIGN {
+			return nil
+		}
+	case HashModePrehashed, HashModeSHA256, HashModeDoubleSHA256, HashModeKeccak256:
+		// ed25519 hashes the message itself, the digests aren't signed as they are
+		if algo == messages.ECDSAKEYSIGN || algo == messages.SCHNORRKEYSIGN {
+			return nil
+		}
+	case HashModeSHA512:
+		// BIP-340 signs 32 bytes
+		if algo == messages.ECDSAKEYSIGN {
+			return nil
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidHashMode, m)
+	}
+	return fmt.Errorf("%w: %s can't be used by the algo of the pool", ErrInvalidHashMode, m)
+}
+
+// HashMessage returns the digest of the message which is signed in the given hash mode
+func HashMessage(msg []byte, mode HashMode) ([]byte, error) {
+	switch mode {
+	case HashModeNone, HashModeRaw:
+		return msg, nil
+	case HashModePrehashed:
+		if len(msg) != sha256.Size {
+			return nil, fmt.Errorf("the prehashed message must be %d bytes, got %d", sha256.Size, len(msg))
+		}
+		return msg, nil
+	case HashModeSHA256:
+		digest := sha256.Sum256(msg)
+		return digest[:], nil
+	case HashModeDoubleSHA256:
+		first := sha256.Sum256(msg)
+		digest := sha256.Sum256(first[:])
+		return digest[:], nil
+	case HashModeKeccak256:
+		h := sha3.NewLegacyKeccak256()
+		h.Write(msg)
+		return h.Sum(nil), nil
+	case HashModeSHA512:
+		digest := sha512.Sum512(msg)
+		return digest[:], nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidHashMode, mode)
+	}
+}
+
+// MsgToHashIntWithMode is MsgToHashInt of the digest of the message in the given hash mode
+func MsgToHashIntWithMode(msg []byte, algo messages.Algo, mode HashMode) (*big.Int, error) {
+	if err := mode.Validate(algo); err != nil {
+		return nil, err
+	}
+	digest, err := HashMessage(msg, mode)
+	if err != nil {
+		return nil, err
+	}
+	if mode == HashModeRaw {
+		// the eddsa signing of tss-lib signs the bytes of the number, so the leading zeros would be lost
+		if len(digest) == 0 || digest[0] == 0 {
+			return nil, errors.New("the raw message must not start with a zero byte")
+		}
+		return new(big.Int).SetBytes(digest), nil
+	}
+	if algo == messages.EDDSAKEYSIGN {
+		return hashToInt(digest, tss.Edwards()), nil
+	}
+	return hashToInt(digest, tss.S256()), nil
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+type HashModeTestSuite struct{}
+
+var _ = Suite(&HashModeTestSuite{})
+
+func (HashModeTestSuite) TestHashMessage(c *C) {
+	testCases := []struct {
+		mode     HashMode
+		msg      string
+		expected string
+	}{
+		// the hash of an ethereum payload, the empty input is the well known Keccak-256 of nothing
+		{HashModeKeccak256, "", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		// the hash of a bitcoin payload is the SHA-256 of the SHA-256
+		{HashModeDoubleSHA256, "hello", "9595c9df90075148eb06860365df33584b75bff782a510c6cd4883a419833d50"},
+		{HashModeSHA256, "hello", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{HashModeNone, "hello", hex.EncodeToString([]byte("hello"))},
+		{HashModeRaw, "hello", hex.EncodeToString([]byte("hello"))},
+	}
+	for _, tc := range testCases {
+		digest, err := HashMessage([]byte(tc.msg), tc.mode)
+		c.Assert(err, IsNil)
+		c.Assert(hex.EncodeToString(digest), Equals, tc.expected, Commentf("%s", tc.mode))
+	}
+	digest, err := HashMessage([]byte("hello"), HashModeSHA512)
+	c.Assert(err, IsNil)
+	c.Assert(digest, HasLen, 64)
+
+	// a prehashed message must be a digest
+	_, err = HashMessage([]byte("hello"), HashModePrehashed)
+	c.Assert(err, NotNil)
+	digest, err = HashMessage(make([]byte, 32), HashModePrehashed)
+	c.Assert(err, IsNil)
+	c.Assert(digest, HasLen, 32)
+
+	_, err = HashMessage([]byte("hello"), HashMode("md5"))
+	c.Assert(errors.Is(err, ErrInvalidHashMode), Equals, true)
+}
+
+func (HashModeTestSuite) TestValidate(c *C) {
+	testCases := []struct {
+		mode  HashMode
+		algo  messages.Algo
+		valid bool
+	}{
+		{HashModeNone, messages.ECDSAKEYSIGN, true},
+		{HashModeNone, messages.EDDSAKEYSIGN, true},
+		{HashModeKeccak256, messages.ECDSAKEYSIGN, true},
+		{HashModeDoubleSHA256, messages.SCHNORRKEYSIGN, true},
+		{HashModeSHA512, messages.ECDSAKEYSIGN, true},
+		{HashModeSHA512, messages.SCHNORRKEYSIGN, false},
+		{HashModeSHA256, messages.EDDSAKEYSIGN, false},
+		{HashModeRaw, messages.EDDSAKEYSIGN, true},
+		{HashModeRaw, messages.ECDSAKEYSIGN, false},
+		{HashMode("md5"), messages.ECDSAKEYSIGN, false},
+	}
+	for _, tc := range testCases {
+		err := tc.mode.Validate(tc.algo)
+		c.Assert(err == nil, Equals, tc.valid, Commentf("%s %d", tc.mode, tc.algo))
+		if err != nil {
+			c.Assert(errors.Is(err, ErrInvalidHashMode), Equals, true)
+		}
+	}
+}
+
+func (HashModeTestSuite) TestMsgToHashIntWithMode(c *C) {
+	payload := []byte("an ethereum transaction")
+	m, err := MsgToHashIntWithMode(payload, messages.ECDSAKEYSIGN, HashModeKeccak256)
+	c.Assert(err, IsNil)
+	digest, err := HashMessage(payload, HashModeKeccak256)
+	c.Assert(err, IsNil)
+	c.Assert(m.Cmp(new(big.Int).SetBytes(digest)), Equals, 0)
+
+	// the SHA-512 digest is truncated to the leftmost 32 bytes for secp256k1
+	m, err = MsgToHashIntWithMode(payload, messages.ECDSAKEYSIGN, HashModeSHA512)
+	c.Assert(err, IsNil)
+	digest, err = HashMessage(payload, HashModeSHA512)
+	c.Assert(err, IsNil)
+	c.Assert(m.Cmp(new(big.Int).SetBytes(digest[:32])), Equals, 0)
+
+	// the raw messages of ed25519 are signed whole
+	long := []byte("a raw message which is longer than thirty two bytes")
+	m, err = MsgToHashIntWithMode(long, messages.EDDSAKEYSIGN, HashModeRaw)
+	c.Assert(err, IsNil)
+	c.Assert(m.Bytes(), DeepEquals, long)
+	_, err = MsgToHashIntWithMode([]byte{0, 1}, messages.EDDSAKEYSIGN, HashModeRaw)
+	c.Assert(err, NotNil)
+
+	// no hash mode is the same as MsgToHashInt
+	legacy, err := MsgToHashInt(payload, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	m, err = MsgToHashIntWithMode(payload, messages.ECDSAKEYSIGN, HashModeNone)
+	c.Assert(err, IsNil)
+	c.Assert(m.Cmp(legacy), Equals, 0)
+}
@@ -53,6 +53,12 @@ type TssCommon struct {
 	cachedWireBroadcastMsgLists *sync.Map
 	cachedWireUnicastMsgLists   *sync.Map
 	msgNum                      int
+	// roundObserver is told the type of every message this node sends, it is used to report the round in progress
+	roundObserver func(msgType string)
+	// chainCodes are the chain codes the old committee sent in the regroup by their peer
+	chainCodes      map[string][]byte
+	chainCodeLock   *sync.Mutex
+	chainCodeUpdate chan struct{}
 }
 
 func NewTssCommon(peerID string, broadcastChannel chan *messages.BroadcastMsgChan, conf TssConfig, msgID string, privKey tcrypto.PrivKey, msgNum int) *TssCommon {
@@ -78,6 +84,9 @@ func NewTssCommon(peerID string, broadcastChannel chan *messages.BroadcastMsgCha
 		cachedWireBroadcastMsgLists: &sync.Map{},
 		cachedWireUnicastMsgLists:   &sync.Map{},
 		msgNum:                      msgNum,
+		chainCodes:                  make(map[string][]byte),
+		chainCodeLock:               &sync.Mutex{},
+		chainCodeUpdate:             make(chan struct{}, 1),
 	}
 }
 
@@ -164,6 +173,18 @@ func (t *TssCommon) GetBlameMgr() *blame.Manager {
 	return t.blameMgr
 }
 
+// SetRoundObserver sets the func which is told the type of every message this node sends, it has to be set before
+// the ceremony starts
+func (t *TssCommon) SetRoundObserver(observer func(msgType string)) {
+	t.roundObserver = observer
+}
+
+func (t *TssCommon) observeRound(msg btss.Message) {
+	if t.roundObserver != nil {
+		t.roundObserver(msg.Type())
+	}
+}
+
 func (t *TssCommon) SetPartyInfo(partyInfo *PartyInfo) {
 	t.partyLock.Lock()
 	defer t.partyLock.Unlock()
@@ -361,13 +382,13 @@ func (t *TssCommon) ProcessOneMessage(wrappedMsg *messages.WrappedMessage, peerI
 	}
 
 	switch wrappedMsg.MessageType {
-	case messages.TSSKeyGenMsg, messages.TSSKeySignMsg, messages.TSSPartyReGroupMsg:
+	case messages.TSSKeyGenMsg, messages.TSSKeySignMsg, messages.TSSPartyReGroupMsg, messages.TSSRefreshMsg:
 		var wireMsg messages.WireMessage
 		if err := json.Unmarshal(wrappedMsg.Payload, &wireMsg); nil != err {
 			return fmt.Errorf("fail to unmarshal wire message: %w", err)
 		}
 		return t.processTSSMsg(&wireMsg, wrappedMsg.MessageType, false)
-	case messages.TSSKeyGenVerMsg, messages.TSSKeySignVerMsg, messages.TSSPartReGroupVerMSg:
+	case messages.TSSKeyGenVerMsg, messages.TSSKeySignVerMsg, messages.TSSPartReGroupVerMSg, messages.TSSRefreshVerMsg:
 		var bMsg messages.BroadcastConfirmMessage
 		if err := json.Unmarshal(wrappedMsg.Payload, &bMsg); nil != err {
 			return errors.New("fail to unmarshal broadcast confirm message")
@@ -416,6 +437,12 @@ func (t *TssCommon) ProcessOneMessage(wrappedMsg *messages.WrappedMessage, peerI
 		}
 		t.logger.Info().Msg("we got the missing share from the peer")
 		return t.processTSSMsg(wireMsg.Msg, wireMsg.RequestType, true)
+	case messages.TSSChainCodeMsg:
+		var chainCodeMsg messages.TssChainCode
+		if err := json.Unmarshal(wrappedMsg.Payload, &chainCodeMsg); nil != err {
+			return fmt.Errorf("fail to unmarshal the chain code message: %w", err)
+		}
+		return t.processChainCode(chainCodeMsg.ChainCode, peerID)
 	}
 
 	return nil
@@ -538,6 +565,7 @@ func (t *TssCommon) ProcessOutCh(msg btss.Message, msgType messages.THORChainTSS
 	if err != nil {
 		return fmt.Errorf("fail to get wire bytes: %w", err)
 	}
+	t.observeRound(msg)
 
 	if r.IsBroadcast {
 		cachedWiredMsg := NewBulkWireMsg(msgData, msg.GetFrom().Moniker, r)
@@ -666,7 +694,10 @@ func (t *TssCommon) requestShareFromPeer(localCacheItem *LocalCacheItem, thresho
 	case messages.TSSPartReGroupVerMSg:
 		msg.RequestType = messages.TSSPartyReGroupMsg
 		return t.processRequestMsgFromPeer(peersIDs, msg, true)
-	case messages.TSSKeySignMsg, messages.TSSKeyGenMsg, messages.TSSPartyReGroupMsg:
+	case messages.TSSRefreshVerMsg:
+		msg.RequestType = messages.TSSRefreshMsg
+		return t.processRequestMsgFromPeer(peersIDs, msg, true)
+	case messages.TSSKeySignMsg, messages.TSSKeyGenMsg, messages.TSSPartyReGroupMsg, messages.TSSRefreshMsg:
 		msg.RequestType = msgType
 		return t.processRequestMsgFromPeer(peersIDs, msg, true)
 	default:
@@ -842,6 +873,8 @@ func getBroadcastMessageType(msgType messages.THORChainTSSMessageType) messages.
 		return messages.TSSKeySignVerMsg
 	case messages.TSSPartyReGroupMsg:
 		return messages.TSSPartReGroupVerMSg
+	case messages.TSSRefreshMsg:
+		return messages.TSSRefreshVerMsg
 	default:
 		return messages.Unknown // this should not happen
 	}
@@ -912,6 +945,7 @@ func (t *TssCommon) ProcessRegroupOutCh(msg btss.Message, msgType messages.THORC
 	if err != nil {
 		return fmt.Errorf("fail to get wire bytes: %w", err)
 	}
+	t.observeRound(msg)
 
 	cachedWiredMsg := NewBulkWireMsg(msgData, moniker, r)
 	wiredMsgType := msg.Type()
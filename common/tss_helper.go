@@ -20,7 +20,6 @@ import (
 	ecdsaKeySign "github.com/HyperCore-Team/tss-lib/ecdsa/signing"
 	eddsaKeygen "github.com/HyperCore-Team/tss-lib/eddsa/keygen"
 	eddsaSigning "github.com/HyperCore-Team/tss-lib/eddsa/signing"
-	"github.com/HyperCore-Team/tss-lib/tss"
 	btss "github.com/HyperCore-Team/tss-lib/tss"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/rs/zerolog"
@@ -44,13 +43,10 @@ func Contains(s []*btss.PartyID, e *btss.PartyID) bool {
 }
 
 func MsgToHashInt(msg []byte, algo messages.Algo) (*big.Int, error) {
-	if algo == messages.ECDSAKEYSIGN {
-		return hashToInt(msg, tss.S256()), nil
-	} else if algo == messages.EDDSAKEYSIGN {
-		return hashToInt(msg, tss.Edwards()), nil
-	} else {
+	if algo != messages.ECDSAKEYSIGN && algo != messages.EDDSAKEYSIGN && algo != messages.SCHNORRKEYSIGN {
 		return nil, errors.New("invalid algo")
 	}
+	return MsgToHashIntWithMode(msg, algo, HashModeNone)
 }
 
 func MsgToHashString(msg []byte) (string, error) {
@@ -131,6 +127,10 @@ func getHighestFreq(confirmedList map[string]string) (string, int, error) {
 // an error in this round, we check whether the previous round is the unicast
 func checkUnicast(round blame.RoundInfo) bool {
 	index := round.Index
+	// all the messages of the schnorr signing are broadcast
+	if strings.HasPrefix(round.RoundMsg, "Schnorr") {
+		return false
+	}
 	isEddsa := strings.Contains(round.RoundMsg, "EDDSA")
 	if isEddsa {
 		isKeyGen := strings.Contains(round.RoundMsg, "KGR")
@@ -358,6 +358,23 @@ func GetMsgRound(msg []byte, partyID *btss.PartyID, isBroadcast bool) (blame.Rou
 			RoundMsg: messages.EDDSAKEYREGROUP4,
 		}, nil
 
+	// SCHNORR -- Signing
+	case *messages.SchnorrSignRound1Message:
+		return blame.RoundInfo{
+			Index:    0,
+			RoundMsg: messages.SCHNORRKEYSIGN1,
+		}, nil
+	case *messages.SchnorrSignRound2Message:
+		return blame.RoundInfo{
+			Index:    1,
+			RoundMsg: messages.SCHNORRKEYSIGN2,
+		}, nil
+	case *messages.SchnorrSignRound3Message:
+		return blame.RoundInfo{
+			Index:    2,
+			RoundMsg: messages.SCHNORRKEYSIGN3,
+		}, nil
+
 	default:
 		{
 			return blame.RoundInfo{}, errors.New("unknown round")
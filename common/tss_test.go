@@ -70,6 +70,25 @@ func (t *TssTestSuite) TestGetThreshold(c *C) {
 	c.Assert(output, Equals, 65)
 }
 
+func (t *TssTestSuite) TestResolveThreshold(c *C) {
+	// the default threshold is used if it is not set
+	output, err := conversion.ResolveThreshold(0, 4)
+	c.Assert(err, IsNil)
+	c.Assert(output, Equals, 2)
+	// 3-of-5
+	output, err = conversion.ResolveThreshold(2, 5)
+	c.Assert(err, IsNil)
+	c.Assert(output, Equals, 2)
+	// n-of-n
+	output, err = conversion.ResolveThreshold(4, 5)
+	c.Assert(err, IsNil)
+	c.Assert(output, Equals, 4)
+	_, err = conversion.ResolveThreshold(5, 5)
+	c.Assert(err, NotNil)
+	_, err = conversion.ResolveThreshold(-1, 5)
+	c.Assert(err, NotNil)
+}
+
 func (t *TssTestSuite) TestMsgToHashInt(c *C) {
 	input := []byte("whatever")
 	result, err := MsgToHashInt(input, messages.ECDSAKEYSIGN)
@@ -452,3 +471,39 @@ func (t *TssTestSuite) TestProcessInvalidMsgBlame(c *C) {
 	// for the last one, since we do not store the msg before hand, it should return no record of this party
 	c.Assert(blameResult.BlameNodes[2].BlameData, HasLen, 0)
 }
+
+func (t *TssTestSuite) TestProcessChainCode(c *C) {
+	tssCommon := NewTssCommon("", nil, TssConfig{}, "message-id", t.privKey, 1)
+	oldPartiesID, _, err := conversion.GetParties(testPubKeys[:3], "", false, "old_party")
+	c.Assert(err, IsNil)
+	tssCommon.SetPartyInfo(&PartyInfo{OldPartyIDMap: conversion.SetupPartyIDMap(oldPartiesID)})
+	var peers []string
+	for _, el := range testPubKeys {
+		peerID, err := conversion.GetPeerIDFromPubKey(el)
+		c.Assert(err, IsNil)
+		peers = append(peers, peerID.String())
+	}
+	chainCode := bytes.Repeat([]byte{1}, 32)
+	wrapChainCode := func(chainCode []byte) *messages.WrappedMessage {
+		buf, err := json.Marshal(messages.TssChainCode{ChainCode: chainCode})
+		c.Assert(err, IsNil)
+		return &messages.WrappedMessage{MessageType: messages.TSSChainCodeMsg, Payload: buf}
+	}
+
+	c.Assert(tssCommon.ProcessOneMessage(wrapChainCode(chainCode), peers[0]), IsNil)
+	c.Assert(tssCommon.ProcessOneMessage(wrapChainCode(chainCode), peers[0]), ErrorMatches, "duplicated chain code.*")
+	// the new member isn't in the old committee
+	c.Assert(tssCommon.ProcessOneMessage(wrapChainCode(chainCode), peers[3]), ErrorMatches, ".*isn't in the old committee.*")
+	c.Assert(tssCommon.ProcessOneMessage(wrapChainCode(chainCode[:16]), peers[1]), ErrorMatches, "invalid chain code length.*")
+	c.Assert(tssCommon.ProcessOneMessage(wrapChainCode(bytes.Repeat([]byte{2}, 32)), peers[1]), IsNil)
+	_, err = tssCommon.WaitChainCode(2, nil, time.Millisecond*20)
+	c.Assert(err, NotNil)
+
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		c.Check(tssCommon.ProcessOneMessage(wrapChainCode(chainCode), peers[2]), IsNil)
+	}()
+	agreed, err := tssCommon.WaitChainCode(2, nil, time.Second)
+	c.Assert(err, IsNil)
+	c.Assert(agreed, DeepEquals, chainCode)
+}
@@ -17,8 +17,45 @@ type TssConfig struct {
 	PreParamTimeout time.Duration
 	// enable the tss monitor
 	EnableMonitor bool
+	// RetirementPolicy defines what to do with the old key share of a node which is not a member of the regrouped committee
+	RetirementPolicy RetirementPolicy
+	// RetirementDelay defines how long we wait after a successful regroup before the old key share is retired,
+	// if it is zero the old key share is only retired by an explicit ConfirmRegroup
+	RetirementDelay time.Duration
+	// MaxConcurrentCeremonies defines how many keygens, regroups and refreshes run at the same time, the others wait
+	// in the queue, it defaults to 1
+	MaxConcurrentCeremonies int
+	// CeremonyQueueSize defines how many keygens, regroups and refreshes may wait for a free slot, the ones beyond it
+	// are rejected, 0 doesn't limit the queue
+	CeremonyQueueSize int
+	// BatchKeySignWorkers defines how many keysigns of a batch run at the same time, the nodes of the pools should use
+	// the same number, so they join the parties of the batch in the same order, it defaults to 1
+	BatchKeySignWorkers int
+	// KeySignCacheWindow defines how long the signatures of a keysign answer the same request again without a new
+	// ceremony, 0 doesn't cache them
+	KeySignCacheWindow time.Duration
+	// SignatureCacheSize defines how many signatures of the recent keysigns are kept on disk, a node which restarted
+	// answers the keysigns it finished before from them, 0 doesn't keep any
+	SignatureCacheSize int
+	// SignatureCacheTTL defines how long the signatures are kept, 0 keeps them until there are too many
+	SignatureCacheTTL time.Duration
+	// PreParamsPoolSize defines how many pre-parameters are generated ahead of the ECDSA keygens, it defaults to 1, a
+	// negative size generates them only when a keygen needs them
+	PreParamsPoolSize int
 }
 
+// RetirementPolicy defines how the key share of a pool we are no longer a member of is retired
+type RetirementPolicy string
+
+const (
+	// RetireNone keeps the old key share
+	RetireNone RetirementPolicy = ""
+	// RetireArchive moves the old key share to the archive
+	RetireArchive RetirementPolicy = "archive"
+	// RetireWipe overwrites and removes the old key share, the kv state manager doesn't support it
+	RetireWipe RetirementPolicy = "wipe"
+)
+
 const (
 	NewParty = "new_party"
 	OldParty = "old_party"
@@ -0,0 +1,144 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+// FieldError tells why a field of a request is invalid, the field is named as in the json of the request, e.g.
+// keys[2] for the third key
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Err)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+type fieldErrorJSON struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// MarshalJSON encodes the field error as its field and the message of its error
+func (e FieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fieldErrorJSON{Field: e.Field, Message: e.Err.Error()})
+}
+
+// UnmarshalJSON decodes the field error of MarshalJSON, only the message of the error is kept
+func (e *FieldError) UnmarshalJSON(data []byte) error {
+	var out fieldErrorJSON
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	e.Field = out.Field
+	e.Err = errors.New(out.Message)
+	return nil
+}
+
+// FieldErrors are all the invalid fields of a request, the Validate methods of the requests return them
+type FieldErrors []FieldError
+
+// Add records the error of the field
+func (e *FieldErrors) Add(field string, err error) {
+	*e = append(*e, FieldError{Field: field, Err: err})
+}
+
+// Addf records the error of the field with the given message
+func (e *FieldErrors) Addf(field, format string, args ...interface{}) {
+	e.Add(field, fmt.Errorf(format, args...))
+}
+
+func (e FieldErrors) Error() string {
+	fields := make([]string, len(e))
+	for i, el := range e {
+		fields[i] = el.Error()
+	}
+	return "invalid fields: " + strings.Join(fields, "; ")
+}
+
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, el := range e {
+		errs[i] = el
+	}
+	return errs
+}
+
+// Err returns the field errors as an error, it is nil if no field is invalid
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// CheckNodePubKeys checks every key is the pub key of a node and no key is given twice
+func (e *FieldErrors) CheckNodePubKeys(field string, keys []string) {
+	seen := make(map[string]bool, len(keys))
+	for i, el := range keys {
+		name := fmt.Sprintf("%s[%d]", field, i)
+		if _, err := conversion.GetPeerIDFromPubKey(el); err != nil {
+			e.Addf(name, "invalid node pub key %q", el)
+			continue
+		}
+		if seen[el] {
+			e.Addf(name, "duplicate node pub key %s", el)
+			continue
+		}
+		seen[el] = true
+	}
+}
+
+// CheckPoolPubKey checks the pub key can be a pool of the given algo, the ecdsa pub keys are compressed and the eddsa
+// and schnorr pub keys are 32 bytes
+func (e *FieldErrors) CheckPoolPubKey(field, pubKey, algo string) {
+	if pubKey == "" {
+		e.Addf(field, "empty pool pub key")
+		return
+	}
+	pubKeyBytes, err := base64.StdEncoding.DecodeString(pubKey)
+	if err != nil {
+		e.Addf(field, "the pool pub key isn't base64: %w", err)
+		return
+	}
+	expected := 0
+	switch algo {
+	case "ecdsa":
+		expected = 33
+	case "eddsa", "schnorr":
+		expected = 32
+	}
+	if expected != 0 && len(pubKeyBytes) != expected {
+		e.Addf(field, "the %s pool pub key must be %d bytes, got %d", algo, expected, len(pubKeyBytes))
+	}
+}
+
+// CheckVersion checks the tss version is a semantic version
+func (e *FieldErrors) CheckVersion(field, version string) {
+	if version == "" {
+		e.Addf(field, "empty tss version")
+		return
+	}
+	if _, err := conversion.VersionLTCheck(version, messages.NEWJOINPARTYVERSION); err != nil {
+		e.Addf(field, "invalid tss version %q", version)
+	}
+}
+
+// CheckBlockHeight checks the block height isn't negative
+func (e *FieldErrors) CheckBlockHeight(field string, blockHeight int64) {
+	if blockHeight < 0 {
+		e.Addf(field, "negative block height %d", blockHeight)
+	}
+}
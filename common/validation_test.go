@@ -0,0 +1,36 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+type ValidationTestSuite struct{}
+
+var _ = Suite(&ValidationTestSuite{})
+
+func (ValidationTestSuite) TestFieldErrors(c *C) {
+	var errs FieldErrors
+	c.Assert(errs.Err(), IsNil)
+	errs.CheckNodePubKeys("keys", []string{"D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=", "!", "D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY="})
+	errs.CheckPoolPubKey("pool_pub_key", "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq", "ecdsa")
+	errs.CheckVersion("tss_version", "0.14.0")
+	errs.Add("hash_mode", ErrInvalidHashMode)
+	err := errs.Err()
+	c.Assert(err, NotNil)
+	c.Assert(errs, HasLen, 3)
+	c.Assert(errs[0].Field, Equals, "keys[1]")
+	c.Assert(errs[1].Field, Equals, "keys[2]")
+	c.Assert(errors.Is(err, ErrInvalidHashMode), Equals, true)
+	c.Assert(err.Error(), Matches, "invalid fields: keys\\[1\\]: .*; keys\\[2\\]: .*; hash_mode: invalid hash mode")
+
+	buf, err := json.Marshal(errs)
+	c.Assert(err, IsNil)
+	var decoded FieldErrors
+	c.Assert(json.Unmarshal(buf, &decoded), IsNil)
+	c.Assert(decoded, HasLen, 3)
+	c.Assert(decoded[2].Field, Equals, "hash_mode")
+	c.Assert(decoded[2].Err.Error(), Equals, ErrInvalidHashMode.Error())
+}
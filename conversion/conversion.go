@@ -184,6 +184,18 @@ func GetThreshold(value int) (int, error) {
 	return threshold, nil
 }
 
+// ResolveThreshold returns the threshold of a pool with partyNum parties, threshold+1 parties are needed to sign.
+// The given threshold is used if it is set, otherwise it falls back to the default threshold of GetThreshold
+func ResolveThreshold(threshold, partyNum int) (int, error) {
+	if threshold == 0 {
+		return GetThreshold(partyNum)
+	}
+	if threshold < 1 || threshold >= partyNum {
+		return 0, fmt.Errorf("invalid threshold %d for %d parties", threshold, partyNum)
+	}
+	return threshold, nil
+}
+
 func GetTssPubKeyECDSA(pubKeyPoint *crypto.ECPoint) (string, error) {
 	// we check whether the point is on curve according to Kudelski report
 	if pubKeyPoint == nil || !isOnCurve(pubKeyPoint.X(), pubKeyPoint.Y(), btss.S256()) {
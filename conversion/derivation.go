@@ -0,0 +1,148 @@
+package conversion
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/HyperCore-Team/tss-lib/crypto"
+	"github.com/HyperCore-Team/tss-lib/crypto/ckd"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/sha3"
+)
+
+// chainCodeDomain separates the chain codes of the pools from any other hash of their pub keys
+const chainCodeDomain = "go-tss/chain-code"
+
+// ChainCodeFromKeyShares returns the chain code of the ECDSA pool created by the keygen, it hashes the public key shares
+// of the parties in their order. They depend on the secrets of every party and only the parties know them, so every
+// member computes the same chain code without another round, while no one else can link the child keys to the pool.
+func ChainCodeFromKeyShares(bigXj []*crypto.ECPoint) ([]byte, error) {
+	if len(bigXj) == 0 {
+		return nil, errors.New("no key shares")
+	}
+	hasher := sha256.New()
+	hasher.Write([]byte(chainCodeDomain))
+	for i, el := range bigXj {
+		if el == nil {
+			return nil, fmt.Errorf("key share %d is missing", i)
+		}
+		pk := btcec.PublicKey{Curve: btcec.S256(), X: el.X(), Y: el.Y()}
+		hasher.Write(pk.SerializeCompressed())
+	}
+	return hasher.Sum(nil), nil
+}
+
+// ChainCodeFromPubKey returns the chain code of the ECDSA pools created before the chain code was taken from the key
+// shares, anyone who knows the pub key computes it, so it is only kept for the child keys of those pools
+func ChainCodeFromPubKey(poolPubKey string) ([]byte, error) {
+	pk, err := parseECDSAPubKey(poolPubKey)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(append([]byte(chainCodeDomain), pk.SerializeCompressed()...))
+	return hash[:], nil
+}
+
+// ParseDerivationPath parses a BIP-32 path like m/0/1, only non-hardened indexes are supported as the private key of
+// the pool is never assembled
+func ParseDerivationPath(path string) ([]uint32, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "m"), "/")
+	if path == "" {
+		return nil, errors.New("empty derivation path")
+	}
+	var indexes []uint32
+	for _, el := range strings.Split(path, "/") {
+		if strings.HasSuffix(el, "'") || strings.HasSuffix(el, "h") || strings.HasSuffix(el, "H") {
+			return nil, fmt.Errorf("hardened index %s is not supported", el)
+		}
+		index, err := strconv.ParseUint(el, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %s: %w", el, err)
+		}
+		if index >= ckd.HardenedKeyStart {
+			return nil, fmt.Errorf("index %s is hardened, it is not supported", el)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// DeriveChildKey derives the child of the ECDSA pool at the given path, it returns the delta the key shares are
+// shifted by and the child public key
+func DeriveChildKey(poolPubKey string, chainCode []byte, path []uint32) (*big.Int, *ecdsa.PublicKey, error) {
+	pk, err := parseECDSAPubKey(poolPubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(chainCode) != 32 {
+		return nil, nil, fmt.Errorf("invalid chain code length %d", len(chainCode))
+	}
+	parent := &ckd.ExtendedKey{
+		PublicKey: *pk.ToECDSA(),
+		ChainCode: chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+	curve := btss.S256()
+	delta, child, err := ckd.DeriveChildKeyFromHierarchy(path, parent, curve.Params().N, curve)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fail to derive the child key: %w", err)
+	}
+	return delta, &child.PublicKey, nil
+}
+
+// DeriveChildPubKey derives the pub key of the child of the ECDSA pool at the given path without a ceremony, it is
+// encoded like the pool pub keys
+func DeriveChildPubKey(poolPubKey string, chainCode []byte, path string) (string, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return "", err
+	}
+	_, child, err := DeriveChildKey(poolPubKey, chainCode, indexes)
+	if err != nil {
+		return "", err
+	}
+	childPk := btcec.PublicKey{Curve: btcec.S256(), X: child.X, Y: child.Y}
+	return base64.StdEncoding.EncodeToString(childPk.SerializeCompressed()), nil
+}
+
+// GetEthereumAddress returns the EIP-55 checksummed address of the given ECDSA pub key
+func GetEthereumAddress(pubKey string) (string, error) {
+	pk, err := parseECDSAPubKey(pubKey)
+	if err != nil {
+		return "", err
+	}
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(pk.SerializeUncompressed()[1:])
+	addr := hex.EncodeToString(hasher.Sum(nil)[12:])
+
+	hasher = sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(addr))
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	result := []byte(addr)
+	for i, c := range result {
+		if c >= 'a' && checksum[i] >= '8' {
+			result[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(result), nil
+}
+
+func parseECDSAPubKey(pubKey string) (*btcec.PublicKey, error) {
+	buf, err := base64.StdEncoding.DecodeString(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("fail to decode the pub key: %w", err)
+	}
+	pk, err := btcec.ParsePubKey(buf, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse the pub key: %w", err)
+	}
+	return pk, nil
+}
@@ -0,0 +1,140 @@
+package conversion
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/HyperCore-Team/tss-lib/crypto"
+	"github.com/HyperCore-Team/tss-lib/crypto/ckd"
+	"github.com/btcsuite/btcd/btcec"
+	. "gopkg.in/check.v1"
+)
+
+type DerivationTestSuite struct{}
+
+var _ = Suite(&DerivationTestSuite{})
+
+func (DerivationTestSuite) TestParseDerivationPath(c *C) {
+	testCases := []struct {
+		path    string
+		indexes []uint32
+		valid   bool
+	}{
+		{path: "m/0/1", indexes: []uint32{0, 1}, valid: true},
+		{path: "0/1/2147483647", indexes: []uint32{0, 1, 2147483647}, valid: true},
+		{path: "m"},
+		{path: ""},
+		{path: "m/0'/1"},
+		{path: "m/0h"},
+		{path: "m/2147483648"},
+		{path: "m/a"},
+		{path: "m//1"},
+	}
+	for _, tc := range testCases {
+		c.Log(tc.path)
+		indexes, err := ParseDerivationPath(tc.path)
+		if !tc.valid {
+			c.Assert(err, NotNil)
+			continue
+		}
+		c.Assert(err, IsNil)
+		c.Assert(indexes, DeepEquals, tc.indexes)
+	}
+}
+
+// TestDeriveChildPubKeyBIP32 checks the derivation against the m/0 public derivation of the second test vector of BIP-32
+func (DerivationTestSuite) TestDeriveChildPubKeyBIP32(c *C) {
+	master, err := ckd.NewExtendedKeyFromString("xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB", btcec.S256())
+	c.Assert(err, IsNil)
+	child, err := ckd.NewExtendedKeyFromString("xpub69H7F5d8KSRgmmdJg2KhpAK8SR3DjMwAdkxj3ZuxV27CprR9LgpeyGmXUbC6wb7ERfvrnKZjXoUmmDznezpbZb7ap6r1D3tgFxHmwMkQTPH", btcec.S256())
+	c.Assert(err, IsNil)
+	poolPubKey := base64.StdEncoding.EncodeToString((*btcec.PublicKey)(&master.PublicKey).SerializeCompressed())
+	childPubKey, err := DeriveChildPubKey(poolPubKey, master.ChainCode, "m/0")
+	c.Assert(err, IsNil)
+	c.Assert(childPubKey, Equals, base64.StdEncoding.EncodeToString((*btcec.PublicKey)(&child.PublicKey).SerializeCompressed()))
+
+	_, err = DeriveChildPubKey(poolPubKey, master.ChainCode[:16], "m/0")
+	c.Assert(err, NotNil)
+	_, err = DeriveChildPubKey(poolPubKey, master.ChainCode, "m/0'")
+	c.Assert(err, NotNil)
+}
+
+func (DerivationTestSuite) TestChainCodeFromKeyShares(c *C) {
+	var bigXj []*crypto.ECPoint
+	for i := 0; i < 3; i++ {
+		priKey, err := btcec.NewPrivateKey(btcec.S256())
+		c.Assert(err, IsNil)
+		point, err := crypto.NewECPoint(btcec.S256(), priKey.PubKey().X, priKey.PubKey().Y)
+		c.Assert(err, IsNil)
+		bigXj = append(bigXj, point)
+	}
+	chainCode, err := ChainCodeFromKeyShares(bigXj)
+	c.Assert(err, IsNil)
+	c.Assert(chainCode, HasLen, 32)
+	again, err := ChainCodeFromKeyShares(bigXj)
+	c.Assert(err, IsNil)
+	c.Assert(again, DeepEquals, chainCode)
+
+	// the pub key of the pool alone doesn't tell the chain code
+	poolPubKey, err := bigXj[0].Add(bigXj[1])
+	c.Assert(err, IsNil)
+	poolPk := btcec.PublicKey{Curve: btcec.S256(), X: poolPubKey.X(), Y: poolPubKey.Y()}
+	legacy, err := ChainCodeFromPubKey(base64.StdEncoding.EncodeToString(poolPk.SerializeCompressed()))
+	c.Assert(err, IsNil)
+	c.Assert(legacy, Not(DeepEquals), chainCode)
+
+	swapped, err := ChainCodeFromKeyShares([]*crypto.ECPoint{bigXj[1], bigXj[0], bigXj[2]})
+	c.Assert(err, IsNil)
+	c.Assert(swapped, Not(DeepEquals), chainCode)
+
+	_, err = ChainCodeFromKeyShares(nil)
+	c.Assert(err, NotNil)
+	_, err = ChainCodeFromKeyShares([]*crypto.ECPoint{bigXj[0], nil})
+	c.Assert(err, NotNil)
+}
+
+// TestChildKeySignature checks a signature of the pool key shifted by the delta, which is what the parties sign with,
+// verifies against the derived pub key
+func (DerivationTestSuite) TestChildKeySignature(c *C) {
+	priKey, err := btcec.NewPrivateKey(btcec.S256())
+	c.Assert(err, IsNil)
+	poolPubKey := base64.StdEncoding.EncodeToString(priKey.PubKey().SerializeCompressed())
+	chainCode, err := ChainCodeFromPubKey(poolPubKey)
+	c.Assert(err, IsNil)
+	c.Assert(chainCode, HasLen, 32)
+
+	path, err := ParseDerivationPath("m/44/60/0/7")
+	c.Assert(err, IsNil)
+	delta, _, err := DeriveChildKey(poolPubKey, chainCode, path)
+	c.Assert(err, IsNil)
+	childPriKey := new(big.Int).Add(priKey.D, delta)
+	childPriKey.Mod(childPriKey, btcec.S256().N)
+	x, y := btcec.S256().ScalarBaseMult(childPriKey.Bytes())
+	signer := &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: btcec.S256(), X: x, Y: y}, D: childPriKey}
+
+	hash := sha256.Sum256([]byte("hello"))
+	r, s, err := ecdsa.Sign(rand.Reader, signer, hash[:])
+	c.Assert(err, IsNil)
+
+	childPubKey, err := DeriveChildPubKey(poolPubKey, chainCode, "m/44/60/0/7")
+	c.Assert(err, IsNil)
+	pk, err := parseECDSAPubKey(childPubKey)
+	c.Assert(err, IsNil)
+	c.Assert(ecdsa.Verify(pk.ToECDSA(), hash[:], r, s), Equals, true)
+	// the pool key doesn't verify the signature of the child key
+	c.Assert(ecdsa.Verify(priKey.PubKey().ToECDSA(), hash[:], r, s), Equals, false)
+}
+
+func (DerivationTestSuite) TestGetEthereumAddress(c *C) {
+	// the private key 1, its pub key is the generator
+	_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), []byte{1})
+	addr, err := GetEthereumAddress(base64.StdEncoding.EncodeToString(pubKey.SerializeCompressed()))
+	c.Assert(err, IsNil)
+	c.Assert(addr, Equals, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf")
+
+	_, err = GetEthereumAddress("not a pub key")
+	c.Assert(err, NotNil)
+}
@@ -126,6 +126,12 @@ func CheckKeyOnCurve(pk string, algo messages.Algo) (bool, error) {
 			return false, err
 		}
 		return isOnCurve(btPk.X, btPk.Y, btcec.S256()), nil
+	} else if algo == messages.SCHNORRKEYSIGN || algo == messages.SCHNORRKEYGEN {
+		// the x-only keys are always lifted to a point on the curve
+		if _, err := ParseSchnorrPubKey(pk); err != nil {
+			return false, err
+		}
+		return true, nil
 	}
 	return false, fmt.Errorf("invalid algo")
 }
@@ -0,0 +1,52 @@
+package conversion
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/HyperCore-Team/tss-lib/crypto"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+// GetTssPubKeySchnorr returns the BIP-340 x-only pub key of the schnorr pool, the pool key with the odd Y is stored
+// as it is generated, the signers negate their shares when they sign with it
+func GetTssPubKeySchnorr(pubKeyPoint *crypto.ECPoint) (string, error) {
+	// we check whether the point is on curve according to Kudelski report
+	if pubKeyPoint == nil || !isOnCurve(pubKeyPoint.X(), pubKeyPoint.Y(), btss.S256()) {
+		return "", errors.New("[SCHNORR] invalid points")
+	}
+	var x, y btcec.FieldVal
+	x.SetByteSlice(pubKeyPoint.X().Bytes())
+	y.SetByteSlice(pubKeyPoint.Y().Bytes())
+	pubKey := btcec.NewPublicKey(&x, &y)
+	return base64.StdEncoding.EncodeToString(schnorr.SerializePubKey(pubKey)), nil
+}
+
+// ParseSchnorrPubKey parses the x-only pub key of a schnorr pool, the point with the even Y is returned
+func ParseSchnorrPubKey(pubKey string) (*btcec.PublicKey, error) {
+	buf, err := base64.StdEncoding.DecodeString(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("fail to decode the pub key: %w", err)
+	}
+	pk, err := schnorr.ParsePubKey(buf)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse the x-only pub key: %w", err)
+	}
+	return pk, nil
+}
+
+// VerifySchnorrSignature verifies the 64 bytes BIP-340 signature of the message against the x-only pub key
+func VerifySchnorrSignature(pubKey string, msg, sig []byte) (bool, error) {
+	pk, err := ParseSchnorrPubKey(pubKey)
+	if err != nil {
+		return false, err
+	}
+	signature, err := schnorr.ParseSignature(sig)
+	if err != nil {
+		return false, fmt.Errorf("fail to parse the signature: %w", err)
+	}
+	return signature.Verify(msg, pk), nil
+}
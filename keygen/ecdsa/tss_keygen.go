@@ -34,6 +34,7 @@ type TssKeyGen struct {
 	stateManager    storage.LocalStateManager
 	commStopChan    chan struct{}
 	p2pComm         *p2p.Communication
+	schnorr         bool
 }
 
 func NewTssKeyGen(localP2PID string,
@@ -61,6 +62,12 @@ func NewTssKeyGen(localP2PID string,
 	}
 }
 
+// SetSchnorr makes the keygen create a schnorr pool, the key is shared the same way as the ecdsa pools, but the pool
+// pub key is x-only
+func (tKeyGen *TssKeyGen) SetSchnorr() {
+	tKeyGen.schnorr = true
+}
+
 func (tKeyGen *TssKeyGen) GetTssKeyGenChannels() chan *p2p.Message {
 	return tKeyGen.tssCommonStruct.TssMsg
 }
@@ -75,14 +82,14 @@ func (tKeyGen *TssKeyGen) GenerateNewKey(keygenReq keygen.Request) (*bcrypto.ECP
 		return nil, fmt.Errorf("fail to get keygen parties: %w", err)
 	}
 
+	threshold, err := conversion.ResolveThreshold(keygenReq.Threshold, len(partiesID))
+	if err != nil {
+		return nil, err
+	}
 	keyGenLocalStateItem := storage.KeygenLocalState{
 		ParticipantKeys: keygenReq.Keys,
 		LocalPartyKey:   tKeyGen.localNodePubKey,
-	}
-
-	threshold, err := conversion.GetThreshold(len(partiesID))
-	if err != nil {
-		return nil, err
+		Threshold:       threshold,
 	}
 	keyGenPartyMap := new(sync.Map)
 	ctx := btss.NewPeerContext(partiesID)
@@ -224,18 +231,33 @@ func (tKeyGen *TssKeyGen) processKeyGen(errChan chan struct{},
 			if err != nil {
 				tKeyGen.logger.Error().Err(err).Msg("fail to broadcast the keysign done")
 			}
-			pubKey, err := conversion.GetTssPubKeyECDSA(msg.ECDSAPub)
-			if err != nil {
-				return nil, fmt.Errorf("fail to get thorchain pubkey: %w", err)
-			}
 			marshaledMsg, err := json.Marshal(msg)
 			if err != nil {
 				tKeyGen.logger.Error().Err(err).Msg("fail to marshal the result")
 				return nil, errors.New("fail to marshal the result")
 			}
 			keyGenLocalStateItem.LocalData = marshaledMsg
-			keyGenLocalStateItem.PubKey = pubKey
-			if err := tKeyGen.stateManager.SaveLocalState(keyGenLocalStateItem, messages.ECDSAKEYGEN); err != nil {
+			algo := messages.ECDSAKEYGEN
+			if tKeyGen.schnorr {
+				algo = messages.SCHNORRKEYGEN
+				keyGenLocalStateItem.Algo = storage.AlgoSchnorr
+				keyGenLocalStateItem.PubKey, err = conversion.GetTssPubKeySchnorr(msg.ECDSAPub)
+				if err != nil {
+					return nil, fmt.Errorf("fail to get the x-only pubkey: %w", err)
+				}
+			} else {
+				pubKey, err := conversion.GetTssPubKeyECDSA(msg.ECDSAPub)
+				if err != nil {
+					return nil, fmt.Errorf("fail to get thorchain pubkey: %w", err)
+				}
+				chainCode, err := conversion.ChainCodeFromKeyShares(msg.BigXj)
+				if err != nil {
+					return nil, fmt.Errorf("fail to get the chain code: %w", err)
+				}
+				keyGenLocalStateItem.PubKey = pubKey
+				keyGenLocalStateItem.ChainCode = chainCode
+			}
+			if err := tKeyGen.stateManager.SaveLocalState(keyGenLocalStateItem, algo); err != nil {
 				return nil, fmt.Errorf("fail to save keygen result to storage: %w", err)
 			}
 			address := tKeyGen.p2pComm.ExportPeerAddress()
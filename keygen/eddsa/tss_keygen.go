@@ -72,14 +72,14 @@ func (tKeyGen *EDDSAKeyGen) GenerateNewKey(keygenReq keygen.Request) (*bcrypto.E
 		return nil, fmt.Errorf("fail to get keygen parties: %w", err)
 	}
 
+	threshold, err := conversion.ResolveThreshold(keygenReq.Threshold, len(partiesID))
+	if err != nil {
+		return nil, err
+	}
 	keyGenLocalStateItem := storage.KeygenLocalState{
 		ParticipantKeys: keygenReq.Keys,
 		LocalPartyKey:   tKeyGen.localNodePubKey,
-	}
-
-	threshold, err := conversion.GetThreshold(len(partiesID))
-	if err != nil {
-		return nil, err
+		Threshold:       threshold,
 	}
 	keyGenPartyMap := new(sync.Map)
 	ctx := btss.NewPeerContext(partiesID)
@@ -1,12 +1,17 @@
 package keygen
 
 import (
+	"errors"
+
 	bcrypto "github.com/HyperCore-Team/tss-lib/crypto"
 
 	"github.com/HyperCore-Team/go-tss/common"
 	"github.com/HyperCore-Team/go-tss/p2p"
 )
 
+// ErrInvalidAlgo is returned for a keygen request of an unknown algo
+var ErrInvalidAlgo = errors.New("invalid keygen algo")
+
 type TssKeyGen interface {
 	GenerateNewKey(keygenReq Request) (*bcrypto.ECPoint, error)
 	GetTssKeyGenChannels() chan *p2p.Message
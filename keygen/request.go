@@ -1,11 +1,20 @@
 package keygen
 
+import (
+	"fmt"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
+)
+
 // Request request to do keygen
 type Request struct {
 	Keys        []string `json:"keys"`
 	BlockHeight int64    `json:"block_height"`
 	Version     string   `json:"tss_version"`
 	Algo        string   `json:"algo"`
+	// Threshold of the new pool, threshold+1 parties are needed to sign, the default threshold is used if it is zero
+	Threshold int `json:"threshold,omitempty"`
 }
 
 // NewRequest creeate a new instance of keygen.Request
@@ -17,3 +26,26 @@ func NewRequest(keys []string, blockHeight int64, version string, algo string) R
 		Algo:        algo,
 	}
 }
+
+// Validate checks the fields of the request before any party is contacted, whether the keys are whitelisted and
+// include the local node is checked by the TssServer
+func (r Request) Validate() error {
+	var errs common.FieldErrors
+	if len(r.Keys) < 2 {
+		errs.Addf("keys", "a pool needs at least 2 keys, got %d", len(r.Keys))
+	}
+	errs.CheckNodePubKeys("keys", r.Keys)
+	errs.CheckBlockHeight("block_height", r.BlockHeight)
+	errs.CheckVersion("tss_version", r.Version)
+	switch r.Algo {
+	case "ecdsa", "eddsa", "schnorr":
+	default:
+		errs.Add("algo", fmt.Errorf("%w: %q", ErrInvalidAlgo, r.Algo))
+	}
+	if len(r.Keys) >= 2 {
+		if _, err := conversion.ResolveThreshold(r.Threshold, len(r.Keys)); err != nil {
+			errs.Add("threshold", err)
+		}
+	}
+	return errs.Err()
+}
@@ -0,0 +1,75 @@
+package keygen
+
+import (
+	"errors"
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/common"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type RequestTestSuite struct{}
+
+var _ = Suite(&RequestTestSuite{})
+
+var testNodePubKeys = []string{
+	"D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=",
+	"8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=",
+	"Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU=",
+	"jzTMn5m27Cmt6EuCAuKnIzxNbVYY4EIywP0a9grmSok=",
+}
+
+func (RequestTestSuite) TestValidate(c *C) {
+	valid := func() Request {
+		return NewRequest(append([]string{}, testNodePubKeys...), 10, "0.14.0", "ecdsa")
+	}
+	testCases := []struct {
+		name   string
+		modify func(r *Request)
+		fields []string
+	}{
+		{name: "valid", modify: func(r *Request) {}},
+		{name: "eddsa", modify: func(r *Request) { r.Algo = "eddsa" }},
+		{name: "custom threshold", modify: func(r *Request) { r.Threshold = 3 }},
+		{name: "no keys", modify: func(r *Request) { r.Keys = nil }, fields: []string{"keys"}},
+		{name: "single key", modify: func(r *Request) { r.Keys = r.Keys[:1] }, fields: []string{"keys"}},
+		{name: "duplicate key", modify: func(r *Request) { r.Keys[2] = r.Keys[0] }, fields: []string{"keys[2]"}},
+		{name: "bech32 key", modify: func(r *Request) {
+			r.Keys[1] = "thorpub1addwnpepqtdklw8tf3anjz7nn5fly3uvq2e67w2apn560s4smmrt9e3x52nt2svmmu3"
+		}, fields: []string{"keys[1]"}},
+		{name: "key of a wrong length", modify: func(r *Request) { r.Keys[3] = "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq" }, fields: []string{"keys[3]"}},
+		{name: "negative block height", modify: func(r *Request) { r.BlockHeight = -1 }, fields: []string{"block_height"}},
+		{name: "no version", modify: func(r *Request) { r.Version = "" }, fields: []string{"tss_version"}},
+		{name: "invalid version", modify: func(r *Request) { r.Version = "v1" }, fields: []string{"tss_version"}},
+		{name: "unknown algo", modify: func(r *Request) { r.Algo = "rsa" }, fields: []string{"algo"}},
+		{name: "threshold of all the keys", modify: func(r *Request) { r.Threshold = 4 }, fields: []string{"threshold"}},
+		{name: "every field is reported", modify: func(r *Request) {
+			r.Keys[1] = "!"
+			r.Version = ""
+			r.Algo = ""
+		}, fields: []string{"keys[1]", "tss_version", "algo"}},
+	}
+	for _, tc := range testCases {
+		req := valid()
+		tc.modify(&req)
+		err := req.Validate()
+		if len(tc.fields) == 0 {
+			c.Check(err, IsNil, Commentf(tc.name))
+			continue
+		}
+		var fieldErrs common.FieldErrors
+		c.Assert(errors.As(err, &fieldErrs), Equals, true, Commentf(tc.name))
+		var fields []string
+		for _, el := range fieldErrs {
+			fields = append(fields, el.Field)
+		}
+		c.Check(fields, DeepEquals, tc.fields, Commentf(tc.name))
+	}
+
+	req := valid()
+	req.Algo = "rsa"
+	c.Assert(errors.Is(req.Validate(), ErrInvalidAlgo), Equals, true)
+}
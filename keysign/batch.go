@@ -0,0 +1,75 @@
+package keysign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/HyperCore-Team/go-tss/common"
+)
+
+// BatchRequest signs the messages of several pools in one request, e.g. all the pools swept at the same block height,
+// every request is a keysign of its own with its own msgID and join party
+type BatchRequest struct {
+	Requests []Request `json:"requests"`
+}
+
+// NewBatchRequest creates the batch of the given keysign requests
+func NewBatchRequest(reqs ...Request) BatchRequest {
+	return BatchRequest{Requests: reqs}
+}
+
+// Validate checks every request of the batch, the fields of a request are named after its index, e.g.
+// requests[1].messages[0], a pool may only be in one request as its messages can be signed together
+func (r BatchRequest) Validate() error {
+	var errs common.FieldErrors
+	if len(r.Requests) == 0 {
+		errs.Addf("requests", "no request to sign")
+	}
+	pools := make(map[string]int, len(r.Requests))
+	for i, req := range r.Requests {
+		prefix := fmt.Sprintf("requests[%d]", i)
+		if err := req.Validate(); err != nil {
+			var fieldErrs common.FieldErrors
+			if !errors.As(err, &fieldErrs) {
+				errs.Add(prefix, err)
+				continue
+			}
+			for _, el := range fieldErrs {
+				errs.Add(prefix+"."+el.Field, el.Err)
+			}
+		}
+		if j, ok := pools[req.PoolPubKey]; ok && req.PoolPubKey != "" {
+			errs.Addf(prefix+".pool_pub_key", "the pool is signed with in requests[%d] already", j)
+			continue
+		}
+		pools[req.PoolPubKey] = i
+	}
+	return errs.Err()
+}
+
+// BatchResult is the keysign of one request of the batch, the error is set if the keysign of the pool failed, the
+// response keeps the blame then
+type BatchResult struct {
+	PoolPubKey string   `json:"pool_pub_key"`
+	Response   Response `json:"response"`
+	Err        error    `json:"-"`
+}
+
+// BatchResponse has the result of every request of the batch in the order of the requests, the status is a success
+// only if every keysign succeeded
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+	Status  common.Status `json:"status"`
+}
+
+// NewBatchResponse creates the response of the given results
+func NewBatchResponse(results []BatchResult) BatchResponse {
+	status := common.Success
+	for _, el := range results {
+		if el.Err != nil || el.Response.Status != common.Success {
+			status = common.Fail
+			break
+		}
+	}
+	return BatchResponse{Results: results, Status: status}
+}
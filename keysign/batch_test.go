@@ -0,0 +1,72 @@
+package keysign
+
+import (
+	"encoding/base64"
+	"errors"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+)
+
+type BatchTestSuite struct{}
+
+var _ = Suite(&BatchTestSuite{})
+
+func (BatchTestSuite) TestValidate(c *C) {
+	msg := base64.StdEncoding.EncodeToString([]byte("hello"))
+	ecdsaReq := NewRequest(testECDSAPoolPubKey, []string{msg}, 10, testSignerPubKeys, "0.14.0", "ecdsa")
+	eddsaReq := NewRequest(testEdDSAPoolPubKey, []string{msg}, 10, testSignerPubKeys, "0.14.0", "eddsa")
+	invalidReq := NewRequest(testECDSAPoolPubKey, []string{"!"}, -1, testSignerPubKeys, "0.14.0", "ecdsa")
+	testCases := []struct {
+		name   string
+		req    BatchRequest
+		fields []string
+	}{
+		{name: "valid", req: NewBatchRequest(ecdsaReq, eddsaReq)},
+		{name: "no requests", req: NewBatchRequest(), fields: []string{"requests"}},
+		{
+			name:   "fields of the requests",
+			req:    NewBatchRequest(eddsaReq, invalidReq),
+			fields: []string{"requests[1].messages[0]", "requests[1].block_height"},
+		},
+		{
+			name:   "pool signed twice",
+			req:    NewBatchRequest(ecdsaReq, eddsaReq, ecdsaReq),
+			fields: []string{"requests[2].pool_pub_key"},
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.req.Validate()
+		if len(tc.fields) == 0 {
+			c.Check(err, IsNil, Commentf(tc.name))
+			continue
+		}
+		var fieldErrs common.FieldErrors
+		c.Assert(errors.As(err, &fieldErrs), Equals, true, Commentf(tc.name))
+		var fields []string
+		for _, el := range fieldErrs {
+			fields = append(fields, el.Field)
+		}
+		c.Check(fields, DeepEquals, tc.fields, Commentf(tc.name))
+	}
+
+	// the causes of the field errors are kept
+	invalidReq.Algo = "rsa"
+	c.Assert(errors.Is(NewBatchRequest(invalidReq).Validate(), ErrInvalidAlgo), Equals, true)
+}
+
+func (BatchTestSuite) TestNewBatchResponse(c *C) {
+	signed := BatchResult{PoolPubKey: testECDSAPoolPubKey, Response: NewResponse(nil, common.Success, blame.Blame{})}
+	failed := BatchResult{
+		PoolPubKey: testEdDSAPoolPubKey,
+		Response:   NewResponse(nil, common.Fail, blame.NewBlame(blame.TssTimeout, nil)),
+		Err:        ErrKeysignFailed,
+	}
+	c.Assert(NewBatchResponse([]BatchResult{signed, signed}).Status, Equals, common.Success)
+	resp := NewBatchResponse([]BatchResult{signed, failed})
+	c.Assert(resp.Status, Equals, common.Fail)
+	c.Assert(resp.Results, HasLen, 2)
+	c.Assert(resp.Results[1].Err, Equals, ErrKeysignFailed)
+}
@@ -0,0 +1,132 @@
+package ecdsa
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	tsslibcommon "github.com/HyperCore-Team/tss-lib/common"
+	"github.com/HyperCore-Team/tss-lib/ecdsa/keygen"
+	"github.com/HyperCore-Team/tss-lib/ecdsa/signing"
+	"github.com/HyperCore-Team/tss-lib/test"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+	"github.com/btcsuite/btcd/btcec"
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+type DerivationTestSuite struct{}
+
+var _ = Suite(&DerivationTestSuite{})
+
+// TestSignWithChildKey runs the parties in process with the shares of the child key, the signature has to verify
+// against the child pub key derived without a ceremony
+func (DerivationTestSuite) TestSignWithChildKey(c *C) {
+	if testing.Short() {
+		c.Skip("skip the test")
+		return
+	}
+	const path = "m/0/7"
+	indexes, err := conversion.ParseDerivationPath(path)
+	c.Assert(err, IsNil)
+
+	var states []storage.KeygenLocalState
+	for i := 0; i < 4; i++ {
+		buf, err := ioutil.ReadFile(fmt.Sprintf("../../test_data/keysign_data/%d.json", i))
+		c.Assert(err, IsNil)
+		var state storage.KeygenLocalState
+		c.Assert(json.Unmarshal(buf, &state), IsNil)
+		states = append(states, state)
+	}
+	chainCode, err := states[0].GetChainCode()
+	c.Assert(err, IsNil)
+	delta, childPubKey, err := conversion.DeriveChildKey(states[0].PubKey, chainCode, indexes)
+	c.Assert(err, IsNil)
+
+	partiesID, _, err := conversion.GetParties(states[0].ParticipantKeys, states[0].LocalPartyKey, true, "")
+	c.Assert(err, IsNil)
+	threshold, err := states[0].GetThreshold()
+	c.Assert(err, IsNil)
+	p2pCtx := btss.NewPeerContext(partiesID)
+	msg := big.NewInt(42)
+	outCh := make(chan btss.Message, 4*len(partiesID))
+	endCh := make(chan tsslibcommon.SignatureData, len(partiesID))
+	errCh := make(chan *btss.Error, len(partiesID))
+	parties := make([]btss.Party, len(partiesID))
+	for _, state := range states {
+		_, localPartyID, err := conversion.GetParties(state.ParticipantKeys, state.LocalPartyKey, true, "")
+		c.Assert(err, IsNil)
+		var localData keygen.LocalPartySaveData
+		c.Assert(json.Unmarshal(state.LocalData, &localData), IsNil)
+		localData, err = deriveChildShares(localData, delta, childPubKey)
+		c.Assert(err, IsNil)
+		index := -1
+		for i, el := range partiesID {
+			if el.Id == localPartyID.Id {
+				index = i
+			}
+		}
+		c.Assert(index, Not(Equals), -1)
+		params := btss.NewParameters(btcec.S256(), p2pCtx, partiesID[index], len(partiesID), threshold)
+		parties[index] = signing.NewLocalPartyWithKDD(msg, params, localData, delta, outCh, endCh)
+	}
+	for _, el := range parties {
+		go func(party btss.Party) {
+			if err := party.Start(); err != nil {
+				errCh <- err
+			}
+		}(el)
+	}
+
+	// the signing parties send the signature by value, only its R || S is taken, so the lock in it isn't copied
+	sigCh := make(chan []byte, len(parties))
+	go func() {
+		for range parties {
+			sigCh <- (<-endCh).Signature
+		}
+	}()
+	var signature []byte
+	ended := 0
+	for ended < len(parties) {
+		select {
+		case err := <-errCh:
+			c.Fatal(err)
+		case msg := <-outCh:
+			if msg.GetTo() == nil {
+				for _, el := range parties {
+					if el.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(el, msg, errCh)
+					}
+				}
+				continue
+			}
+			go test.SharedPartyUpdater(parties[msg.GetTo()[0].Index], msg, errCh)
+		case signature = <-sigCh:
+			ended++
+		}
+	}
+
+	derived, err := conversion.DeriveChildPubKey(states[0].PubKey, chainCode, path)
+	c.Assert(err, IsNil)
+	buf, err := base64.StdEncoding.DecodeString(derived)
+	c.Assert(err, IsNil)
+	pk, err := btcec.ParsePubKey(buf, btcec.S256())
+	c.Assert(err, IsNil)
+	c.Assert(signature, HasLen, 64)
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	c.Assert(ecdsa.Verify(pk.ToECDSA(), msg.Bytes(), r, s), Equals, true)
+
+	// the pool key doesn't verify the signature of the child key
+	buf, err = base64.StdEncoding.DecodeString(states[0].PubKey)
+	c.Assert(err, IsNil)
+	poolPk, err := btcec.ParsePubKey(buf, btcec.S256())
+	c.Assert(err, IsNil)
+	c.Assert(ecdsa.Verify(poolPk.ToECDSA(), msg.Bytes(), r, s), Equals, false)
+}
@@ -96,6 +96,18 @@ func (s *MockLocalStateManager) RetrieveP2PAddresses() ([]maddr.Multiaddr, error
 	return nil, os.ErrNotExist
 }
 
+func (s *MockLocalStateManager) ListLocalStates() ([]storage.KeygenLocalState, error) {
+	return nil, nil
+}
+
+func (s *MockLocalStateManager) DeleteLocalState(pubKey string, algo messages.Algo) error {
+	return nil
+}
+
+func (s *MockLocalStateManager) ArchiveLocalState(pubKey string, algo messages.Algo) error {
+	return nil
+}
+
 type TssKeysignTestSuite struct {
 	comms        []*p2p.Communication
 	partyNum     int
@@ -1,6 +1,7 @@
 package ecdsa
 
 import (
+	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,6 +37,8 @@ type TssKeySign struct {
 	commStopChan    chan struct{}
 	p2pComm         *p2p.Communication
 	stateManager    storage.LocalStateManager
+	derivationPath  []uint32
+	hashMode        common.HashMode
 }
 
 func NewTssKeySign(localP2PID string,
@@ -53,6 +56,16 @@ func NewTssKeySign(localP2PID string,
 	}
 }
 
+// SetDerivationPath makes the keysign sign with the child key at the given path rather than with the pool key
+func (tKeySign *TssKeySign) SetDerivationPath(path []uint32) {
+	tKeySign.derivationPath = path
+}
+
+// SetHashMode tells how the messages become the digests which are signed
+func (tKeySign *TssKeySign) SetHashMode(mode common.HashMode) {
+	tKeySign.hashMode = mode
+}
+
 func (tKeySign *TssKeySign) GetTssKeySignChannels() chan *p2p.Message {
 	return tKeySign.tssCommonStruct.TssMsg
 }
@@ -94,9 +107,22 @@ func (tKeySign *TssKeySign) SignMessage(msgsToSign [][]byte, localStateItem stor
 		tKeySign.logger.Info().Msgf("we are not in this rounds key sign")
 		return nil, nil
 	}
-	threshold, err := conversion.GetThreshold(len(localStateItem.ParticipantKeys))
+	threshold, err := localStateItem.GetThreshold()
 	if err != nil {
-		return nil, errors.New("fail to get threshold")
+		return nil, fmt.Errorf("fail to get threshold: %w", err)
+	}
+
+	var keyDerivationDelta *big.Int
+	var childPubKey *ecdsa.PublicKey
+	if len(tKeySign.derivationPath) > 0 {
+		chainCode, err := localStateItem.GetChainCode()
+		if err != nil {
+			return nil, fmt.Errorf("fail to get the chain code: %w", err)
+		}
+		keyDerivationDelta, childPubKey, err = conversion.DeriveChildKey(localStateItem.PubKey, chainCode, tKeySign.derivationPath)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	outCh := make(chan btss.Message, 2*len(partiesID)*len(msgsToSign))
@@ -105,7 +131,7 @@ func (tKeySign *TssKeySign) SignMessage(msgsToSign [][]byte, localStateItem stor
 
 	keySignPartyMap := new(sync.Map)
 	for i, val := range msgsToSign {
-		m, err := common.MsgToHashInt(val, messages.ECDSAKEYSIGN)
+		m, err := common.MsgToHashIntWithMode(val, messages.ECDSAKEYSIGN, tKeySign.hashMode)
 		if err != nil {
 			return nil, fmt.Errorf("fail to convert msg to hash int: %w", err)
 		}
@@ -128,7 +154,13 @@ func (tKeySign *TssKeySign) SignMessage(msgsToSign [][]byte, localStateItem stor
 		if !ret {
 			return nil, errors.New("fail to valid the keygen saved data")
 		}
-		keySignParty := signing.NewLocalParty(m, params, localData, outCh, endCh)
+		if keyDerivationDelta != nil {
+			localData, err = deriveChildShares(localData, keyDerivationDelta, childPubKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+		keySignParty := signing.NewLocalPartyWithKDD(m, params, localData, keyDerivationDelta, outCh, endCh)
 		keySignPartyMap.Store(moniker, keySignParty)
 	}
 
@@ -190,6 +222,15 @@ func (tKeySign *TssKeySign) SignMessage(msgsToSign [][]byte, localStateItem stor
 	return results, nil
 }
 
+// deriveChildShares returns the key shares of the child key, they are the shares of the pool key shifted by the delta
+func deriveChildShares(localData keygen.LocalPartySaveData, keyDerivationDelta *big.Int, childPubKey *ecdsa.PublicKey) (keygen.LocalPartySaveData, error) {
+	keys := []keygen.LocalPartySaveData{localData}
+	if err := signing.UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta, keys, childPubKey, btcec.S256()); err != nil {
+		return keygen.LocalPartySaveData{}, fmt.Errorf("fail to derive the child key shares: %w", err)
+	}
+	return keys[0], nil
+}
+
 func (tKeySign *TssKeySign) processKeySign(reqNum int, errChan chan struct{}, outCh <-chan btss.Message, endCh <-chan tsslibcommon.SignatureData) ([]*tsslibcommon.SignatureData, error) {
 	defer tKeySign.logger.Debug().Msg("key sign finished")
 	tKeySign.logger.Debug().Msg("start to read messages from local party")
@@ -36,6 +36,7 @@ type EDDSATssKeySign struct {
 	commStopChan    chan struct{}
 	p2pComm         *p2p.Communication
 	stateManager    storage.LocalStateManager
+	hashMode        common.HashMode
 }
 
 func NewTssKeySign(localP2PID string,
@@ -54,6 +55,11 @@ func NewTssKeySign(localP2PID string,
 	}
 }
 
+// SetHashMode tells how the messages become the digests which are signed
+func (tKeySign *EDDSATssKeySign) SetHashMode(mode common.HashMode) {
+	tKeySign.hashMode = mode
+}
+
 func (tKeySign *EDDSATssKeySign) GetTssKeySignChannels() chan *p2p.Message {
 	return tKeySign.tssCommonStruct.TssMsg
 }
@@ -94,9 +100,9 @@ func (tKeySign *EDDSATssKeySign) SignMessage(msgsToSign [][]byte, localStateItem
 		tKeySign.logger.Info().Msgf("we are not in this rounds key sign")
 		return nil, nil
 	}
-	threshold, err := conversion.GetThreshold(len(localStateItem.ParticipantKeys))
+	threshold, err := localStateItem.GetThreshold()
 	if err != nil {
-		return nil, errors.New("fail to get threshold")
+		return nil, fmt.Errorf("fail to get threshold: %w", err)
 	}
 
 	outCh := make(chan btss.Message, 2*len(partiesID)*len(msgsToSign))
@@ -105,7 +111,7 @@ func (tKeySign *EDDSATssKeySign) SignMessage(msgsToSign [][]byte, localStateItem
 
 	keySignPartyMap := new(sync.Map)
 	for i, val := range msgsToSign {
-		m, err := common.MsgToHashInt(val, messages.EDDSAKEYSIGN)
+		m, err := common.MsgToHashIntWithMode(val, messages.EDDSAKEYSIGN, tKeySign.hashMode)
 		if err != nil {
 			return nil, fmt.Errorf("fail to convert msg to hash int: %w", err)
 		}
@@ -0,0 +1,129 @@
+package keysign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/HyperCore-Team/tss-lib/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+// Encoding tells how the signatures are encoded in the Encoded field of the response
+type Encoding string
+
+const (
+	// EncodingNone leaves the Encoded field empty
+	EncodingNone Encoding = ""
+	// EncodingDER is the ASN.1 DER encoding of the ecdsa signature, as bitcoin scripts take it
+	EncodingDER Encoding = "der"
+	// EncodingCompact is the 64 bytes R || S, the ed25519 and BIP-340 signatures are 64 bytes already
+	EncodingCompact Encoding = "compact"
+	// EncodingCompactRecoverable is the 65 bytes R || S || V of the ecdsa signature, V is the recovery id 0 or 1
+	EncodingCompactRecoverable Encoding = "compact65"
+	// EncodingEthereum is the R || S || V of ethereum, V is 27 or 28, or follows EIP-155 if a chain id is given, it is
+	// big endian and may take more than a byte from the chain id 110 on
+	EncodingEthereum Encoding = "rsv"
+	// EncodingCosmos is the 64 bytes signature of the cosmos sdk, R || S for secp256k1 and the ed25519 signature for
+	// ed25519
+	EncodingCosmos Encoding = "cosmos"
+)
+
+// ErrInvalidEncoding is returned for an encoding which is unknown or can't be used by the algo of the pool
+var ErrInvalidEncoding = errors.New("invalid signature encoding")
+
+// Validate checks the encoding can be used by the signatures of the given keysign algo
+func (e Encoding) Validate(algo messages.Algo) error {
+	switch e {
+	case EncodingNone, EncodingCompact:
+		return nil
+	case EncodingDER, EncodingCompactRecoverable, EncodingEthereum:
+		if algo == messages.ECDSAKEYSIGN {
+			return nil
+		}
+	case EncodingCosmos:
+		// cosmos has no BIP-340 keys
+		if algo == messages.ECDSAKEYSIGN || algo == messages.EDDSAKEYSIGN {
+			return nil
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidEncoding, e)
+	}
+	return fmt.Errorf("%w: %s can't be used by the algo of the pool", ErrInvalidEncoding, e)
+}
+
+// EncodeSignature encodes the signature in the given encoding, the ecdsa signatures are always encoded with the low S,
+// the chain id is only used by EncodingEthereum
+func EncodeSignature(sig *common.SignatureData, algo messages.Algo, encoding Encoding, chainID uint64) ([]byte, error) {
+	if err := encoding.Validate(algo); err != nil {
+		return nil, err
+	}
+	if encoding == EncodingNone {
+		return nil, nil
+	}
+	if algo != messages.ECDSAKEYSIGN {
+		if len(sig.Signature) != 64 {
+			return nil, fmt.Errorf("the signature must be 64 bytes, got %d", len(sig.Signature))
+		}
+		return append([]byte{}, sig.Signature...), nil
+	}
+
+	r, s, flipped, err := lowS(sig)
+	if err != nil {
+		return nil, err
+	}
+	if encoding == EncodingDER {
+		var rScalar, sScalar btcec.ModNScalar
+		rScalar.SetByteSlice(r.Bytes())
+		sScalar.SetByteSlice(s.Bytes())
+		return btcecdsa.NewSignature(&rScalar, &sScalar).Serialize(), nil
+	}
+	out := make([]byte, 64, 65)
+	r.FillBytes(out[:32])
+	s.FillBytes(out[32:])
+	if encoding == EncodingCompact || encoding == EncodingCosmos {
+		return out, nil
+	}
+	// the recovery ids 2 and 3 are for the R which overflowed the order, they can't be encoded in V
+	if len(sig.SignatureRecovery) != 1 || sig.SignatureRecovery[0] > 1 {
+		return nil, errors.New("invalid recovery id of the signature")
+	}
+	recoveryID := sig.SignatureRecovery[0]
+	if flipped {
+		recoveryID ^= 1
+	}
+	switch encoding {
+	case EncodingCompactRecoverable:
+		out = append(out, recoveryID)
+	case EncodingEthereum:
+		if chainID == 0 {
+			return append(out, 27+recoveryID), nil
+		}
+		// V of EIP-155 is chain id * 2 + 35 + recovery id, it takes more than a byte for the big chain ids
+		v := new(big.Int).SetUint64(chainID)
+		v.Mul(v, big.NewInt(2)).Add(v, big.NewInt(35+int64(recoveryID)))
+		out = append(out, v.Bytes()...)
+	}
+	return out, nil
+}
+
+// lowS returns R and S of the ecdsa signature with S in the lower half of the order of the curve, it tells whether S
+// was negated, the recovery id is flipped along with S
+func lowS(sig *common.SignatureData) (*big.Int, *big.Int, bool, error) {
+	if len(sig.R) == 0 || len(sig.R) > 32 || len(sig.S) == 0 || len(sig.S) > 32 {
+		return nil, nil, false, errors.New("invalid R or S of the signature")
+	}
+	n := btcec.S256().N
+	r := new(big.Int).SetBytes(sig.R)
+	s := new(big.Int).SetBytes(sig.S)
+	if r.Sign() == 0 || r.Cmp(n) >= 0 || s.Sign() == 0 || s.Cmp(n) >= 0 {
+		return nil, nil, false, errors.New("R or S of the signature is out of range")
+	}
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		return r, s.Sub(n, s), true, nil
+	}
+	return r, s, false, nil
+}
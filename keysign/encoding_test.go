@@ -0,0 +1,138 @@
+package keysign
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"github.com/HyperCore-Team/tss-lib/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+type EncodingTestSuite struct{}
+
+var _ = Suite(&EncodingTestSuite{})
+
+// the signature of the example transaction of EIP-155, signed by the key 0x4646...46 on the chain 1
+const (
+	eip155Hash = "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"
+	eip155R    = "28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276"
+	eip155S    = "67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+)
+
+func eip155Signature(c *C, highS bool) *common.SignatureData {
+	r, err := hex.DecodeString(eip155R)
+	c.Assert(err, IsNil)
+	s, err := hex.DecodeString(eip155S)
+	c.Assert(err, IsNil)
+	recoveryID := byte(0)
+	if highS {
+		s = new(big.Int).Sub(btcec.S256().N, new(big.Int).SetBytes(s)).Bytes()
+		recoveryID = 1
+	}
+	return &common.SignatureData{R: r, S: s, SignatureRecovery: []byte{recoveryID}}
+}
+
+func (EncodingTestSuite) TestEncodeECDSASignature(c *C) {
+	testCases := []struct {
+		encoding Encoding
+		chainID  uint64
+		expected string
+	}{
+		{EncodingDER, 0, "3044" + "0220" + eip155R + "0220" + eip155S},
+		{EncodingCompact, 0, eip155R + eip155S},
+		{EncodingCosmos, 0, eip155R + eip155S},
+		{EncodingCompactRecoverable, 0, eip155R + eip155S + "00"},
+		{EncodingEthereum, 0, eip155R + eip155S + "1b"},
+		// v = 37 in EIP-155
+		{EncodingEthereum, 1, eip155R + eip155S + "25"},
+		// v = 137 * 2 + 35 takes two bytes
+		{EncodingEthereum, 137, eip155R + eip155S + "0135"},
+	}
+	for _, highS := range []bool{false, true} {
+		sig := eip155Signature(c, highS)
+		for _, tc := range testCases {
+			encoded, err := EncodeSignature(sig, messages.ECDSAKEYSIGN, tc.encoding, tc.chainID)
+			c.Assert(err, IsNil)
+			c.Check(hex.EncodeToString(encoded), Equals, tc.expected, Commentf("%s with high S %v", tc.encoding, highS))
+		}
+		encoded, err := EncodeSignature(sig, messages.ECDSAKEYSIGN, EncodingNone, 0)
+		c.Assert(err, IsNil)
+		c.Assert(encoded, IsNil)
+	}
+
+	// the key of EIP-155 is recovered from the signature
+	priKey, _ := btcec.PrivKeyFromBytes(bytes.Repeat([]byte{0x46}, 32))
+	hash, err := hex.DecodeString(eip155Hash)
+	c.Assert(err, IsNil)
+	encoded, err := EncodeSignature(eip155Signature(c, true), messages.ECDSAKEYSIGN, EncodingCompactRecoverable, 0)
+	c.Assert(err, IsNil)
+	compact := append([]byte{27 + encoded[64]}, encoded[:64]...)
+	pubKey, _, err := btcecdsa.RecoverCompact(compact, hash)
+	c.Assert(err, IsNil)
+	c.Assert(pubKey.IsEqual(priKey.PubKey()), Equals, true)
+
+	// the recovery ids 2 and 3 can't be encoded
+	sig := eip155Signature(c, false)
+	sig.SignatureRecovery = []byte{2}
+	_, err = EncodeSignature(sig, messages.ECDSAKEYSIGN, EncodingEthereum, 1)
+	c.Assert(err, NotNil)
+	encoded, err = EncodeSignature(sig, messages.ECDSAKEYSIGN, EncodingDER, 0)
+	c.Assert(err, IsNil)
+	c.Assert(encoded, HasLen, 70)
+
+	sig.S = make([]byte, 32)
+	_, err = EncodeSignature(sig, messages.ECDSAKEYSIGN, EncodingCompact, 0)
+	c.Assert(err, NotNil)
+}
+
+func (EncodingTestSuite) TestEncodeEdDSASignature(c *C) {
+	// the signature of the empty message of the first test vector of RFC 8032
+	expected := "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"
+	signature, err := hex.DecodeString(expected)
+	c.Assert(err, IsNil)
+	sig := &common.SignatureData{Signature: signature}
+	for _, el := range []Encoding{EncodingCompact, EncodingCosmos} {
+		encoded, err := EncodeSignature(sig, messages.EDDSAKEYSIGN, el, 0)
+		c.Assert(err, IsNil)
+		c.Assert(hex.EncodeToString(encoded), Equals, expected)
+	}
+	encoded, err := EncodeSignature(sig, messages.SCHNORRKEYSIGN, EncodingCompact, 0)
+	c.Assert(err, IsNil)
+	c.Assert(encoded, DeepEquals, signature)
+
+	sig.Signature = signature[:63]
+	_, err = EncodeSignature(sig, messages.EDDSAKEYSIGN, EncodingCompact, 0)
+	c.Assert(err, NotNil)
+}
+
+func (EncodingTestSuite) TestValidate(c *C) {
+	testCases := []struct {
+		encoding Encoding
+		algo     messages.Algo
+		valid    bool
+	}{
+		{EncodingNone, messages.EDDSAKEYSIGN, true},
+		{EncodingDER, messages.ECDSAKEYSIGN, true},
+		{EncodingDER, messages.SCHNORRKEYSIGN, false},
+		{EncodingEthereum, messages.EDDSAKEYSIGN, false},
+		{EncodingCompactRecoverable, messages.ECDSAKEYSIGN, true},
+		{EncodingCompact, messages.SCHNORRKEYSIGN, true},
+		{EncodingCosmos, messages.EDDSAKEYSIGN, true},
+		{EncodingCosmos, messages.SCHNORRKEYSIGN, false},
+		{Encoding("base58"), messages.ECDSAKEYSIGN, false},
+	}
+	for _, tc := range testCases {
+		err := tc.encoding.Validate(tc.algo)
+		if tc.valid {
+			c.Check(err, IsNil, Commentf("%s", tc.encoding))
+			continue
+		}
+		c.Check(errors.Is(err, ErrInvalidEncoding), Equals, true, Commentf("%s", tc.encoding))
+	}
+}
@@ -1,6 +1,8 @@
 package keysign
 
 import (
+	"errors"
+
 	bc "github.com/HyperCore-Team/tss-lib/common"
 
 	"github.com/HyperCore-Team/go-tss/common"
@@ -8,6 +10,15 @@ import (
 	"github.com/HyperCore-Team/go-tss/storage"
 )
 
+var (
+	// ErrInvalidAlgo is returned for a keysign request of an unknown algo
+	ErrInvalidAlgo = errors.New("invalid keysign algo")
+	// ErrKeysignFailed is returned while we wait for the signature and the signers report the keysign failed
+	ErrKeysignFailed = errors.New("keysign failed")
+	// ErrSignatureTimeout is returned when no signature is received before the keysign times out
+	ErrSignatureTimeout = errors.New("didn't receive the signature in time")
+)
+
 type TssKeySign interface {
 	GetTssKeySignChannels() chan *p2p.Message
 	GetTssCommonStruct() *common.TssCommon
@@ -0,0 +1,9 @@
+package keysign
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
@@ -5,6 +5,8 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	tsscommon "github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
 	"github.com/HyperCore-Team/go-tss/messages"
 	"github.com/HyperCore-Team/tss-lib/common"
 	"github.com/tendermint/btcd/btcec"
@@ -19,10 +21,11 @@ type Notifier struct {
 	poolPubKey string
 	resp       chan []*common.SignatureData
 	algo       messages.Algo
+	hashMode   tsscommon.HashMode
 }
 
 // NewNotifier create a new instance of Notifier
-func NewNotifier(messageID string, messages [][]byte, poolPubKey string, algo messages.Algo) (*Notifier, error) {
+func NewNotifier(messageID string, messages [][]byte, poolPubKey string, algo messages.Algo, hashMode tsscommon.HashMode) (*Notifier, error) {
 	if len(messageID) == 0 {
 		return nil, errors.New("messageID is empty")
 	}
@@ -38,21 +41,33 @@ func NewNotifier(messageID string, messages [][]byte, poolPubKey string, algo me
 		poolPubKey: poolPubKey,
 		resp:       make(chan []*common.SignatureData, 1),
 		algo:       algo,
+		hashMode:   hashMode,
 	}, nil
 }
 
 // verifySignature is a method to verify the signature against the message it signed , if the signature can be verified successfully
 // There is a method call VerifyBytes in crypto.PubKey, but we can't use that method to verify the signature, because it always hash the message
 // first and then verify the hash of the message against the signature , which is not the case in tss
-// go-tss respect the payload it receives , assume the payload had been hashed already by whoever send it in.
+// go-tss respect the payload it receives , assume the payload had been hashed already by whoever send it in, unless the
+// hash mode of the keysign tells otherwise.
 func (n *Notifier) verifySignature(data *common.SignatureData, msg []byte, algo messages.Algo) (bool, error) {
 	// we should be able to use any of the pubkeys to verify the signature
 	poolPubKey, err := base64.StdEncoding.DecodeString(n.poolPubKey)
 	if err != nil {
 		return false, err
 	}
+	if err := n.hashMode.Validate(algo); err != nil {
+		return false, err
+	}
+	msg, err = tsscommon.HashMessage(msg, n.hashMode)
+	if err != nil {
+		return false, err
+	}
 	if algo == messages.EDDSAKEYSIGN {
 		return ed25519.Verify(poolPubKey, msg, data.Signature), nil
+	} else if algo == messages.SCHNORRKEYSIGN {
+		// the pool pub key is x-only, the BIP-340 signature is R.x || s
+		return conversion.VerifySchnorrSignature(n.poolPubKey, msg, data.Signature)
 	} else {
 		pub, err := btcec.ParsePubKey(poolPubKey, btcec.S256())
 		if err != nil {
@@ -1,9 +1,18 @@
 package keysign
 
 import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
 	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/tss-lib/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	. "gopkg.in/check.v1"
 
+	tsscommon "github.com/HyperCore-Team/go-tss/common"
 	"github.com/HyperCore-Team/go-tss/conversion"
 )
 
@@ -17,20 +26,92 @@ func (*NotifierTestSuite) SetUpSuite(c *C) {
 func (NotifierTestSuite) TestNewNotifier(c *C) {
 	testMSg := [][]byte{[]byte("hello"), []byte("world")}
 	poolPubKey := conversion.GetRandomPubKey()
-	n, err := NewNotifier("", testMSg, poolPubKey, messages.ECDSAKEYSIGN)
+	n, err := NewNotifier("", testMSg, poolPubKey, messages.ECDSAKEYSIGN, tsscommon.HashModeNone)
 	c.Assert(err, NotNil)
 	c.Assert(n, IsNil)
-	n, err = NewNotifier("aasfdasdf", nil, poolPubKey, messages.ECDSAKEYSIGN)
+	n, err = NewNotifier("aasfdasdf", nil, poolPubKey, messages.ECDSAKEYSIGN, tsscommon.HashModeNone)
 	c.Assert(err, NotNil)
 	c.Assert(n, IsNil)
 
-	n, err = NewNotifier("hello", testMSg, "", messages.ECDSAKEYSIGN)
+	n, err = NewNotifier("hello", testMSg, "", messages.ECDSAKEYSIGN, tsscommon.HashModeNone)
 	c.Assert(err, NotNil)
 	c.Assert(n, IsNil)
 
-	n, err = NewNotifier("hello", testMSg, poolPubKey, messages.ECDSAKEYSIGN)
+	n, err = NewNotifier("hello", testMSg, poolPubKey, messages.ECDSAKEYSIGN, tsscommon.HashModeNone)
 	c.Assert(err, IsNil)
 	c.Assert(n, NotNil)
 	ch := n.GetResponseChannel()
 	c.Assert(ch, NotNil)
 }
+
+func (NotifierTestSuite) TestProcessSchnorrSignature(c *C) {
+	priKey, err := btcec.NewPrivateKey()
+	c.Assert(err, IsNil)
+	poolPubKey := base64.StdEncoding.EncodeToString(schnorr.SerializePubKey(priKey.PubKey()))
+	msg := sha256.Sum256([]byte("hello"))
+	sig, err := schnorr.Sign(priKey, msg[:])
+	c.Assert(err, IsNil)
+
+	n, err := NewNotifier("hello", [][]byte{msg[:]}, poolPubKey, messages.SCHNORRKEYSIGN, tsscommon.HashModeNone)
+	c.Assert(err, IsNil)
+	finished, err := n.ProcessSignature([]*common.SignatureData{{Signature: sig.Serialize()}}, messages.SCHNORRKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(finished, Equals, true)
+	c.Assert(<-n.GetResponseChannel(), HasLen, 1)
+
+	other := sha256.Sum256([]byte("world"))
+	n, err = NewNotifier("world", [][]byte{other[:]}, poolPubKey, messages.SCHNORRKEYSIGN, tsscommon.HashModeNone)
+	c.Assert(err, IsNil)
+	finished, err = n.ProcessSignature([]*common.SignatureData{{Signature: sig.Serialize()}}, messages.SCHNORRKEYSIGN)
+	c.Assert(err, NotNil)
+	c.Assert(finished, Equals, false)
+}
+
+func (NotifierTestSuite) TestProcessSignatureWithHashMode(c *C) {
+	priKey, err := btcec.NewPrivateKey()
+	c.Assert(err, IsNil)
+
+	// an ethereum payload is signed by its Keccak-256
+	ecdsaPubKey := base64.StdEncoding.EncodeToString(priKey.PubKey().SerializeCompressed())
+	payload := []byte("an ethereum transaction")
+	digest, err := tsscommon.HashMessage(payload, tsscommon.HashModeKeccak256)
+	c.Assert(err, IsNil)
+	r, s, err := ecdsa.Sign(rand.Reader, priKey.ToECDSA(), digest)
+	c.Assert(err, IsNil)
+	sig := &common.SignatureData{R: r.Bytes(), S: s.Bytes(), Signature: append(r.Bytes(), s.Bytes()...)}
+	n, err := NewNotifier("ethereum", [][]byte{payload}, ecdsaPubKey, messages.ECDSAKEYSIGN, tsscommon.HashModeKeccak256)
+	c.Assert(err, IsNil)
+	finished, err := n.ProcessSignature([]*common.SignatureData{sig}, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(finished, Equals, true)
+	// the payload itself isn't what was signed
+	n, err = NewNotifier("ethereum", [][]byte{payload}, ecdsaPubKey, messages.ECDSAKEYSIGN, tsscommon.HashModeNone)
+	c.Assert(err, IsNil)
+	finished, err = n.ProcessSignature([]*common.SignatureData{sig}, messages.ECDSAKEYSIGN)
+	c.Assert(err, NotNil)
+	c.Assert(finished, Equals, false)
+
+	// a bitcoin payload is signed by its double SHA-256
+	schnorrPubKey := base64.StdEncoding.EncodeToString(schnorr.SerializePubKey(priKey.PubKey()))
+	payload = []byte("a bitcoin transaction")
+	digest, err = tsscommon.HashMessage(payload, tsscommon.HashModeDoubleSHA256)
+	c.Assert(err, IsNil)
+	schnorrSig, err := schnorr.Sign(priKey, digest)
+	c.Assert(err, IsNil)
+	n, err = NewNotifier("bitcoin", [][]byte{payload}, schnorrPubKey, messages.SCHNORRKEYSIGN, tsscommon.HashModeDoubleSHA256)
+	c.Assert(err, IsNil)
+	finished, err = n.ProcessSignature([]*common.SignatureData{{Signature: schnorrSig.Serialize()}}, messages.SCHNORRKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(finished, Equals, true)
+	n, err = NewNotifier("bitcoin", [][]byte{payload}, schnorrPubKey, messages.SCHNORRKEYSIGN, tsscommon.HashModeSHA256)
+	c.Assert(err, IsNil)
+	finished, err = n.ProcessSignature([]*common.SignatureData{{Signature: schnorrSig.Serialize()}}, messages.SCHNORRKEYSIGN)
+	c.Assert(err, NotNil)
+	c.Assert(finished, Equals, false)
+
+	// the hash modes of ecdsa can't be used by eddsa
+	n, err = NewNotifier("eddsa", [][]byte{payload}, schnorrPubKey, messages.EDDSAKEYSIGN, tsscommon.HashModeKeccak256)
+	c.Assert(err, IsNil)
+	_, err = n.ProcessSignature([]*common.SignatureData{{Signature: schnorrSig.Serialize()}}, messages.EDDSAKEYSIGN)
+	c.Assert(err, NotNil)
+}
@@ -1,5 +1,14 @@
 package keysign
 
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
 // Request request to sign a message
 type Request struct {
 	PoolPubKey    string   `json:"pool_pub_key"` // pub key of the pool that we would like to send this message from
@@ -8,6 +17,16 @@ type Request struct {
 	BlockHeight   int64    `json:"block_height"`
 	Version       string   `json:"tss_version"`
 	Algo          string   `json:"algo"`
+	// DerivationPath is the BIP-32 path of the child key of the ECDSA pool to sign with, e.g. m/0/1, only non-hardened
+	// indexes are supported, the pool key signs if it is empty
+	DerivationPath string `json:"derivation_path,omitempty"`
+	// HashMode tells how the messages become the digests which are signed, the messages are signed as they are if it
+	// is empty
+	HashMode common.HashMode `json:"hash_mode,omitempty"`
+	// Encoding tells how the signatures are encoded in the Encoded field of the response, e.g. der or rsv
+	Encoding Encoding `json:"encoding,omitempty"`
+	// ChainID is the EIP-155 chain id of the rsv encoding, V is 27 or 28 if it is zero
+	ChainID uint64 `json:"chain_id,omitempty"`
 }
 
 func NewRequest(pk string, msgs []string, blockHeight int64, signers []string, version string, algo string) Request {
@@ -20,3 +39,68 @@ func NewRequest(pk string, msgs []string, blockHeight int64, signers []string, v
 		Algo:          algo,
 	}
 }
+
+// Validate checks the fields of the request before any party is contacted, the signers don't have to include the
+// local node, the nodes which aren't signers wait for the signature
+func (r Request) Validate() error {
+	var errs common.FieldErrors
+	errs.CheckPoolPubKey("pool_pub_key", r.PoolPubKey, r.Algo)
+	algo, algoErr := keysignAlgo(r.Algo)
+	if algoErr != nil {
+		errs.Add("algo", algoErr)
+	}
+	// the messages are only hashed in a hash mode the algo can use
+	hashable := algoErr == nil && r.HashMode.Validate(algo) == nil
+	if len(r.Messages) == 0 {
+		errs.Addf("messages", "no message to sign")
+	}
+	for i, el := range r.Messages {
+		field := fmt.Sprintf("messages[%d]", i)
+		msg, err := base64.StdEncoding.DecodeString(el)
+		if err != nil {
+			errs.Addf(field, "the message isn't base64: %w", err)
+			continue
+		}
+		if len(msg) == 0 {
+			errs.Addf(field, "empty message")
+			continue
+		}
+		if hashable {
+			if _, err := common.MsgToHashIntWithMode(msg, algo, r.HashMode); err != nil {
+				errs.Add(field, err)
+			}
+		}
+	}
+	errs.CheckNodePubKeys("signer_pub_keys", r.SignerPubKeys)
+	errs.CheckBlockHeight("block_height", r.BlockHeight)
+	errs.CheckVersion("tss_version", r.Version)
+	if r.DerivationPath != "" {
+		if r.Algo != "ecdsa" {
+			errs.Addf("derivation_path", "only the ecdsa pools have child keys")
+		} else if _, err := conversion.ParseDerivationPath(r.DerivationPath); err != nil {
+			errs.Add("derivation_path", err)
+		}
+	}
+	if algoErr == nil {
+		if err := r.HashMode.Validate(algo); err != nil {
+			errs.Add("hash_mode", err)
+		}
+		if err := r.Encoding.Validate(algo); err != nil {
+			errs.Add("encoding", err)
+		}
+	}
+	return errs.Err()
+}
+
+// keysignAlgo returns the keysign algo of the algo of the request
+func keysignAlgo(algo string) (messages.Algo, error) {
+	switch algo {
+	case "ecdsa":
+		return messages.ECDSAKEYSIGN, nil
+	case "eddsa":
+		return messages.EDDSAKEYSIGN, nil
+	case "schnorr":
+		return messages.SCHNORRKEYSIGN, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidAlgo, algo)
+}
@@ -0,0 +1,115 @@
+package keysign
+
+import (
+	"encoding/base64"
+	"errors"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/common"
+)
+
+type RequestTestSuite struct{}
+
+var _ = Suite(&RequestTestSuite{})
+
+const (
+	testECDSAPoolPubKey = "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq"
+	testEdDSAPoolPubKey = "D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY="
+)
+
+var testSignerPubKeys = []string{
+	"8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=",
+	"Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU=",
+	"jzTMn5m27Cmt6EuCAuKnIzxNbVYY4EIywP0a9grmSok=",
+}
+
+func (RequestTestSuite) TestValidate(c *C) {
+	msg := base64.StdEncoding.EncodeToString([]byte("hello"))
+	digest := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	valid := func() Request {
+		return NewRequest(testECDSAPoolPubKey, []string{msg}, 10, append([]string{}, testSignerPubKeys...), "0.14.0", "ecdsa")
+	}
+	testCases := []struct {
+		name   string
+		modify func(r *Request)
+		fields []string
+	}{
+		{name: "valid", modify: func(r *Request) {}},
+		{name: "no signers", modify: func(r *Request) { r.SignerPubKeys = nil }},
+		{name: "eddsa", modify: func(r *Request) {
+			r.PoolPubKey = testEdDSAPoolPubKey
+			r.Algo = "eddsa"
+			r.HashMode = common.HashModeRaw
+			r.Encoding = EncodingCosmos
+		}},
+		{name: "child key", modify: func(r *Request) { r.DerivationPath = "m/0/1" }},
+		{name: "ethereum", modify: func(r *Request) {
+			r.HashMode = common.HashModeKeccak256
+			r.Encoding = EncodingEthereum
+			r.ChainID = 1
+		}},
+		{name: "prehashed digest", modify: func(r *Request) {
+			r.Messages = []string{digest}
+			r.HashMode = common.HashModePrehashed
+		}},
+		{name: "no pool pub key", modify: func(r *Request) { r.PoolPubKey = "" }, fields: []string{"pool_pub_key"}},
+		{name: "bech32 pool pub key", modify: func(r *Request) {
+			r.PoolPubKey = "thorpub1addwnpepqtdklw8tf3anjz7nn5fly3uvq2e67w2apn560s4smmrt9e3x52nt2svmmu3"
+		}, fields: []string{"pool_pub_key"}},
+		{name: "eddsa pub key of an ecdsa pool", modify: func(r *Request) { r.PoolPubKey = testEdDSAPoolPubKey }, fields: []string{"pool_pub_key"}},
+		{name: "no messages", modify: func(r *Request) { r.Messages = nil }, fields: []string{"messages"}},
+		{name: "message which isn't base64", modify: func(r *Request) { r.Messages = []string{msg, "helloworld!"} }, fields: []string{"messages[1]"}},
+		{name: "empty message", modify: func(r *Request) { r.Messages = []string{""} }, fields: []string{"messages[0]"}},
+		{name: "prehashed message of a wrong length", modify: func(r *Request) { r.HashMode = common.HashModePrehashed }, fields: []string{"messages[0]"}},
+		{name: "duplicate signer", modify: func(r *Request) { r.SignerPubKeys[1] = r.SignerPubKeys[0] }, fields: []string{"signer_pub_keys[1]"}},
+		{name: "invalid signer", modify: func(r *Request) { r.SignerPubKeys[2] = testECDSAPoolPubKey }, fields: []string{"signer_pub_keys[2]"}},
+		{name: "negative block height", modify: func(r *Request) { r.BlockHeight = -10 }, fields: []string{"block_height"}},
+		{name: "no version", modify: func(r *Request) { r.Version = "" }, fields: []string{"tss_version"}},
+		{name: "unknown algo", modify: func(r *Request) { r.Algo = "rsa" }, fields: []string{"algo"}},
+		{name: "child key of eddsa", modify: func(r *Request) {
+			r.PoolPubKey = testEdDSAPoolPubKey
+			r.Algo = "eddsa"
+			r.DerivationPath = "m/0"
+		}, fields: []string{"derivation_path"}},
+		{name: "hardened child key", modify: func(r *Request) { r.DerivationPath = "m/0'" }, fields: []string{"derivation_path"}},
+		{name: "hash mode of another algo", modify: func(r *Request) { r.HashMode = common.HashModeRaw }, fields: []string{"hash_mode"}},
+		{name: "encoding of another algo", modify: func(r *Request) {
+			r.PoolPubKey = testEdDSAPoolPubKey
+			r.Algo = "eddsa"
+			r.Encoding = EncodingDER
+		}, fields: []string{"encoding"}},
+		{name: "every field is reported", modify: func(r *Request) {
+			r.PoolPubKey = ""
+			r.Messages = []string{"!"}
+			r.Version = "latest"
+		}, fields: []string{"pool_pub_key", "messages[0]", "tss_version"}},
+	}
+	for _, tc := range testCases {
+		req := valid()
+		tc.modify(&req)
+		err := req.Validate()
+		if len(tc.fields) == 0 {
+			c.Check(err, IsNil, Commentf(tc.name))
+			continue
+		}
+		var fieldErrs common.FieldErrors
+		c.Assert(errors.As(err, &fieldErrs), Equals, true, Commentf(tc.name))
+		var fields []string
+		for _, el := range fieldErrs {
+			fields = append(fields, el.Field)
+		}
+		c.Check(fields, DeepEquals, tc.fields, Commentf(tc.name))
+	}
+
+	// the causes of the field errors are kept
+	req := valid()
+	req.Algo = "rsa"
+	c.Assert(errors.Is(req.Validate(), ErrInvalidAlgo), Equals, true)
+	req = valid()
+	req.Encoding = "base58"
+	c.Assert(errors.Is(req.Validate(), ErrInvalidEncoding), Equals, true)
+	req = valid()
+	req.HashMode = "md5"
+	c.Assert(errors.Is(req.Validate(), common.ErrInvalidHashMode), Equals, true)
+}
@@ -12,6 +12,8 @@ type Signature struct {
 	S          string `json:"s"`
 	RecoveryID string `json:"recovery_id"`
 	Signature  string `json:"signature"`
+	// Encoded is the base64 signature in the encoding of the request, it is empty if the request gives no encoding
+	Encoded string `json:"encoded,omitempty"`
 }
 
 // Response key sign response
@@ -0,0 +1,158 @@
+package schnorr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/HyperCore-Team/tss-lib/common"
+	"github.com/HyperCore-Team/tss-lib/crypto"
+	cmt "github.com/HyperCore-Team/tss-lib/crypto/commitments"
+	"github.com/HyperCore-Team/tss-lib/ecdsa/keygen"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+// TaskName is the name of the schnorr signing in the errors of the parties
+const TaskName = "schnorr-signing"
+
+var _ btss.Party = (*LocalParty)(nil)
+
+// LocalParty signs one message with BIP-340 on secp256k1, the schnorr pools share their keys the same way as the ecdsa
+// pools, so the key shares come from the ecdsa keygen. It follows the eddsa signing of tss-lib, the nonces are
+// committed to in the first round, opened with a proof in the second one and the partial signatures are exchanged in
+// the third.
+type LocalParty struct {
+	*btss.BaseParty
+	params *btss.Parameters
+
+	keys keygen.LocalPartySaveData
+	temp localTempData
+	data *common.SignatureData
+
+	out chan<- btss.Message
+	end chan<- *common.SignatureData
+}
+
+type localTempData struct {
+	signRound1Messages,
+	signRound2Messages,
+	signRound3Messages []btss.ParsedMessage
+
+	// the message is signed as it is, BIP-340 takes the bytes rather than a number
+	m []byte
+	// the share of the key with the even Y, the shares are negated if the pool key has the odd Y
+	wi      *big.Int
+	negKey  bool
+	ri      *big.Int
+	pointRi *crypto.ECPoint
+
+	deCommit cmt.HashDeCommitment
+	cjs      []*big.Int
+	pointRj  []*crypto.ECPoint
+
+	// the nonce point with the even Y and the challenge of the signature
+	r    *crypto.ECPoint
+	negR bool
+	e    *big.Int
+	si   *big.Int
+
+	ssid      []byte
+	ssidNonce *big.Int
+}
+
+// NewLocalParty creates the party which signs the given 32 bytes message with the key shares of a schnorr pool
+func NewLocalParty(
+	msg []byte,
+	params *btss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- btss.Message,
+	end chan<- *common.SignatureData,
+) btss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(btss.BaseParty),
+		params:    params,
+		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	p.temp.signRound1Messages = make([]btss.ParsedMessage, partyCount)
+	p.temp.signRound2Messages = make([]btss.ParsedMessage, partyCount)
+	p.temp.signRound3Messages = make([]btss.ParsedMessage, partyCount)
+	p.temp.m = append([]byte{}, msg...)
+	p.temp.cjs = make([]*big.Int, partyCount)
+	p.temp.pointRj = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() btss.Round {
+	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *btss.Error {
+	return btss.BaseStart(p, TaskName, func(round btss.Round) *btss.Error {
+		round1, ok := round.(*round1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *LocalParty) Update(msg btss.ParsedMessage) (ok bool, err *btss.Error) {
+	return btss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *btss.PartyID, isBroadcast bool) (bool, *btss.Error) {
+	msg, err := btss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg btss.ParsedMessage) (bool, *btss.Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return p.BaseParty.ValidateMessage(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg btss.ParsedMessage) (bool, *btss.Error) {
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+	// the messages of the later rounds are stored as well, the replays are filtered by the caller
+	switch msg.Content().(type) {
+	case *messages.SchnorrSignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+	case *messages.SchnorrSignRound2Message:
+		p.temp.signRound2Messages[fromPIdx] = msg
+	case *messages.SchnorrSignRound3Message:
+		p.temp.signRound3Messages[fromPIdx] = msg
+	default:
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *btss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
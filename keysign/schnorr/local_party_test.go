@@ -0,0 +1,177 @@
+package schnorr
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	tsslibcommon "github.com/HyperCore-Team/tss-lib/common"
+	"github.com/HyperCore-Team/tss-lib/crypto"
+	"github.com/HyperCore-Team/tss-lib/ecdsa/keygen"
+	"github.com/HyperCore-Team/tss-lib/test"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type LocalPartyTestSuite struct {
+	partyIDs  btss.SortedPartyIDs
+	keys      []keygen.LocalPartySaveData
+	threshold int
+}
+
+var _ = Suite(&LocalPartyTestSuite{})
+
+// SetUpSuite loads the key shares of the test pool, the schnorr pools are generated by the ecdsa keygen
+func (s *LocalPartyTestSuite) SetUpSuite(c *C) {
+	var states []storage.KeygenLocalState
+	for i := 0; i < 4; i++ {
+		buf, err := ioutil.ReadFile(fmt.Sprintf("../../test_data/keysign_data/%d.json", i))
+		c.Assert(err, IsNil)
+		var state storage.KeygenLocalState
+		c.Assert(json.Unmarshal(buf, &state), IsNil)
+		states = append(states, state)
+	}
+	partyIDs, _, err := conversion.GetParties(states[0].ParticipantKeys, states[0].LocalPartyKey, true, "")
+	c.Assert(err, IsNil)
+	s.partyIDs = partyIDs
+	s.threshold, err = states[0].GetThreshold()
+	c.Assert(err, IsNil)
+	s.keys = make([]keygen.LocalPartySaveData, len(partyIDs))
+	for _, state := range states {
+		_, localPartyID, err := conversion.GetParties(state.ParticipantKeys, state.LocalPartyKey, true, "")
+		c.Assert(err, IsNil)
+		c.Assert(json.Unmarshal(state.LocalData, &s.keys[s.keyIndex(c, localPartyID)]), IsNil)
+	}
+}
+
+// route delivers the messages of the parties until done reports all the parties are finished
+func route(c *C, parties []btss.Party, outCh chan btss.Message, errCh chan *btss.Error, done func() bool) {
+	for _, el := range parties {
+		go func(party btss.Party) {
+			if err := party.Start(); err != nil {
+				errCh <- err
+			}
+		}(el)
+	}
+	ended := 0
+	for ended < len(parties) {
+		select {
+		case err := <-errCh:
+			c.Fatal(err)
+		case msg := <-outCh:
+			if msg.GetTo() == nil {
+				for _, el := range parties {
+					if el.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(el, msg, errCh)
+					}
+				}
+				continue
+			}
+			go test.SharedPartyUpdater(parties[msg.GetTo()[0].Index], msg, errCh)
+		default:
+			if done() {
+				ended++
+			}
+		}
+	}
+}
+
+func (s *LocalPartyTestSuite) sign(c *C, signers []int, msg []byte) []*tsslibcommon.SignatureData {
+	var signerIDs btss.UnSortedPartyIDs
+	for _, el := range signers {
+		signerIDs = append(signerIDs, btss.NewPartyID(s.partyIDs[el].Id, s.partyIDs[el].Moniker, new(big.Int).SetBytes(s.partyIDs[el].Key)))
+	}
+	sortedIDs := btss.SortPartyIDs(signerIDs)
+	p2pCtx := btss.NewPeerContext(sortedIDs)
+	outCh := make(chan btss.Message, len(signers)*len(signers))
+	endCh := make(chan *tsslibcommon.SignatureData, len(signers))
+	errCh := make(chan *btss.Error, len(signers))
+	parties := make([]btss.Party, len(sortedIDs))
+	for i, el := range sortedIDs {
+		params := btss.NewParameters(btss.S256(), p2pCtx, el, len(sortedIDs), s.threshold)
+		parties[i] = NewLocalParty(msg, params, s.keys[s.keyIndex(c, el)], outCh, endCh)
+	}
+	var results []*tsslibcommon.SignatureData
+	route(c, parties, outCh, errCh, func() bool {
+		select {
+		case data := <-endCh:
+			results = append(results, data)
+			return true
+		default:
+			return false
+		}
+	})
+	return results
+}
+
+func (s *LocalPartyTestSuite) keyIndex(c *C, id *btss.PartyID) int {
+	for i, el := range s.partyIDs {
+		if el.Id == id.Id {
+			return i
+		}
+	}
+	c.Fatalf("party %s has no key", id.Id)
+	return -1
+}
+
+// negatedKeys returns the shares of the pool key with the other Y, so both the even and the odd Y are tested
+func (s *LocalPartyTestSuite) negatedKeys() []keygen.LocalPartySaveData {
+	N := btss.S256().Params().N
+	keys := make([]keygen.LocalPartySaveData, len(s.keys))
+	for i, el := range s.keys {
+		keys[i] = el
+		keys[i].Xi = new(big.Int).Sub(N, el.Xi)
+		keys[i].BigXj = make([]*crypto.ECPoint, len(el.BigXj))
+		for j, x := range el.BigXj {
+			keys[i].BigXj[j] = negate(x)
+		}
+		keys[i].ECDSAPub = negate(el.ECDSAPub)
+	}
+	return keys
+}
+
+func (s *LocalPartyTestSuite) TestSign(c *C) {
+	original := s.keys
+	defer func() {
+		s.keys = original
+	}()
+	for _, keys := range [][]keygen.LocalPartySaveData{original, s.negatedKeys()} {
+		s.keys = keys
+		c.Log("odd Y: ", keys[0].ECDSAPub.Y().Bit(0))
+		poolPubKey, err := conversion.GetTssPubKeySchnorr(keys[0].ECDSAPub)
+		c.Assert(err, IsNil)
+		pk, err := conversion.ParseSchnorrPubKey(poolPubKey)
+		c.Assert(err, IsNil)
+		testCases := [][]int{{0, 1, 2}, {1, 2, 3}, {0, 1, 2, 3}}
+		for i, signers := range testCases {
+			c.Log(signers)
+			msg := sha256.Sum256([]byte(fmt.Sprintf("taproot spend %d", i)))
+			results := s.sign(c, signers, msg[:])
+			c.Assert(results, HasLen, len(signers))
+			for _, el := range results {
+				c.Assert(el.Signature, HasLen, 64)
+				c.Assert(el.Signature, DeepEquals, results[0].Signature)
+				sig, err := schnorr.ParseSignature(el.Signature)
+				c.Assert(err, IsNil)
+				c.Assert(sig.Verify(msg[:], pk), Equals, true)
+				ok, err := conversion.VerifySchnorrSignature(poolPubKey, msg[:], el.Signature)
+				c.Assert(err, IsNil)
+				c.Assert(ok, Equals, true)
+			}
+			// the signature doesn't verify any other message
+			other := sha256.Sum256([]byte("another message"))
+			ok, err := conversion.VerifySchnorrSignature(poolPubKey, other[:], results[0].Signature)
+			c.Assert(err, IsNil)
+			c.Assert(ok, Equals, false)
+		}
+	}
+}
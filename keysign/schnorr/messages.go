@@ -0,0 +1,64 @@
+package schnorr
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/HyperCore-Team/tss-lib/common"
+	"github.com/HyperCore-Team/tss-lib/crypto"
+	cmt "github.com/HyperCore-Team/tss-lib/crypto/commitments"
+	zkp "github.com/HyperCore-Team/tss-lib/crypto/schnorr"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+func newSignRound1Message(from *btss.PartyID, commitment cmt.HashCommitment) btss.ParsedMessage {
+	meta := btss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &messages.SchnorrSignRound1Message{
+		Commitment: commitment.Bytes(),
+	}
+	msg := btss.NewMessageWrapper(meta, content)
+	return btss.NewMessage(meta, content, msg)
+}
+
+func newSignRound2Message(from *btss.PartyID, deCommitment cmt.HashDeCommitment, proof *zkp.ZKProof) btss.ParsedMessage {
+	meta := btss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &messages.SchnorrSignRound2Message{
+		DeCommitment: common.BigIntsToBytes(deCommitment),
+		ProofAlphaX:  proof.Alpha.X().Bytes(),
+		ProofAlphaY:  proof.Alpha.Y().Bytes(),
+		ProofT:       proof.T.Bytes(),
+	}
+	msg := btss.NewMessageWrapper(meta, content)
+	return btss.NewMessage(meta, content, msg)
+}
+
+func newSignRound3Message(from *btss.PartyID, si *big.Int) btss.ParsedMessage {
+	meta := btss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &messages.SchnorrSignRound3Message{
+		S: si.Bytes(),
+	}
+	msg := btss.NewMessageWrapper(meta, content)
+	return btss.NewMessage(meta, content, msg)
+}
+
+func unmarshalZKProof(ec elliptic.Curve, m *messages.SchnorrSignRound2Message) (*zkp.ZKProof, error) {
+	point, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(m.GetProofAlphaX()), new(big.Int).SetBytes(m.GetProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &zkp.ZKProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+	}, nil
+}
@@ -0,0 +1,408 @@
+package schnorr
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/HyperCore-Team/tss-lib/common"
+	"github.com/HyperCore-Team/tss-lib/crypto"
+	"github.com/HyperCore-Team/tss-lib/crypto/commitments"
+	zkp "github.com/HyperCore-Team/tss-lib/crypto/schnorr"
+	"github.com/HyperCore-Team/tss-lib/ecdsa/keygen"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+type (
+	base struct {
+		*btss.Parameters
+		key     *keygen.LocalPartySaveData
+		data    *common.SignatureData
+		temp    *localTempData
+		out     chan<- btss.Message
+		end     chan<- *common.SignatureData
+		ok      []bool // the parties whose message of the round is received
+		started bool
+		number  int
+	}
+	round1 struct {
+		*base
+	}
+	round2 struct {
+		*round1
+	}
+	round3 struct {
+		*round2
+	}
+	finalization struct {
+		*round3
+	}
+)
+
+var (
+	_ btss.Round = (*round1)(nil)
+	_ btss.Round = (*round2)(nil)
+	_ btss.Round = (*round3)(nil)
+	_ btss.Round = (*finalization)(nil)
+)
+
+func newRound1(params *btss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- btss.Message, end chan<- *common.SignatureData) btss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *base) Params() *btss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (round *base) WaitingFor() []*btss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*btss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*btss.PartyID) *btss.Error {
+	return btss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// update marks the parties whose message of the round is received
+func (round *base) update(msgs []btss.ParsedMessage, canAccept func(btss.ParsedMessage) bool) (bool, *btss.Error) {
+	for j, msg := range msgs {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !canAccept(msg) {
+			return false, nil
+		}
+		round.ok[j] = true
+	}
+	return true, nil
+}
+
+func (round *base) getSSID() ([]byte, error) {
+	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy}
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)
+	bigXjList, err := crypto.FlattenECPoints(round.key.BigXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	ssidList = append(ssidList, bigXjList...)
+	ssidList = append(ssidList, big.NewInt(int64(round.number)))
+	ssidList = append(ssidList, round.temp.ssidNonce)
+	return common.SHA512_256i(ssidList...).Bytes(), nil
+}
+
+// prepare turns the share of the party into its additive share of the pool key with the even Y
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+	ks := round.key.Ks
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	if round.key.ECDSAPub == nil || !round.key.ECDSAPub.IsOnCurve() {
+		return errors.New("the pool key is not on the curve")
+	}
+	round.temp.wi = common.ModInt(round.EC().Params().N).Mul(lagrangeCoefficient(round.EC().Params().N, i, ks), round.key.Xi)
+	round.temp.negKey = round.key.ECDSAPub.Y().Bit(0) == 1
+	if round.temp.negKey {
+		round.temp.wi = new(big.Int).Sub(round.EC().Params().N, round.temp.wi)
+	}
+	return nil
+}
+
+func (round *round1) Start() *btss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.ssid = ssid
+
+	ri := common.GetRandomPositiveInt(round.EC().Params().N)
+	pointRi := crypto.ScalarBaseMult(round.EC(), ri)
+	cmt := commitments.NewHashCommitment(pointRi.X(), pointRi.Y())
+	round.temp.ri = ri
+	round.temp.pointRi = pointRi
+	round.temp.deCommit = cmt.D
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+	r1msg := newSignRound1Message(round.PartyID(), cmt.C)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+	return nil
+}
+
+func (round *round1) Update() (bool, *btss.Error) {
+	return round.update(round.temp.signRound1Messages, round.CanAccept)
+}
+
+func (round *round1) CanAccept(msg btss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*messages.SchnorrSignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() btss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+func (round *round2) Start() *btss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+	for j, msg := range round.temp.signRound1Messages {
+		r1msg := msg.Content().(*messages.SchnorrSignRound1Message)
+		round.temp.cjs[j] = new(big.Int).SetBytes(r1msg.GetCommitment())
+	}
+	contextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	proof, err := zkp.NewZKProof(contextI, round.temp.ri, round.temp.pointRi)
+	if err != nil {
+		return round.WrapError(fmt.Errorf("fail to prove the nonce: %w", err))
+	}
+	r2msg := newSignRound2Message(round.PartyID(), round.temp.deCommit, proof)
+	round.temp.signRound2Messages[i] = r2msg
+	round.out <- r2msg
+	return nil
+}
+
+func (round *round2) Update() (bool, *btss.Error) {
+	return round.update(round.temp.signRound2Messages, round.CanAccept)
+}
+
+func (round *round2) CanAccept(msg btss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*messages.SchnorrSignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() btss.Round {
+	round.started = false
+	return &round3{round}
+}
+
+func (round *round3) Start() *btss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+	round.temp.pointRj[i] = round.temp.pointRi
+	R := round.temp.pointRi
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r2msg := round.temp.signRound2Messages[j].Content().(*messages.SchnorrSignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: commitments.NewHashDeCommitmentFromBytes(r2msg.GetDeCommitment())}
+		ok, coordinates := cmtDeCmt.DeCommit()
+		if !ok || len(coordinates) != 2 {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj)
+		}
+		Rj, err := crypto.NewECPoint(round.EC(), coordinates[0], coordinates[1])
+		if err != nil {
+			return round.WrapError(fmt.Errorf("invalid nonce point: %w", err), Pj)
+		}
+		proof, err := unmarshalZKProof(round.EC(), r2msg)
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
+		}
+		contextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		if !proof.Verify(contextJ, Rj) {
+			return round.WrapError(errors.New("failed to prove Rj"), Pj)
+		}
+		round.temp.pointRj[j] = Rj
+		R, err = R.Add(Rj)
+		if err != nil {
+			return round.WrapError(fmt.Errorf("fail to add the nonce points: %w", err))
+		}
+	}
+
+	// BIP-340 only has the nonce points with the even Y, so every party negates its nonce if R has the odd Y
+	N := round.EC().Params().N
+	round.temp.negR = R.Y().Bit(0) == 1
+	ri := round.temp.ri
+	if round.temp.negR {
+		ri = new(big.Int).Sub(N, ri)
+	}
+	round.temp.r = R
+	round.temp.e = challenge(R, round.key.ECDSAPub, round.temp.m, N)
+
+	// si = ri + e*wi
+	modN := common.ModInt(N)
+	round.temp.si = modN.Add(ri, modN.Mul(round.temp.e, round.temp.wi))
+	r3msg := newSignRound3Message(round.PartyID(), round.temp.si)
+	round.temp.signRound3Messages[i] = r3msg
+	round.out <- r3msg
+	return nil
+}
+
+func (round *round3) Update() (bool, *btss.Error) {
+	return round.update(round.temp.signRound3Messages, round.CanAccept)
+}
+
+func (round *round3) CanAccept(msg btss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*messages.SchnorrSignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() btss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+func (round *finalization) Start() *btss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	N := round.EC().Params().N
+	modN := common.ModInt(N)
+	ks := round.key.Ks
+	sumS := big.NewInt(0)
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		r3msg := round.temp.signRound3Messages[j].Content().(*messages.SchnorrSignRound3Message)
+		sj := new(big.Int).SetBytes(r3msg.GetS())
+		// the partial signature is checked against the public share of the party, so we know who cheats
+		// sj*G = Rj + e*wj*G
+		ej := modN.Mul(round.temp.e, lagrangeCoefficient(N, j, ks))
+		if round.temp.negKey {
+			ej = new(big.Int).Sub(N, ej)
+		}
+		Rj := round.temp.pointRj[j]
+		if round.temp.negR {
+			Rj = negate(Rj)
+		}
+		expected, err := Rj.Add(round.key.BigXj[j].ScalarMult(ej))
+		if err != nil || sj.Cmp(N) >= 0 || !crypto.ScalarBaseMult(round.EC(), sj).Equals(expected) {
+			return round.WrapError(errors.New("invalid partial signature"), Pj)
+		}
+		sumS = modN.Add(sumS, sj)
+	}
+
+	rx := make([]byte, 32)
+	round.temp.r.X().FillBytes(rx)
+	s := make([]byte, 32)
+	sumS.FillBytes(s)
+	round.data.Signature = append(append([]byte{}, rx...), s...)
+	round.data.R = rx
+	round.data.S = s
+	round.data.M = round.temp.m
+
+	px := make([]byte, 32)
+	round.key.ECDSAPub.X().FillBytes(px)
+	pk, err := schnorr.ParsePubKey(px)
+	if err != nil {
+		return round.WrapError(fmt.Errorf("invalid pool key: %w", err))
+	}
+	sig, err := schnorr.ParseSignature(round.data.Signature)
+	if err != nil || !sig.Verify(round.temp.m, pk) {
+		return round.WrapError(errors.New("signature verification failed"))
+	}
+	round.end <- round.data
+	return nil
+}
+
+func (round *finalization) CanAccept(msg btss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *btss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() btss.Round {
+	return nil // finished!
+}
+
+// challenge is the BIP-340 challenge of the signature, the hash tagged with BIP0340/challenge of R.x || P.x || m
+func challenge(R, P *crypto.ECPoint, m []byte, N *big.Int) *big.Int {
+	tag := sha256.Sum256([]byte("BIP0340/challenge"))
+	h := sha256.New()
+	h.Write(tag[:])
+	h.Write(tag[:])
+	buf := make([]byte, 32)
+	h.Write(R.X().FillBytes(buf))
+	h.Write(P.X().FillBytes(buf))
+	h.Write(m)
+	return new(big.Int).Mod(new(big.Int).SetBytes(h.Sum(nil)), N)
+}
+
+// lagrangeCoefficient is the coefficient of the share of the i-th signer when the key is interpolated at zero
+func lagrangeCoefficient(N *big.Int, i int, ks []*big.Int) *big.Int {
+	modN := common.ModInt(N)
+	coef := big.NewInt(1)
+	for j, kj := range ks {
+		if j == i {
+			continue
+		}
+		coef = modN.Mul(coef, modN.Mul(kj, modN.ModInverse(new(big.Int).Sub(kj, ks[i]))))
+	}
+	return coef
+}
+
+func negate(p *crypto.ECPoint) *crypto.ECPoint {
+	y := new(big.Int).Sub(p.Curve().Params().P, p.Y())
+	return crypto.NewECPointNoCurveCheck(p.Curve(), p.X(), y)
+}
@@ -0,0 +1,271 @@
+package schnorr
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	tsslibcommon "github.com/HyperCore-Team/tss-lib/common"
+	"github.com/HyperCore-Team/tss-lib/ecdsa/keygen"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	tcrypto "github.com/tendermint/tendermint/crypto"
+	"go.uber.org/atomic"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/go-tss/p2p"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+// SchnorrTssKeySign signs the messages with BIP-340 schnorr signatures of the pool
+type SchnorrTssKeySign struct {
+	logger          zerolog.Logger
+	tssCommonStruct *common.TssCommon
+	stopChan        chan struct{} // channel to indicate whether we should stop
+	localParties    []*btss.PartyID
+	commStopChan    chan struct{}
+	p2pComm         *p2p.Communication
+	stateManager    storage.LocalStateManager
+	hashMode        common.HashMode
+}
+
+func NewTssKeySign(localP2PID string,
+	conf common.TssConfig,
+	broadcastChan chan *messages.BroadcastMsgChan,
+	stopChan chan struct{}, msgID string, privKey tcrypto.PrivKey, p2pComm *p2p.Communication, stateManager storage.LocalStateManager, msgNum int) *SchnorrTssKeySign {
+	logItems := []string{"keySign", msgID}
+	return &SchnorrTssKeySign{
+		logger:          log.With().Strs("module", logItems).Logger(),
+		tssCommonStruct: common.NewTssCommon(localP2PID, broadcastChan, conf, msgID, privKey, msgNum),
+		stopChan:        stopChan,
+		localParties:    make([]*btss.PartyID, 0),
+		commStopChan:    make(chan struct{}),
+		p2pComm:         p2pComm,
+		stateManager:    stateManager,
+	}
+}
+
+// SetHashMode tells how the messages become the digests which are signed
+func (tKeySign *SchnorrTssKeySign) SetHashMode(mode common.HashMode) {
+	tKeySign.hashMode = mode
+}
+
+func (tKeySign *SchnorrTssKeySign) GetTssKeySignChannels() chan *p2p.Message {
+	return tKeySign.tssCommonStruct.TssMsg
+}
+
+func (tKeySign *SchnorrTssKeySign) GetTssCommonStruct() *common.TssCommon {
+	return tKeySign.tssCommonStruct
+}
+
+func (tKeySign *SchnorrTssKeySign) startBatchSigning(keySignPartyMap *sync.Map, msgNum int) bool {
+	// start the batch sign
+	var keySignWg sync.WaitGroup
+	ret := atomic.NewBool(true)
+	keySignWg.Add(msgNum)
+	keySignPartyMap.Range(func(key, value interface{}) bool {
+		eachParty := value.(btss.Party)
+		go func(eachParty btss.Party) {
+			defer keySignWg.Done()
+			if err := eachParty.Start(); err != nil {
+				tKeySign.logger.Error().Err(err).Msg("fail to start key sign party")
+				ret.Store(false)
+			}
+			tKeySign.logger.Info().Msgf("local party(%s) %s is ready", eachParty.PartyID().Id, eachParty.PartyID().Moniker)
+		}(eachParty)
+		return true
+	})
+	keySignWg.Wait()
+	return ret.Load()
+}
+
+// signMessage
+func (tKeySign *SchnorrTssKeySign) SignMessage(msgsToSign [][]byte, localStateItem storage.KeygenLocalState, parties []string) ([]*tsslibcommon.SignatureData, error) {
+	partiesID, localPartyID, err := conversion.GetParties(parties, localStateItem.LocalPartyKey, true, "")
+	if err != nil {
+		return nil, fmt.Errorf("fail to form key sign party: %w", err)
+	}
+
+	if !common.Contains(partiesID, localPartyID) {
+		tKeySign.logger.Info().Msgf("we are not in this rounds key sign")
+		return nil, nil
+	}
+	threshold, err := localStateItem.GetThreshold()
+	if err != nil {
+		return nil, fmt.Errorf("fail to get threshold: %w", err)
+	}
+
+	outCh := make(chan btss.Message, 2*len(partiesID)*len(msgsToSign))
+	endCh := make(chan *tsslibcommon.SignatureData, len(partiesID)*len(msgsToSign))
+	errCh := make(chan struct{})
+
+	keySignPartyMap := new(sync.Map)
+	for i, msg := range msgsToSign {
+		val, err := common.HashMessage(msg, tKeySign.hashMode)
+		if err != nil {
+			return nil, err
+		}
+		// BIP-340 signs the 32 bytes hash of the transaction, the digests are never hashed again
+		if len(val) != 32 {
+			return nil, fmt.Errorf("the message to sign must be 32 bytes, got %d", len(val))
+		}
+		moniker := hex.EncodeToString(val) + ":" + strconv.Itoa(i)
+		partiesID, eachLocalPartyID, err := conversion.GetParties(parties, localStateItem.LocalPartyKey, true, "")
+		ctx := btss.NewPeerContext(partiesID)
+		if err != nil {
+			return nil, fmt.Errorf("error to create parties in batch signging %w\n", err)
+		}
+		tKeySign.logger.Info().Msgf("message: (%s) keysign parties: %+v", hex.EncodeToString(val), parties)
+		eachLocalPartyID.Moniker = moniker
+		tKeySign.localParties = nil
+		params := btss.NewParameters(btss.S256(), ctx, eachLocalPartyID, len(partiesID), threshold)
+		var localData keygen.LocalPartySaveData
+		err = json.Unmarshal(localStateItem.LocalData, &localData)
+		if err != nil {
+			return nil, fmt.Errorf("fail to unmarshal the local saved data")
+		}
+		keySignParty := NewLocalParty(val, params, localData, outCh, endCh)
+		keySignPartyMap.Store(moniker, keySignParty)
+	}
+
+	blameMgr := tKeySign.tssCommonStruct.GetBlameMgr()
+	partyIDMap := conversion.SetupPartyIDMap(partiesID)
+	err1 := conversion.SetupIDMaps(partyIDMap, tKeySign.tssCommonStruct.PartyIDtoP2PID)
+	err2 := conversion.SetupIDMaps(partyIDMap, blameMgr.PartyIDtoP2PID)
+	if err1 != nil || err2 != nil {
+		tKeySign.logger.Error().Err(err).Msgf("error in creating mapping between partyID and P2P ID")
+		return nil, err
+	}
+
+	tKeySign.tssCommonStruct.SetPartyInfo(&common.PartyInfo{
+		PartyMap:   keySignPartyMap,
+		PartyIDMap: partyIDMap,
+	})
+
+	blameMgr.SetPartyInfo(keySignPartyMap, partyIDMap)
+
+	tKeySign.tssCommonStruct.P2PPeersLock.Lock()
+	tKeySign.tssCommonStruct.P2PPeers = conversion.GetPeersID(tKeySign.tssCommonStruct.PartyIDtoP2PID, tKeySign.tssCommonStruct.GetLocalPeerID())
+	tKeySign.tssCommonStruct.P2PPeersLock.Unlock()
+	var keySignWg sync.WaitGroup
+	keySignWg.Add(2)
+	// start the key sign
+	go func() {
+		defer keySignWg.Done()
+		ret := tKeySign.startBatchSigning(keySignPartyMap, len(msgsToSign))
+		if !ret {
+			close(errCh)
+		}
+	}()
+	go tKeySign.tssCommonStruct.ProcessInboundMessages(tKeySign.commStopChan, &keySignWg)
+	results, err := tKeySign.processKeySign(len(msgsToSign), errCh, outCh, endCh)
+	if err != nil {
+		close(tKeySign.commStopChan)
+		return nil, fmt.Errorf("fail to process key sign: %w", err)
+	}
+
+	select {
+	case <-time.After(time.Second * 5):
+		close(tKeySign.commStopChan)
+	case <-tKeySign.tssCommonStruct.GetTaskDone():
+		close(tKeySign.commStopChan)
+	}
+	keySignWg.Wait()
+
+	tKeySign.logger.Info().Msgf("%s successfully sign the message", tKeySign.p2pComm.GetHost().ID().String())
+	sort.SliceStable(results, func(i, j int) bool {
+		a := new(big.Int).SetBytes(results[i].M)
+		b := new(big.Int).SetBytes(results[j].M)
+
+		if a.Cmp(b) == -1 {
+			return false
+		}
+		return true
+	})
+
+	return results, nil
+}
+
+func (tKeySign *SchnorrTssKeySign) processKeySign(reqNum int, errChan chan struct{}, outCh <-chan btss.Message, endCh <-chan *tsslibcommon.SignatureData) ([]*tsslibcommon.SignatureData, error) {
+	defer tKeySign.logger.Debug().Msg("key sign finished")
+	tKeySign.logger.Debug().Msg("start to read messages from local party")
+	var signatures []*tsslibcommon.SignatureData
+
+	tssConf := tKeySign.tssCommonStruct.GetConf()
+	blameMgr := tKeySign.tssCommonStruct.GetBlameMgr()
+
+	for {
+		select {
+		case <-errChan: // when key sign return
+			tKeySign.logger.Error().Msg("key sign failed")
+			return nil, errors.New("error channel closed fail to start local party")
+		case <-tKeySign.stopChan: // when TSS processor receive signal to quit
+			return nil, errors.New("received exit signal")
+		case <-time.After(tssConf.KeySignTimeout):
+			// we bail out after KeySignTimeoutSeconds
+			tKeySign.logger.Error().Msgf("fail to sign message with %s", tssConf.KeySignTimeout.String())
+			lastMsg := blameMgr.GetLastMsg()
+
+			tKeySign.tssCommonStruct.P2PPeersLock.RLock()
+			threshold, err := conversion.GetThreshold(len(tKeySign.tssCommonStruct.P2PPeers) + 1)
+			tKeySign.tssCommonStruct.P2PPeersLock.RUnlock()
+			if err != nil {
+				tKeySign.logger.Error().Err(err).Msg("error in get the threshold for generate blame")
+			}
+			// all the messages of the schnorr signing are broadcast
+			blameNodesBroadcast, err := blameMgr.GetBroadcastBlame(lastMsg.Type())
+			if err != nil {
+				tKeySign.logger.Error().Err(err).Msg("error in get broadcast blame")
+			}
+			blameMgr.GetBlame().AddBlameNodes(blameNodesBroadcast...)
+
+			// if we cannot find the blame node, we check whether everyone send me the share
+			if len(blameMgr.GetBlame().BlameNodes) == 0 {
+				blameNodesMisingShare, isUnicast, err := blameMgr.TssMissingShareBlame(messages.SCHNORRKEYSIGNROUNDS, messages.SCHNORRKEYSIGN)
+				if err != nil {
+					tKeySign.logger.Error().Err(err).Msg("fail to get the node of missing share ")
+				}
+
+				if len(blameNodesMisingShare) > 0 && len(blameNodesMisingShare) <= threshold {
+					blameMgr.GetBlame().AddBlameNodes(blameNodesMisingShare...)
+					blameMgr.GetBlame().IsUnicast = isUnicast
+				}
+			}
+
+			return nil, blame.ErrTssTimeOut
+		case msg := <-outCh:
+			tKeySign.logger.Debug().Msgf(">>>>>>>>>>key sign msg: %s", msg.String())
+			tKeySign.tssCommonStruct.GetBlameMgr().SetLastMsg(msg)
+			err := tKeySign.tssCommonStruct.ProcessOutCh(msg, messages.TSSKeySignMsg)
+			if err != nil {
+				return nil, err
+			}
+
+		case msg := <-endCh:
+			signatures = append(signatures, msg)
+			if len(signatures) == reqNum {
+				tKeySign.logger.Debug().Msg("we have done the key sign")
+				err := tKeySign.tssCommonStruct.NotifyTaskDone()
+				if err != nil {
+					tKeySign.logger.Error().Err(err).Msg("fail to broadcast the keysign done")
+				}
+				//export the address book
+				address := tKeySign.p2pComm.ExportPeerAddress()
+				if err := tKeySign.stateManager.SaveAddressBook(address); err != nil {
+					tKeySign.logger.Error().Err(err).Msg("fail to save the peer addresses")
+				}
+				return signatures, nil
+			}
+		}
+	}
+}
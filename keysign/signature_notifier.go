@@ -3,6 +3,7 @@ package keysign
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -16,12 +17,28 @@ import (
 	"github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/proto"
 
+	tsscommon "github.com/HyperCore-Team/go-tss/common"
 	"github.com/HyperCore-Team/go-tss/messages"
 	"github.com/HyperCore-Team/go-tss/p2p"
+	"github.com/HyperCore-Team/go-tss/storage"
 )
 
 var signatureNotifierProtocol protocol.ID = "/p2p/signatureNotifier"
 
+const (
+	// unclaimedSignatureTTL is how long the signatures nobody waits for are kept for a keysign which starts late
+	unclaimedSignatureTTL = time.Minute
+	// maxUnclaimedSignatures is how many keysigns may have signatures nobody waits for
+	maxUnclaimedSignatures = 256
+)
+
+// unclaimedSignature are the signatures of a keysign which arrived before we waited for them, they are verified once
+// the keysign waits for them
+type unclaimedSignature struct {
+	signatures []*common.SignatureData
+	receivedAt time.Time
+}
+
 type signatureItem struct {
 	messageID     string
 	peerID        peer.ID
@@ -39,6 +56,10 @@ type SignatureNotifier struct {
 	streamMgr    *p2p.StreamMgr
 	whitelist    map[string]bool
 	algo         messages.Algo
+	// store keeps the signatures of the finished keysigns, they are not kept if it is nil
+	store *storage.SignatureStore
+	// unclaimed are the signatures of the keysigns nobody waits for yet, keyed by the msgID
+	unclaimed map[string]unclaimedSignature
 }
 
 // NewSignatureNotifier create a new instance of SignatureNotifier
@@ -48,6 +69,7 @@ func NewSignatureNotifier(host host.Host, whitelist map[string]bool, algo messag
 		host:         host,
 		notifierLock: &sync.Mutex{},
 		notifiers:    make(map[string]*Notifier),
+		unclaimed:    make(map[string]unclaimedSignature),
 		messages:     make(chan *signatureItem),
 		streamMgr:    p2p.NewStreamMgr(),
 		whitelist:    whitelist,
@@ -112,15 +134,20 @@ func (s *SignatureNotifier) handleStream(stream network.Stream) {
 	n, ok := s.notifiers[msg.ID]
 	if !ok {
 		logger.Debug().Msgf("notifier for message id(%s) not exist", msg.ID)
+		s.keepUnclaimed(msg.ID, signatures)
 		return
 	}
-	finished, err := n.ProcessSignature(signatures, s.algo)
+	// the signatures are verified with the algo of the keysign we wait for
+	finished, err := n.ProcessSignature(signatures, n.algo)
 	if err != nil {
 		logger.Error().Err(err).Msg("fail to verify local signature data")
 		return
 	}
 	if finished {
 		delete(s.notifiers, msg.ID)
+		if err := s.SaveSignatures(msg.ID, n.poolPubKey, n.algo, n.messages, signatures); err != nil {
+			logger.Error().Err(err).Msg("fail to save the received signatures")
+		}
 	}
 }
 
@@ -205,10 +232,49 @@ func (s *SignatureNotifier) BroadcastFailed(messageID string, peers []peer.ID) e
 	return s.broadcastCommon(messageID, nil, peers)
 }
 
+// keepUnclaimed keeps the signatures nobody waits for, e.g. the ones a peer answers from its cache before the keysign
+// of this node starts, the caller holds the notifier lock
+func (s *SignatureNotifier) keepUnclaimed(messageID string, signatures []*common.SignatureData) {
+	if len(signatures) == 0 {
+		return
+	}
+	now := time.Now()
+	for id, el := range s.unclaimed {
+		if now.Sub(el.receivedAt) > unclaimedSignatureTTL {
+			delete(s.unclaimed, id)
+		}
+	}
+	if len(s.unclaimed) >= maxUnclaimedSignatures {
+		s.logger.Debug().Msgf("too many unclaimed signatures, the ones of message id(%s) are dropped", messageID)
+		return
+	}
+	s.unclaimed[messageID] = unclaimedSignature{signatures: signatures, receivedAt: now}
+}
+
+// addToNotifiers starts waiting for the signatures, the ones which arrived before are handed to the notifier
 func (s *SignatureNotifier) addToNotifiers(n *Notifier) {
 	s.notifierLock.Lock()
 	defer s.notifierLock.Unlock()
 	s.notifiers[n.MessageID] = n
+	unclaimed, ok := s.unclaimed[n.MessageID]
+	if !ok {
+		return
+	}
+	delete(s.unclaimed, n.MessageID)
+	if time.Since(unclaimed.receivedAt) > unclaimedSignatureTTL {
+		return
+	}
+	finished, err := n.ProcessSignature(unclaimed.signatures, n.algo)
+	if err != nil {
+		s.logger.Error().Err(err).Msgf("fail to verify the unclaimed signatures of message id(%s)", n.MessageID)
+		return
+	}
+	if finished {
+		delete(s.notifiers, n.MessageID)
+		if err := s.SaveSignatures(n.MessageID, n.poolPubKey, n.algo, n.messages, unclaimed.signatures); err != nil {
+			s.logger.Error().Err(err).Msg("fail to save the unclaimed signatures")
+		}
+	}
 }
 
 func (s *SignatureNotifier) removeNotifier(n *Notifier) {
@@ -218,8 +284,13 @@ func (s *SignatureNotifier) removeNotifier(n *Notifier) {
 }
 
 // WaitForSignature wait until keysign finished and signature is available
-func (s *SignatureNotifier) WaitForSignature(messageID string, message [][]byte, poolPubKey string, timeout time.Duration, sigChan chan string, algo messages.Algo) ([]*common.SignatureData, error) {
-	n, err := NewNotifier(messageID, message, poolPubKey, algo)
+func (s *SignatureNotifier) WaitForSignature(messageID string, message [][]byte, poolPubKey string, timeout time.Duration, sigChan chan string, algo messages.Algo, hashMode tsscommon.HashMode) ([]*common.SignatureData, error) {
+	return s.WaitForSignatureWithContext(context.Background(), messageID, message, poolPubKey, timeout, sigChan, algo, hashMode)
+}
+
+// WaitForSignatureWithContext is WaitForSignature which gives up as soon as the given context is done
+func (s *SignatureNotifier) WaitForSignatureWithContext(ctx context.Context, messageID string, message [][]byte, poolPubKey string, timeout time.Duration, sigChan chan string, algo messages.Algo, hashMode tsscommon.HashMode) ([]*common.SignatureData, error) {
+	n, err := NewNotifier(messageID, message, poolPubKey, algo, hashMode)
 	if err != nil {
 		return nil, fmt.Errorf("fail to create notifier")
 	}
@@ -230,10 +301,58 @@ func (s *SignatureNotifier) WaitForSignature(messageID string, message [][]byte,
 	case d := <-n.GetResponseChannel():
 		return d, nil
 	case <-time.After(timeout):
-		return nil, fmt.Errorf("timeout: didn't receive signature after %s", timeout)
+		return nil, fmt.Errorf("%w after %s", ErrSignatureTimeout, timeout)
 	case <-sigChan:
 		return nil, p2p.ErrSigGenerated
+	case <-ctx.Done():
+		return nil, fmt.Errorf("fail to wait for signature: %w", ctx.Err())
+	}
+}
+
+// SetSignatureStore keeps the signatures of the keysigns in the given store, it has to be set before the first keysign
+func (s *SignatureNotifier) SetSignatureStore(store *storage.SignatureStore) {
+	s.store = store
+}
+
+// SaveSignatures keeps the signatures of the keysign of the messages, nothing is kept if no store is set
+func (s *SignatureNotifier) SaveSignatures(messageID, poolPubKey string, algo messages.Algo, msgs [][]byte, sigs []*common.SignatureData) error {
+	if s.store == nil || len(sigs) == 0 {
+		return nil
+	}
+	rec := storage.SignatureRecord{
+		MsgID:      messageID,
+		PoolPubKey: poolPubKey,
+		Algo:       algo,
+		Messages:   msgs,
+	}
+	for _, el := range sigs {
+		buf, err := proto.Marshal(el)
+		if err != nil {
+			return fmt.Errorf("fail to marshal signature data to bytes:%w", err)
+		}
+		rec.Signatures = append(rec.Signatures, buf)
+	}
+	return s.store.Save(rec)
+}
+
+// GetSignatures returns the kept signatures of the keysign, the error is os.ErrNotExist if there are none
+func (s *SignatureNotifier) GetSignatures(messageID string) (storage.SignatureRecord, []*common.SignatureData, error) {
+	if s.store == nil {
+		return storage.SignatureRecord{}, nil, fmt.Errorf("no signature is kept: %w", os.ErrNotExist)
+	}
+	rec, err := s.store.Get(messageID)
+	if err != nil {
+		return storage.SignatureRecord{}, nil, err
+	}
+	sigs := make([]*common.SignatureData, len(rec.Signatures))
+	for i, el := range rec.Signatures {
+		var signature common.SignatureData
+		if err := proto.Unmarshal(el, &signature); err != nil {
+			return storage.SignatureRecord{}, nil, fmt.Errorf("fail to unmarshal signature data: %w", err)
+		}
+		sigs[i] = &signature
 	}
+	return rec, sigs, nil
 }
 
 func (s *SignatureNotifier) ReleaseStream(msgID string) {
@@ -0,0 +1,78 @@
+package keysign
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/HyperCore-Team/tss-lib/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/rs/zerolog"
+	. "gopkg.in/check.v1"
+
+	tsscommon "github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+type SignatureNotifierTestSuite struct{}
+
+var _ = Suite(&SignatureNotifierTestSuite{})
+
+func (SignatureNotifierTestSuite) TestUnclaimedSignatures(c *C) {
+	priKey, err := btcec.NewPrivateKey()
+	c.Assert(err, IsNil)
+	poolPubKey := base64.StdEncoding.EncodeToString(priKey.PubKey().SerializeCompressed())
+	payload := []byte("a late keysign")
+	digest, err := tsscommon.HashMessage(payload, tsscommon.HashModeSHA256)
+	c.Assert(err, IsNil)
+	r, s, err := ecdsa.Sign(rand.Reader, priKey.ToECDSA(), digest)
+	c.Assert(err, IsNil)
+	sig := &common.SignatureData{R: r.Bytes(), S: s.Bytes(), Signature: append(r.Bytes(), s.Bytes()...)}
+	sn := &SignatureNotifier{
+		logger:       zerolog.Nop(),
+		notifierLock: &sync.Mutex{},
+		notifiers:    make(map[string]*Notifier),
+		unclaimed:    make(map[string]unclaimedSignature),
+	}
+	wait := func(msgID string) ([]*common.SignatureData, error) {
+		return sn.WaitForSignatureWithContext(context.Background(), msgID, [][]byte{payload}, poolPubKey, 100*time.Millisecond,
+			make(chan string), messages.ECDSAKEYSIGN, tsscommon.HashModeSHA256)
+	}
+
+	// the signatures which arrived before the keysign waits for them are handed to it
+	sn.notifierLock.Lock()
+	sn.keepUnclaimed("early", []*common.SignatureData{sig})
+	sn.notifierLock.Unlock()
+	data, err := wait("early")
+	c.Assert(err, IsNil)
+	c.Assert(data, DeepEquals, []*common.SignatureData{sig})
+	c.Assert(sn.unclaimed, HasLen, 0)
+	c.Assert(sn.notifiers, HasLen, 0)
+
+	// the unclaimed signatures are verified and expire
+	sn.notifierLock.Lock()
+	sn.keepUnclaimed("invalid", []*common.SignatureData{{R: s.Bytes(), S: r.Bytes(), Signature: append(s.Bytes(), r.Bytes()...)}})
+	sn.keepUnclaimed("expired", []*common.SignatureData{sig})
+	sn.unclaimed["expired"] = unclaimedSignature{
+		signatures: []*common.SignatureData{sig},
+		receivedAt: time.Now().Add(-2 * unclaimedSignatureTTL),
+	}
+	sn.notifierLock.Unlock()
+	for _, el := range []string{"invalid", "expired"} {
+		_, err = wait(el)
+		c.Assert(errors.Is(err, ErrSignatureTimeout), Equals, true, Commentf(el))
+	}
+
+	// only a few keysigns may have unclaimed signatures
+	sn.notifierLock.Lock()
+	for i := 0; i < maxUnclaimedSignatures+10; i++ {
+		sn.keepUnclaimed(string(rune('a'+i)), []*common.SignatureData{sig})
+	}
+	sn.keepUnclaimed("failed", nil)
+	c.Assert(sn.unclaimed, HasLen, maxUnclaimedSignatures)
+	sn.notifierLock.Unlock()
+}
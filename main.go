@@ -45,7 +45,7 @@ func getEcdsaServer(index int, port int, privKey string, param *btsskeygen.Local
 	if param != nil {
 		preparam = param
 	}
-	instance, err := tss.NewTss(peerIDs, port, priKey, "Asgard", baseHome, conf, preparam, "", messages.ECDSAKEYGEN, pubKeyWhitelist)
+	instance, err := tss.NewTss(peerIDs, port, priKey, "Asgard", baseHome, conf, preparam, "", messages.ECDSAKEYGEN, pubKeyWhitelist, tss.Options{})
 	if err != nil {
 		panic(err)
 	}
@@ -74,7 +74,7 @@ func getEddsaServer(index int, port int, privKey string, conf common.TssConfig,
 	} else {
 		peerIDs = nil
 	}
-	instance, err := tss.NewTss(peerIDs, port, priKey, "Asgard", baseHome, conf, nil, "", messages.EDDSAKEYGEN, whiteList)
+	instance, err := tss.NewTss(peerIDs, port, priKey, "Asgard", baseHome, conf, nil, "", messages.EDDSAKEYGEN, whiteList, tss.Options{})
 	return instance
 }
 
@@ -18,6 +18,11 @@ const (
 	EDDSAKEYSIGNROUNDS = 3
 	EDDSAREGROUPROUNDS = 5
 
+	SCHNORRKEYSIGN1      = "SchnorrSignRound1Message"
+	SCHNORRKEYSIGN2      = "SchnorrSignRound2Message"
+	SCHNORRKEYSIGN3      = "SchnorrSignRound3Message"
+	SCHNORRKEYSIGNROUNDS = 3
+
 	ECDSAKEYGENROUNDS  = 4
 	ECDSAKEYSIGNROUNDS = 8
 	ECDSAREGROUPROUNDS = 5
@@ -51,4 +56,6 @@ const (
 	EDDSAKEYSIGN
 	EDDSAKEYREGROUP
 	ECDSAKEYREGROUP
+	SCHNORRKEYGEN
+	SCHNORRKEYSIGN
 )
@@ -1,2 +1,2 @@
 
-protoc --go_out=. *.proto
\ No newline at end of file
+protoc --go_out=. --go-grpc_out=. *.proto
\ No newline at end of file
@@ -27,6 +27,12 @@ const (
 	TSSControlMsg
 	// TSSTaskDone is the message of Tss process notification
 	TSSTaskDone
+	// TSSRefreshMsg is the message of refreshing the key shares of the tss parties
+	TSSRefreshMsg
+	// TSSRefreshVerMsg is the message we create to make sure every party receive the same broadcast message
+	TSSRefreshVerMsg
+	// TSSChainCodeMsg is the message the old committee tells the new members the chain code of the pool with
+	TSSChainCodeMsg
 	// Unknown is the message indicates the undefined message type
 	Unknown
 )
@@ -46,6 +52,12 @@ func (msgType THORChainTSSMessageType) String() string {
 		return "TSSPartyReGroupMsg"
 	case TSSPartReGroupVerMSg:
 		return "TSSPartReGroupVerMSg"
+	case TSSRefreshMsg:
+		return "TSSRefreshMsg"
+	case TSSRefreshVerMsg:
+		return "TSSRefreshVerMsg"
+	case TSSChainCodeMsg:
+		return "TSSChainCodeMsg"
 	default:
 		return "Unknown"
 	}
@@ -107,3 +119,8 @@ type TssControl struct {
 type TssTaskNotifier struct {
 	TaskDone bool `json:"task_done"`
 }
+
+// TssChainCode is the chain code of the regrouped pool, the new members can't compute it
+type TssChainCode struct {
+	ChainCode []byte `json:"chain_code"`
+}
@@ -0,0 +1,29 @@
+package messages
+
+import (
+	"github.com/HyperCore-Team/tss-lib/common"
+	btss "github.com/HyperCore-Team/tss-lib/tss"
+)
+
+// make sure the schnorr signing messages can be carried by tss-lib
+var _ = []btss.MessageContent{
+	(*SchnorrSignRound1Message)(nil),
+	(*SchnorrSignRound2Message)(nil),
+	(*SchnorrSignRound3Message)(nil),
+}
+
+func (m *SchnorrSignRound1Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *SchnorrSignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment(), 3) &&
+		common.NonEmptyBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetProofT())
+}
+
+func (m *SchnorrSignRound3Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetS())
+}
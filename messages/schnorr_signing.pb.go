@@ -0,0 +1,305 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.0
+// source: schnorr_signing.proto
+
+package messages
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SchnorrSignRound1Message is the commitment to the nonce point of the party
+type SchnorrSignRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commitment []byte `protobuf:"bytes,1,opt,name=Commitment,proto3" json:"Commitment,omitempty"`
+}
+
+func (x *SchnorrSignRound1Message) Reset() {
+	*x = SchnorrSignRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schnorr_signing_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchnorrSignRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchnorrSignRound1Message) ProtoMessage() {}
+
+func (x *SchnorrSignRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_schnorr_signing_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchnorrSignRound1Message.ProtoReflect.Descriptor instead.
+func (*SchnorrSignRound1Message) Descriptor() ([]byte, []int) {
+	return file_schnorr_signing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SchnorrSignRound1Message) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+// SchnorrSignRound2Message opens the commitment and proves the party knows the nonce of the point
+type SchnorrSignRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeCommitment [][]byte `protobuf:"bytes,1,rep,name=DeCommitment,proto3" json:"DeCommitment,omitempty"`
+	ProofAlphaX  []byte   `protobuf:"bytes,2,opt,name=ProofAlphaX,proto3" json:"ProofAlphaX,omitempty"`
+	ProofAlphaY  []byte   `protobuf:"bytes,3,opt,name=ProofAlphaY,proto3" json:"ProofAlphaY,omitempty"`
+	ProofT       []byte   `protobuf:"bytes,4,opt,name=ProofT,proto3" json:"ProofT,omitempty"`
+}
+
+func (x *SchnorrSignRound2Message) Reset() {
+	*x = SchnorrSignRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schnorr_signing_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchnorrSignRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchnorrSignRound2Message) ProtoMessage() {}
+
+func (x *SchnorrSignRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_schnorr_signing_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchnorrSignRound2Message.ProtoReflect.Descriptor instead.
+func (*SchnorrSignRound2Message) Descriptor() ([]byte, []int) {
+	return file_schnorr_signing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SchnorrSignRound2Message) GetDeCommitment() [][]byte {
+	if x != nil {
+		return x.DeCommitment
+	}
+	return nil
+}
+
+func (x *SchnorrSignRound2Message) GetProofAlphaX() []byte {
+	if x != nil {
+		return x.ProofAlphaX
+	}
+	return nil
+}
+
+func (x *SchnorrSignRound2Message) GetProofAlphaY() []byte {
+	if x != nil {
+		return x.ProofAlphaY
+	}
+	return nil
+}
+
+func (x *SchnorrSignRound2Message) GetProofT() []byte {
+	if x != nil {
+		return x.ProofT
+	}
+	return nil
+}
+
+// SchnorrSignRound3Message is the partial signature of the party
+type SchnorrSignRound3Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	S []byte `protobuf:"bytes,1,opt,name=S,proto3" json:"S,omitempty"`
+}
+
+func (x *SchnorrSignRound3Message) Reset() {
+	*x = SchnorrSignRound3Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schnorr_signing_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchnorrSignRound3Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchnorrSignRound3Message) ProtoMessage() {}
+
+func (x *SchnorrSignRound3Message) ProtoReflect() protoreflect.Message {
+	mi := &file_schnorr_signing_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchnorrSignRound3Message.ProtoReflect.Descriptor instead.
+func (*SchnorrSignRound3Message) Descriptor() ([]byte, []int) {
+	return file_schnorr_signing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SchnorrSignRound3Message) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+var File_schnorr_signing_proto protoreflect.FileDescriptor
+
+var file_schnorr_signing_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x73, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x22, 0x3a, 0x0a, 0x18, 0x53, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x9a, 0x01,
+	0x0a, 0x18, 0x53, 0x63, 0x68, 0x6e, 0x6f, 0x72, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0c, 0x44, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x20,
+	0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58,
+	0x12, 0x20, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68,
+	0x61, 0x59, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0x28, 0x0a, 0x18, 0x53, 0x63,
+	0x68, 0x6e, 0x6f, 0x72, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x53, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x01, 0x53, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x48, 0x79, 0x70, 0x65, 0x72, 0x43, 0x6f, 0x72, 0x65, 0x2d, 0x54, 0x65, 0x61,
+	0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x74, 0x73, 0x73, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_schnorr_signing_proto_rawDescOnce sync.Once
+	file_schnorr_signing_proto_rawDescData = file_schnorr_signing_proto_rawDesc
+)
+
+func file_schnorr_signing_proto_rawDescGZIP() []byte {
+	file_schnorr_signing_proto_rawDescOnce.Do(func() {
+		file_schnorr_signing_proto_rawDescData = protoimpl.X.CompressGZIP(file_schnorr_signing_proto_rawDescData)
+	})
+	return file_schnorr_signing_proto_rawDescData
+}
+
+var file_schnorr_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_schnorr_signing_proto_goTypes = []interface{}{
+	(*SchnorrSignRound1Message)(nil), // 0: messages.SchnorrSignRound1Message
+	(*SchnorrSignRound2Message)(nil), // 1: messages.SchnorrSignRound2Message
+	(*SchnorrSignRound3Message)(nil), // 2: messages.SchnorrSignRound3Message
+}
+var file_schnorr_signing_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_schnorr_signing_proto_init() }
+func file_schnorr_signing_proto_init() {
+	if File_schnorr_signing_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_schnorr_signing_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchnorrSignRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schnorr_signing_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchnorrSignRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schnorr_signing_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchnorrSignRound3Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_schnorr_signing_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_schnorr_signing_proto_goTypes,
+		DependencyIndexes: file_schnorr_signing_proto_depIdxs,
+		MessageInfos:      file_schnorr_signing_proto_msgTypes,
+	}.Build()
+	File_schnorr_signing_proto = out.File
+	file_schnorr_signing_proto_rawDesc = nil
+	file_schnorr_signing_proto_goTypes = nil
+	file_schnorr_signing_proto_depIdxs = nil
+}
@@ -0,0 +1,2001 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.0
+// source: tss_service.proto
+
+package messages
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type KeyAlgo int32
+
+const (
+	KeyAlgo_UnknownAlgo KeyAlgo = 0
+	KeyAlgo_ECDSA       KeyAlgo = 1
+	KeyAlgo_EDDSA       KeyAlgo = 2
+	KeyAlgo_SCHNORR     KeyAlgo = 3 // BIP-340 on secp256k1
+)
+
+// Enum value maps for KeyAlgo.
+var (
+	KeyAlgo_name = map[int32]string{
+		0: "UnknownAlgo",
+		1: "ECDSA",
+		2: "EDDSA",
+		3: "SCHNORR",
+	}
+	KeyAlgo_value = map[string]int32{
+		"UnknownAlgo": 0,
+		"ECDSA":       1,
+		"EDDSA":       2,
+		"SCHNORR":     3,
+	}
+)
+
+func (x KeyAlgo) Enum() *KeyAlgo {
+	p := new(KeyAlgo)
+	*p = x
+	return p
+}
+
+func (x KeyAlgo) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyAlgo) Descriptor() protoreflect.EnumDescriptor {
+	return file_tss_service_proto_enumTypes[0].Descriptor()
+}
+
+func (KeyAlgo) Type() protoreflect.EnumType {
+	return &file_tss_service_proto_enumTypes[0]
+}
+
+func (x KeyAlgo) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyAlgo.Descriptor instead.
+func (KeyAlgo) EnumDescriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{0}
+}
+
+type CeremonyStatus int32
+
+const (
+	CeremonyStatus_NA      CeremonyStatus = 0
+	CeremonyStatus_Success CeremonyStatus = 1
+	CeremonyStatus_Fail    CeremonyStatus = 2
+)
+
+// Enum value maps for CeremonyStatus.
+var (
+	CeremonyStatus_name = map[int32]string{
+		0: "NA",
+		1: "Success",
+		2: "Fail",
+	}
+	CeremonyStatus_value = map[string]int32{
+		"NA":      0,
+		"Success": 1,
+		"Fail":    2,
+	}
+)
+
+func (x CeremonyStatus) Enum() *CeremonyStatus {
+	p := new(CeremonyStatus)
+	*p = x
+	return p
+}
+
+func (x CeremonyStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CeremonyStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_tss_service_proto_enumTypes[1].Descriptor()
+}
+
+func (CeremonyStatus) Type() protoreflect.EnumType {
+	return &file_tss_service_proto_enumTypes[1]
+}
+
+func (x CeremonyStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CeremonyStatus.Descriptor instead.
+func (CeremonyStatus) EnumDescriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{1}
+}
+
+type CeremonyProgress_Phase int32
+
+const (
+	CeremonyProgress_PhaseUnknown   CeremonyProgress_Phase = 0
+	CeremonyProgress_PhaseQueued    CeremonyProgress_Phase = 1
+	CeremonyProgress_PhaseJoinParty CeremonyProgress_Phase = 2
+	CeremonyProgress_PhaseRound     CeremonyProgress_Phase = 3
+)
+
+// Enum value maps for CeremonyProgress_Phase.
+var (
+	CeremonyProgress_Phase_name = map[int32]string{
+		0: "PhaseUnknown",
+		1: "PhaseQueued",
+		2: "PhaseJoinParty",
+		3: "PhaseRound",
+	}
+	CeremonyProgress_Phase_value = map[string]int32{
+		"PhaseUnknown":   0,
+		"PhaseQueued":    1,
+		"PhaseJoinParty": 2,
+		"PhaseRound":     3,
+	}
+)
+
+func (x CeremonyProgress_Phase) Enum() *CeremonyProgress_Phase {
+	p := new(CeremonyProgress_Phase)
+	*p = x
+	return p
+}
+
+func (x CeremonyProgress_Phase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CeremonyProgress_Phase) Descriptor() protoreflect.EnumDescriptor {
+	return file_tss_service_proto_enumTypes[2].Descriptor()
+}
+
+func (CeremonyProgress_Phase) Type() protoreflect.EnumType {
+	return &file_tss_service_proto_enumTypes[2]
+}
+
+func (x CeremonyProgress_Phase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CeremonyProgress_Phase.Descriptor instead.
+func (CeremonyProgress_Phase) EnumDescriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type BlameNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PubKey         string `protobuf:"bytes,1,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+	BlameData      []byte `protobuf:"bytes,2,opt,name=BlameData,proto3" json:"BlameData,omitempty"`
+	BlameSignature []byte `protobuf:"bytes,3,opt,name=BlameSignature,proto3" json:"BlameSignature,omitempty"`
+}
+
+func (x *BlameNode) Reset() {
+	*x = BlameNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlameNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlameNode) ProtoMessage() {}
+
+func (x *BlameNode) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlameNode.ProtoReflect.Descriptor instead.
+func (*BlameNode) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BlameNode) GetPubKey() string {
+	if x != nil {
+		return x.PubKey
+	}
+	return ""
+}
+
+func (x *BlameNode) GetBlameData() []byte {
+	if x != nil {
+		return x.BlameData
+	}
+	return nil
+}
+
+func (x *BlameNode) GetBlameSignature() []byte {
+	if x != nil {
+		return x.BlameSignature
+	}
+	return nil
+}
+
+type Blame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FailReason string       `protobuf:"bytes,1,opt,name=FailReason,proto3" json:"FailReason,omitempty"`
+	IsUnicast  bool         `protobuf:"varint,2,opt,name=IsUnicast,proto3" json:"IsUnicast,omitempty"`
+	Round      string       `protobuf:"bytes,3,opt,name=Round,proto3" json:"Round,omitempty"`
+	BlameNodes []*BlameNode `protobuf:"bytes,4,rep,name=BlameNodes,proto3" json:"BlameNodes,omitempty"`
+}
+
+func (x *Blame) Reset() {
+	*x = Blame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Blame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Blame) ProtoMessage() {}
+
+func (x *Blame) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Blame.ProtoReflect.Descriptor instead.
+func (*Blame) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Blame) GetFailReason() string {
+	if x != nil {
+		return x.FailReason
+	}
+	return ""
+}
+
+func (x *Blame) GetIsUnicast() bool {
+	if x != nil {
+		return x.IsUnicast
+	}
+	return false
+}
+
+func (x *Blame) GetRound() string {
+	if x != nil {
+		return x.Round
+	}
+	return ""
+}
+
+func (x *Blame) GetBlameNodes() []*BlameNode {
+	if x != nil {
+		return x.BlameNodes
+	}
+	return nil
+}
+
+type CeremonyProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CeremonyPhase CeremonyProgress_Phase `protobuf:"varint,1,opt,name=CeremonyPhase,proto3,enum=messages.CeremonyProgress_Phase" json:"CeremonyPhase,omitempty"`
+	Round         int32                  `protobuf:"varint,2,opt,name=Round,proto3" json:"Round,omitempty"`   // only set in PhaseRound
+	Rounds        int32                  `protobuf:"varint,3,opt,name=Rounds,proto3" json:"Rounds,omitempty"` // only set in PhaseRound
+}
+
+func (x *CeremonyProgress) Reset() {
+	*x = CeremonyProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CeremonyProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CeremonyProgress) ProtoMessage() {}
+
+func (x *CeremonyProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CeremonyProgress.ProtoReflect.Descriptor instead.
+func (*CeremonyProgress) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CeremonyProgress) GetCeremonyPhase() CeremonyProgress_Phase {
+	if x != nil {
+		return x.CeremonyPhase
+	}
+	return CeremonyProgress_PhaseUnknown
+}
+
+func (x *CeremonyProgress) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *CeremonyProgress) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+type KeygenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys        []string `protobuf:"bytes,1,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	BlockHeight int64    `protobuf:"varint,2,opt,name=BlockHeight,proto3" json:"BlockHeight,omitempty"`
+	Version     string   `protobuf:"bytes,3,opt,name=Version,proto3" json:"Version,omitempty"`
+	Algo        KeyAlgo  `protobuf:"varint,4,opt,name=Algo,proto3,enum=messages.KeyAlgo" json:"Algo,omitempty"`
+	Threshold   int32    `protobuf:"varint,5,opt,name=Threshold,proto3" json:"Threshold,omitempty"` // the default threshold is used if it is zero
+}
+
+func (x *KeygenRequest) Reset() {
+	*x = KeygenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenRequest) ProtoMessage() {}
+
+func (x *KeygenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenRequest.ProtoReflect.Descriptor instead.
+func (*KeygenRequest) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *KeygenRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *KeygenRequest) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *KeygenRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *KeygenRequest) GetAlgo() KeyAlgo {
+	if x != nil {
+		return x.Algo
+	}
+	return KeyAlgo_UnknownAlgo
+}
+
+func (x *KeygenRequest) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type KeygenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PubKey    string         `protobuf:"bytes,1,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+	Status    CeremonyStatus `protobuf:"varint,2,opt,name=Status,proto3,enum=messages.CeremonyStatus" json:"Status,omitempty"`
+	Blame     *Blame         `protobuf:"bytes,3,opt,name=Blame,proto3" json:"Blame,omitempty"`
+	Threshold int32          `protobuf:"varint,4,opt,name=Threshold,proto3" json:"Threshold,omitempty"`
+}
+
+func (x *KeygenResponse) Reset() {
+	*x = KeygenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenResponse) ProtoMessage() {}
+
+func (x *KeygenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenResponse.ProtoReflect.Descriptor instead.
+func (*KeygenResponse) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *KeygenResponse) GetPubKey() string {
+	if x != nil {
+		return x.PubKey
+	}
+	return ""
+}
+
+func (x *KeygenResponse) GetStatus() CeremonyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CeremonyStatus_NA
+}
+
+func (x *KeygenResponse) GetBlame() *Blame {
+	if x != nil {
+		return x.Blame
+	}
+	return nil
+}
+
+func (x *KeygenResponse) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type KeygenUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Update:
+	//	*KeygenUpdate_Progress
+	//	*KeygenUpdate_Response
+	Update isKeygenUpdate_Update `protobuf_oneof:"Update"`
+}
+
+func (x *KeygenUpdate) Reset() {
+	*x = KeygenUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeygenUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeygenUpdate) ProtoMessage() {}
+
+func (x *KeygenUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeygenUpdate.ProtoReflect.Descriptor instead.
+func (*KeygenUpdate) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *KeygenUpdate) GetUpdate() isKeygenUpdate_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (x *KeygenUpdate) GetProgress() *CeremonyProgress {
+	if x, ok := x.GetUpdate().(*KeygenUpdate_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *KeygenUpdate) GetResponse() *KeygenResponse {
+	if x, ok := x.GetUpdate().(*KeygenUpdate_Response); ok {
+		return x.Response
+	}
+	return nil
+}
+
+type isKeygenUpdate_Update interface {
+	isKeygenUpdate_Update()
+}
+
+type KeygenUpdate_Progress struct {
+	Progress *CeremonyProgress `protobuf:"bytes,1,opt,name=Progress,proto3,oneof"`
+}
+
+type KeygenUpdate_Response struct {
+	Response *KeygenResponse `protobuf:"bytes,2,opt,name=Response,proto3,oneof"`
+}
+
+func (*KeygenUpdate_Progress) isKeygenUpdate_Update() {}
+
+func (*KeygenUpdate_Response) isKeygenUpdate_Update() {}
+
+type KeysignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PoolPubKey     string   `protobuf:"bytes,1,opt,name=PoolPubKey,proto3" json:"PoolPubKey,omitempty"`
+	Messages       [][]byte `protobuf:"bytes,2,rep,name=Messages,proto3" json:"Messages,omitempty"` // the raw messages to be signed
+	SignerPubKeys  []string `protobuf:"bytes,3,rep,name=SignerPubKeys,proto3" json:"SignerPubKeys,omitempty"`
+	BlockHeight    int64    `protobuf:"varint,4,opt,name=BlockHeight,proto3" json:"BlockHeight,omitempty"`
+	Version        string   `protobuf:"bytes,5,opt,name=Version,proto3" json:"Version,omitempty"`
+	Algo           KeyAlgo  `protobuf:"varint,6,opt,name=Algo,proto3,enum=messages.KeyAlgo" json:"Algo,omitempty"`
+	DerivationPath string   `protobuf:"bytes,7,opt,name=DerivationPath,proto3" json:"DerivationPath,omitempty"` // the BIP-32 path of the child key to sign with, the pool key signs if it is empty
+	HashMode       string   `protobuf:"bytes,9,opt,name=HashMode,proto3" json:"HashMode,omitempty"`             // how the messages become the digests which are signed, e.g. sha256d or keccak256
+	Encoding       string   `protobuf:"bytes,10,opt,name=Encoding,proto3" json:"Encoding,omitempty"`            // how the signatures are encoded in Encoded, e.g. der, compact, compact65, rsv or cosmos
+	ChainID        uint64   `protobuf:"varint,11,opt,name=ChainID,proto3" json:"ChainID,omitempty"`             // the EIP-155 chain id of the rsv encoding
+}
+
+func (x *KeysignRequest) Reset() {
+	*x = KeysignRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeysignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeysignRequest) ProtoMessage() {}
+
+func (x *KeysignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeysignRequest.ProtoReflect.Descriptor instead.
+func (*KeysignRequest) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *KeysignRequest) GetPoolPubKey() string {
+	if x != nil {
+		return x.PoolPubKey
+	}
+	return ""
+}
+
+func (x *KeysignRequest) GetMessages() [][]byte {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *KeysignRequest) GetSignerPubKeys() []string {
+	if x != nil {
+		return x.SignerPubKeys
+	}
+	return nil
+}
+
+func (x *KeysignRequest) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *KeysignRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *KeysignRequest) GetAlgo() KeyAlgo {
+	if x != nil {
+		return x.Algo
+	}
+	return KeyAlgo_UnknownAlgo
+}
+
+func (x *KeysignRequest) GetDerivationPath() string {
+	if x != nil {
+		return x.DerivationPath
+	}
+	return ""
+}
+
+func (x *KeysignRequest) GetHashMode() string {
+	if x != nil {
+		return x.HashMode
+	}
+	return ""
+}
+
+func (x *KeysignRequest) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+func (x *KeysignRequest) GetChainID() uint64 {
+	if x != nil {
+		return x.ChainID
+	}
+	return 0
+}
+
+type Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msg        []byte `protobuf:"bytes,1,opt,name=Msg,proto3" json:"Msg,omitempty"`
+	R          []byte `protobuf:"bytes,2,opt,name=R,proto3" json:"R,omitempty"`
+	S          []byte `protobuf:"bytes,3,opt,name=S,proto3" json:"S,omitempty"`
+	RecoveryID []byte `protobuf:"bytes,4,opt,name=RecoveryID,proto3" json:"RecoveryID,omitempty"`
+	Signature  []byte `protobuf:"bytes,5,opt,name=Signature,proto3" json:"Signature,omitempty"`
+	Encoded    []byte `protobuf:"bytes,6,opt,name=Encoded,proto3" json:"Encoded,omitempty"` // the signature in the encoding of the request
+}
+
+func (x *Signature) Reset() {
+	*x = Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Signature) ProtoMessage() {}
+
+func (x *Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
+func (*Signature) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Signature) GetMsg() []byte {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *Signature) GetR() []byte {
+	if x != nil {
+		return x.R
+	}
+	return nil
+}
+
+func (x *Signature) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+func (x *Signature) GetRecoveryID() []byte {
+	if x != nil {
+		return x.RecoveryID
+	}
+	return nil
+}
+
+func (x *Signature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *Signature) GetEncoded() []byte {
+	if x != nil {
+		return x.Encoded
+	}
+	return nil
+}
+
+type KeysignResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signatures []*Signature   `protobuf:"bytes,1,rep,name=Signatures,proto3" json:"Signatures,omitempty"`
+	Status     CeremonyStatus `protobuf:"varint,2,opt,name=Status,proto3,enum=messages.CeremonyStatus" json:"Status,omitempty"`
+	Blame      *Blame         `protobuf:"bytes,3,opt,name=Blame,proto3" json:"Blame,omitempty"`
+}
+
+func (x *KeysignResponse) Reset() {
+	*x = KeysignResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeysignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeysignResponse) ProtoMessage() {}
+
+func (x *KeysignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeysignResponse.ProtoReflect.Descriptor instead.
+func (*KeysignResponse) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *KeysignResponse) GetSignatures() []*Signature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *KeysignResponse) GetStatus() CeremonyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CeremonyStatus_NA
+}
+
+func (x *KeysignResponse) GetBlame() *Blame {
+	if x != nil {
+		return x.Blame
+	}
+	return nil
+}
+
+type KeysignUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Update:
+	//	*KeysignUpdate_Progress
+	//	*KeysignUpdate_Response
+	Update isKeysignUpdate_Update `protobuf_oneof:"Update"`
+}
+
+func (x *KeysignUpdate) Reset() {
+	*x = KeysignUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeysignUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeysignUpdate) ProtoMessage() {}
+
+func (x *KeysignUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeysignUpdate.ProtoReflect.Descriptor instead.
+func (*KeysignUpdate) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *KeysignUpdate) GetUpdate() isKeysignUpdate_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (x *KeysignUpdate) GetProgress() *CeremonyProgress {
+	if x, ok := x.GetUpdate().(*KeysignUpdate_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *KeysignUpdate) GetResponse() *KeysignResponse {
+	if x, ok := x.GetUpdate().(*KeysignUpdate_Response); ok {
+		return x.Response
+	}
+	return nil
+}
+
+type isKeysignUpdate_Update interface {
+	isKeysignUpdate_Update()
+}
+
+type KeysignUpdate_Progress struct {
+	Progress *CeremonyProgress `protobuf:"bytes,1,opt,name=Progress,proto3,oneof"`
+}
+
+type KeysignUpdate_Response struct {
+	Response *KeysignResponse `protobuf:"bytes,2,opt,name=Response,proto3,oneof"`
+}
+
+func (*KeysignUpdate_Progress) isKeysignUpdate_Update() {}
+
+func (*KeysignUpdate_Response) isKeysignUpdate_Update() {}
+
+type KeyRegroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PoolPubKey   string   `protobuf:"bytes,1,opt,name=PoolPubKey,proto3" json:"PoolPubKey,omitempty"`
+	OldPartyKeys []string `protobuf:"bytes,2,rep,name=OldPartyKeys,proto3" json:"OldPartyKeys,omitempty"`
+	NewPartyKeys []string `protobuf:"bytes,3,rep,name=NewPartyKeys,proto3" json:"NewPartyKeys,omitempty"`
+	BlockHeight  int64    `protobuf:"varint,4,opt,name=BlockHeight,proto3" json:"BlockHeight,omitempty"`
+	Version      string   `protobuf:"bytes,5,opt,name=Version,proto3" json:"Version,omitempty"`
+	Algo         KeyAlgo  `protobuf:"varint,6,opt,name=Algo,proto3,enum=messages.KeyAlgo" json:"Algo,omitempty"`
+	Threshold    int32    `protobuf:"varint,7,opt,name=Threshold,proto3" json:"Threshold,omitempty"`       // the default threshold is used if it is zero
+	OldThreshold int32    `protobuf:"varint,8,opt,name=OldThreshold,proto3" json:"OldThreshold,omitempty"` // the default threshold of the old committee is used if it is zero
+}
+
+func (x *KeyRegroupRequest) Reset() {
+	*x = KeyRegroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRegroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRegroupRequest) ProtoMessage() {}
+
+func (x *KeyRegroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRegroupRequest.ProtoReflect.Descriptor instead.
+func (*KeyRegroupRequest) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *KeyRegroupRequest) GetPoolPubKey() string {
+	if x != nil {
+		return x.PoolPubKey
+	}
+	return ""
+}
+
+func (x *KeyRegroupRequest) GetOldPartyKeys() []string {
+	if x != nil {
+		return x.OldPartyKeys
+	}
+	return nil
+}
+
+func (x *KeyRegroupRequest) GetNewPartyKeys() []string {
+	if x != nil {
+		return x.NewPartyKeys
+	}
+	return nil
+}
+
+func (x *KeyRegroupRequest) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *KeyRegroupRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *KeyRegroupRequest) GetAlgo() KeyAlgo {
+	if x != nil {
+		return x.Algo
+	}
+	return KeyAlgo_UnknownAlgo
+}
+
+func (x *KeyRegroupRequest) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *KeyRegroupRequest) GetOldThreshold() int32 {
+	if x != nil {
+		return x.OldThreshold
+	}
+	return 0
+}
+
+type KeyRegroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PubKey      string         `protobuf:"bytes,1,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+	PoolAddress string         `protobuf:"bytes,2,opt,name=PoolAddress,proto3" json:"PoolAddress,omitempty"`
+	Status      CeremonyStatus `protobuf:"varint,3,opt,name=Status,proto3,enum=messages.CeremonyStatus" json:"Status,omitempty"`
+	Blame       *Blame         `protobuf:"bytes,4,opt,name=Blame,proto3" json:"Blame,omitempty"`
+	Retirement  string         `protobuf:"bytes,5,opt,name=Retirement,proto3" json:"Retirement,omitempty"`
+}
+
+func (x *KeyRegroupResponse) Reset() {
+	*x = KeyRegroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRegroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRegroupResponse) ProtoMessage() {}
+
+func (x *KeyRegroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRegroupResponse.ProtoReflect.Descriptor instead.
+func (*KeyRegroupResponse) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *KeyRegroupResponse) GetPubKey() string {
+	if x != nil {
+		return x.PubKey
+	}
+	return ""
+}
+
+func (x *KeyRegroupResponse) GetPoolAddress() string {
+	if x != nil {
+		return x.PoolAddress
+	}
+	return ""
+}
+
+func (x *KeyRegroupResponse) GetStatus() CeremonyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CeremonyStatus_NA
+}
+
+func (x *KeyRegroupResponse) GetBlame() *Blame {
+	if x != nil {
+		return x.Blame
+	}
+	return nil
+}
+
+func (x *KeyRegroupResponse) GetRetirement() string {
+	if x != nil {
+		return x.Retirement
+	}
+	return ""
+}
+
+type KeyRegroupUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Update:
+	//	*KeyRegroupUpdate_Progress
+	//	*KeyRegroupUpdate_Response
+	Update isKeyRegroupUpdate_Update `protobuf_oneof:"Update"`
+}
+
+func (x *KeyRegroupUpdate) Reset() {
+	*x = KeyRegroupUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyRegroupUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRegroupUpdate) ProtoMessage() {}
+
+func (x *KeyRegroupUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRegroupUpdate.ProtoReflect.Descriptor instead.
+func (*KeyRegroupUpdate) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (m *KeyRegroupUpdate) GetUpdate() isKeyRegroupUpdate_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (x *KeyRegroupUpdate) GetProgress() *CeremonyProgress {
+	if x, ok := x.GetUpdate().(*KeyRegroupUpdate_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *KeyRegroupUpdate) GetResponse() *KeyRegroupResponse {
+	if x, ok := x.GetUpdate().(*KeyRegroupUpdate_Response); ok {
+		return x.Response
+	}
+	return nil
+}
+
+type isKeyRegroupUpdate_Update interface {
+	isKeyRegroupUpdate_Update()
+}
+
+type KeyRegroupUpdate_Progress struct {
+	Progress *CeremonyProgress `protobuf:"bytes,1,opt,name=Progress,proto3,oneof"`
+}
+
+type KeyRegroupUpdate_Response struct {
+	Response *KeyRegroupResponse `protobuf:"bytes,2,opt,name=Response,proto3,oneof"`
+}
+
+func (*KeyRegroupUpdate_Progress) isKeyRegroupUpdate_Update() {}
+
+func (*KeyRegroupUpdate_Response) isKeyRegroupUpdate_Update() {}
+
+type GetP2PIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetP2PIDRequest) Reset() {
+	*x = GetP2PIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetP2PIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetP2PIDRequest) ProtoMessage() {}
+
+func (x *GetP2PIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetP2PIDRequest.ProtoReflect.Descriptor instead.
+func (*GetP2PIDRequest) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{13}
+}
+
+type GetP2PIDResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+}
+
+func (x *GetP2PIDResponse) Reset() {
+	*x = GetP2PIDResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetP2PIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetP2PIDResponse) ProtoMessage() {}
+
+func (x *GetP2PIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetP2PIDResponse.ProtoReflect.Descriptor instead.
+func (*GetP2PIDResponse) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetP2PIDResponse) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+type ListPoolsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPoolsRequest) Reset() {
+	*x = ListPoolsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPoolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoolsRequest) ProtoMessage() {}
+
+func (x *ListPoolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoolsRequest.ProtoReflect.Descriptor instead.
+func (*ListPoolsRequest) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{15}
+}
+
+type PoolInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PubKey          string   `protobuf:"bytes,1,opt,name=PubKey,proto3" json:"PubKey,omitempty"`
+	Algo            KeyAlgo  `protobuf:"varint,2,opt,name=Algo,proto3,enum=messages.KeyAlgo" json:"Algo,omitempty"`
+	ParticipantKeys []string `protobuf:"bytes,3,rep,name=ParticipantKeys,proto3" json:"ParticipantKeys,omitempty"`
+	LocalPartyKey   string   `protobuf:"bytes,4,opt,name=LocalPartyKey,proto3" json:"LocalPartyKey,omitempty"`
+	Threshold       int32    `protobuf:"varint,5,opt,name=Threshold,proto3" json:"Threshold,omitempty"`
+}
+
+func (x *PoolInfo) Reset() {
+	*x = PoolInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolInfo) ProtoMessage() {}
+
+func (x *PoolInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolInfo.ProtoReflect.Descriptor instead.
+func (*PoolInfo) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PoolInfo) GetPubKey() string {
+	if x != nil {
+		return x.PubKey
+	}
+	return ""
+}
+
+func (x *PoolInfo) GetAlgo() KeyAlgo {
+	if x != nil {
+		return x.Algo
+	}
+	return KeyAlgo_UnknownAlgo
+}
+
+func (x *PoolInfo) GetParticipantKeys() []string {
+	if x != nil {
+		return x.ParticipantKeys
+	}
+	return nil
+}
+
+func (x *PoolInfo) GetLocalPartyKey() string {
+	if x != nil {
+		return x.LocalPartyKey
+	}
+	return ""
+}
+
+func (x *PoolInfo) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type ListPoolsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pools []*PoolInfo `protobuf:"bytes,1,rep,name=Pools,proto3" json:"Pools,omitempty"`
+}
+
+func (x *ListPoolsResponse) Reset() {
+	*x = ListPoolsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tss_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPoolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoolsResponse) ProtoMessage() {}
+
+func (x *ListPoolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tss_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoolsResponse.ProtoReflect.Descriptor instead.
+func (*ListPoolsResponse) Descriptor() ([]byte, []int) {
+	return file_tss_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPoolsResponse) GetPools() []*PoolInfo {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+var File_tss_service_proto protoreflect.FileDescriptor
+
+var file_tss_service_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x74, 0x73, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x69, 0x0a,
+	0x09, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x75,
+	0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x44, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x26, 0x0a, 0x0e, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x90, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x61,
+	0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x46, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x73, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x49, 0x73, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x33, 0x0a, 0x0a, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52,
+	0x0a, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0xd8, 0x01, 0x0a, 0x10,
+	0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x46, 0x0a, 0x0d, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x50, 0x68, 0x61, 0x73,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x0d, 0x43, 0x65, 0x72, 0x65, 0x6d,
+	0x6f, 0x6e, 0x79, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x4e, 0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12,
+	0x10, 0x0a, 0x0c, 0x50, 0x68, 0x61, 0x73, 0x65, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10,
+	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x68, 0x61, 0x73, 0x65, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64,
+	0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x4a, 0x6f, 0x69, 0x6e, 0x50,
+	0x61, 0x72, 0x74, 0x79, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x10, 0x03, 0x22, 0xa4, 0x01, 0x0a, 0x0d, 0x4b, 0x65, 0x79, 0x67, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4b, 0x65, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x41, 0x6c, 0x67, 0x6f,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x4b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x52, 0x04, 0x41, 0x6c, 0x67, 0x6f, 0x12,
+	0x1c, 0x0a, 0x09, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0x9f, 0x01,
+	0x0a, 0x0e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x42, 0x6c,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x42, 0x6c, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22,
+	0x8a, 0x01, 0x0a, 0x0c, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x38, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x65,
+	0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00,
+	0x52, 0x08, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0xd5, 0x02, 0x0a,
+	0x0e, 0x4b, 0x65, 0x79, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x08, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a,
+	0x04, 0x41, 0x6c, 0x67, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x52, 0x04,
+	0x41, 0x6c, 0x67, 0x6f, 0x12, 0x26, 0x0a, 0x0e, 0x44, 0x65, 0x72, 0x69, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x44, 0x65,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08,
+	0x48, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x48, 0x61, 0x73, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x45, 0x6e, 0x63, 0x6f,
+	0x64, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x45, 0x6e, 0x63, 0x6f,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x44, 0x4a, 0x04,
+	0x08, 0x08, 0x10, 0x09, 0x22, 0x91, 0x01, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x4d, 0x73, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x4d, 0x73, 0x67, 0x12, 0x0c, 0x0a, 0x01, 0x52, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x01, 0x52, 0x12, 0x0c, 0x0a, 0x01, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x53,
+	0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x49, 0x44, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x49, 0x44,
+	0x12, 0x1c, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x22, 0x9f, 0x01, 0x0a, 0x0f, 0x4b, 0x65, 0x79,
+	0x73, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0a,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0a, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x30, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x18, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x65, 0x72,
+	0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x6c,
+	0x61, 0x6d, 0x65, 0x52, 0x05, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x0d, 0x4b,
+	0x65, 0x79, 0x73, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x08,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f,
+	0x6e, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x08, 0x50, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x37, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x08, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0xa0, 0x02, 0x0a, 0x11, 0x4b, 0x65,
+	0x79, 0x52, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12,
+	0x22, 0x0a, 0x0c, 0x4f, 0x6c, 0x64, 0x50, 0x61, 0x72, 0x74, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x4f, 0x6c, 0x64, 0x50, 0x61, 0x72, 0x74, 0x79, 0x4b,
+	0x65, 0x79, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x4e, 0x65, 0x77, 0x50, 0x61, 0x72, 0x74, 0x79, 0x4b,
+	0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x4e, 0x65, 0x77, 0x50, 0x61,
+	0x72, 0x74, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x41, 0x6c, 0x67, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x11, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79,
+	0x41, 0x6c, 0x67, 0x6f, 0x52, 0x04, 0x41, 0x6c, 0x67, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x4f, 0x6c, 0x64, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x4f, 0x6c, 0x64, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0xc7, 0x01, 0x0a,
+	0x12, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x50,
+	0x6f, 0x6f, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x50, 0x6f, 0x6f, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x30, 0x0a,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e,
+	0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x25, 0x0a, 0x05, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x52,
+	0x05, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x52, 0x65, 0x74, 0x69,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x92, 0x01, 0x0a, 0x10, 0x4b, 0x65, 0x79, 0x52, 0x65,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e,
+	0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x08, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3a, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x08, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x50, 0x32, 0x50, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x22,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x50, 0x32, 0x50, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x49, 0x44, 0x22, 0x12, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb7, 0x01, 0x0a, 0x08, 0x50, 0x6f, 0x6f, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x25, 0x0a, 0x04, 0x41,
+	0x6c, 0x67, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x52, 0x04, 0x41, 0x6c,
+	0x67, 0x6f, 0x12, 0x28, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e,
+	0x74, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x74, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x50, 0x61, 0x72, 0x74, 0x79, 0x4b,
+	0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x22, 0x3d, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x2a,
+	0x3d, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x41, 0x6c, 0x67, 0x6f, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x41, 0x6c, 0x67, 0x6f, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45,
+	0x43, 0x44, 0x53, 0x41, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x44, 0x44, 0x53, 0x41, 0x10,
+	0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x43, 0x48, 0x4e, 0x4f, 0x52, 0x52, 0x10, 0x03, 0x2a, 0x2f,
+	0x0a, 0x0e, 0x43, 0x65, 0x72, 0x65, 0x6d, 0x6f, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x06, 0x0a, 0x02, 0x4e, 0x41, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x61, 0x69, 0x6c, 0x10, 0x02, 0x32,
+	0xdb, 0x02, 0x0a, 0x0a, 0x54, 0x73, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3b,
+	0x0a, 0x06, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x12, 0x17, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79,
+	0x67, 0x65, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x07, 0x4b,
+	0x65, 0x79, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x18, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x73,
+	0x69, 0x67, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x0a, 0x4b,
+	0x65, 0x79, 0x52, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x50, 0x32, 0x50, 0x49, 0x44,
+	0x12, 0x19, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x32, 0x50, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x32, 0x50, 0x49, 0x44, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x1a, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2b, 0x5a,
+	0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x48, 0x79, 0x70, 0x65,
+	0x72, 0x43, 0x6f, 0x72, 0x65, 0x2d, 0x54, 0x65, 0x61, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x74, 0x73,
+	0x73, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_tss_service_proto_rawDescOnce sync.Once
+	file_tss_service_proto_rawDescData = file_tss_service_proto_rawDesc
+)
+
+func file_tss_service_proto_rawDescGZIP() []byte {
+	file_tss_service_proto_rawDescOnce.Do(func() {
+		file_tss_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_tss_service_proto_rawDescData)
+	})
+	return file_tss_service_proto_rawDescData
+}
+
+var file_tss_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_tss_service_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_tss_service_proto_goTypes = []interface{}{
+	(KeyAlgo)(0),                // 0: messages.KeyAlgo
+	(CeremonyStatus)(0),         // 1: messages.CeremonyStatus
+	(CeremonyProgress_Phase)(0), // 2: messages.CeremonyProgress.Phase
+	(*BlameNode)(nil),           // 3: messages.BlameNode
+	(*Blame)(nil),               // 4: messages.Blame
+	(*CeremonyProgress)(nil),    // 5: messages.CeremonyProgress
+	(*KeygenRequest)(nil),       // 6: messages.KeygenRequest
+	(*KeygenResponse)(nil),      // 7: messages.KeygenResponse
+	(*KeygenUpdate)(nil),        // 8: messages.KeygenUpdate
+	(*KeysignRequest)(nil),      // 9: messages.KeysignRequest
+	(*Signature)(nil),           // 10: messages.Signature
+	(*KeysignResponse)(nil),     // 11: messages.KeysignResponse
+	(*KeysignUpdate)(nil),       // 12: messages.KeysignUpdate
+	(*KeyRegroupRequest)(nil),   // 13: messages.KeyRegroupRequest
+	(*KeyRegroupResponse)(nil),  // 14: messages.KeyRegroupResponse
+	(*KeyRegroupUpdate)(nil),    // 15: messages.KeyRegroupUpdate
+	(*GetP2PIDRequest)(nil),     // 16: messages.GetP2PIDRequest
+	(*GetP2PIDResponse)(nil),    // 17: messages.GetP2PIDResponse
+	(*ListPoolsRequest)(nil),    // 18: messages.ListPoolsRequest
+	(*PoolInfo)(nil),            // 19: messages.PoolInfo
+	(*ListPoolsResponse)(nil),   // 20: messages.ListPoolsResponse
+}
+var file_tss_service_proto_depIdxs = []int32{
+	3,  // 0: messages.Blame.BlameNodes:type_name -> messages.BlameNode
+	2,  // 1: messages.CeremonyProgress.CeremonyPhase:type_name -> messages.CeremonyProgress.Phase
+	0,  // 2: messages.KeygenRequest.Algo:type_name -> messages.KeyAlgo
+	1,  // 3: messages.KeygenResponse.Status:type_name -> messages.CeremonyStatus
+	4,  // 4: messages.KeygenResponse.Blame:type_name -> messages.Blame
+	5,  // 5: messages.KeygenUpdate.Progress:type_name -> messages.CeremonyProgress
+	7,  // 6: messages.KeygenUpdate.Response:type_name -> messages.KeygenResponse
+	0,  // 7: messages.KeysignRequest.Algo:type_name -> messages.KeyAlgo
+	10, // 8: messages.KeysignResponse.Signatures:type_name -> messages.Signature
+	1,  // 9: messages.KeysignResponse.Status:type_name -> messages.CeremonyStatus
+	4,  // 10: messages.KeysignResponse.Blame:type_name -> messages.Blame
+	5,  // 11: messages.KeysignUpdate.Progress:type_name -> messages.CeremonyProgress
+	11, // 12: messages.KeysignUpdate.Response:type_name -> messages.KeysignResponse
+	0,  // 13: messages.KeyRegroupRequest.Algo:type_name -> messages.KeyAlgo
+	1,  // 14: messages.KeyRegroupResponse.Status:type_name -> messages.CeremonyStatus
+	4,  // 15: messages.KeyRegroupResponse.Blame:type_name -> messages.Blame
+	5,  // 16: messages.KeyRegroupUpdate.Progress:type_name -> messages.CeremonyProgress
+	14, // 17: messages.KeyRegroupUpdate.Response:type_name -> messages.KeyRegroupResponse
+	0,  // 18: messages.PoolInfo.Algo:type_name -> messages.KeyAlgo
+	19, // 19: messages.ListPoolsResponse.Pools:type_name -> messages.PoolInfo
+	6,  // 20: messages.TssService.Keygen:input_type -> messages.KeygenRequest
+	9,  // 21: messages.TssService.Keysign:input_type -> messages.KeysignRequest
+	13, // 22: messages.TssService.KeyRegroup:input_type -> messages.KeyRegroupRequest
+	16, // 23: messages.TssService.GetP2PID:input_type -> messages.GetP2PIDRequest
+	18, // 24: messages.TssService.ListPools:input_type -> messages.ListPoolsRequest
+	8,  // 25: messages.TssService.Keygen:output_type -> messages.KeygenUpdate
+	12, // 26: messages.TssService.Keysign:output_type -> messages.KeysignUpdate
+	15, // 27: messages.TssService.KeyRegroup:output_type -> messages.KeyRegroupUpdate
+	17, // 28: messages.TssService.GetP2PID:output_type -> messages.GetP2PIDResponse
+	20, // 29: messages.TssService.ListPools:output_type -> messages.ListPoolsResponse
+	25, // [25:30] is the sub-list for method output_type
+	20, // [20:25] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_tss_service_proto_init() }
+func file_tss_service_proto_init() {
+	if File_tss_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tss_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlameNode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Blame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CeremonyProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeygenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeygenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeygenUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeysignRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeysignResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeysignUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRegroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRegroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRegroupUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetP2PIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetP2PIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPoolsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tss_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPoolsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_tss_service_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*KeygenUpdate_Progress)(nil),
+		(*KeygenUpdate_Response)(nil),
+	}
+	file_tss_service_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*KeysignUpdate_Progress)(nil),
+		(*KeysignUpdate_Response)(nil),
+	}
+	file_tss_service_proto_msgTypes[12].OneofWrappers = []interface{}{
+		(*KeyRegroupUpdate_Progress)(nil),
+		(*KeyRegroupUpdate_Response)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tss_service_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tss_service_proto_goTypes,
+		DependencyIndexes: file_tss_service_proto_depIdxs,
+		EnumInfos:         file_tss_service_proto_enumTypes,
+		MessageInfos:      file_tss_service_proto_msgTypes,
+	}.Build()
+	File_tss_service_proto = out.File
+	file_tss_service_proto_rawDesc = nil
+	file_tss_service_proto_goTypes = nil
+	file_tss_service_proto_depIdxs = nil
+}
@@ -0,0 +1,345 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.0
+// source: tss_service.proto
+
+package messages
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	TssService_Keygen_FullMethodName     = "/messages.TssService/Keygen"
+	TssService_Keysign_FullMethodName    = "/messages.TssService/Keysign"
+	TssService_KeyRegroup_FullMethodName = "/messages.TssService/KeyRegroup"
+	TssService_GetP2PID_FullMethodName   = "/messages.TssService/GetP2PID"
+	TssService_ListPools_FullMethodName  = "/messages.TssService/ListPools"
+)
+
+// TssServiceClient is the client API for TssService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TssServiceClient interface {
+	// Keygen streams the progress of the keygen, the last update is the response
+	Keygen(ctx context.Context, in *KeygenRequest, opts ...grpc.CallOption) (TssService_KeygenClient, error)
+	// Keysign streams the progress of the keysign, the last update is the response
+	Keysign(ctx context.Context, in *KeysignRequest, opts ...grpc.CallOption) (TssService_KeysignClient, error)
+	// KeyRegroup streams the progress of the regroup, the last update is the response
+	KeyRegroup(ctx context.Context, in *KeyRegroupRequest, opts ...grpc.CallOption) (TssService_KeyRegroupClient, error)
+	GetP2PID(ctx context.Context, in *GetP2PIDRequest, opts ...grpc.CallOption) (*GetP2PIDResponse, error)
+	ListPools(ctx context.Context, in *ListPoolsRequest, opts ...grpc.CallOption) (*ListPoolsResponse, error)
+}
+
+type tssServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTssServiceClient(cc grpc.ClientConnInterface) TssServiceClient {
+	return &tssServiceClient{cc}
+}
+
+func (c *tssServiceClient) Keygen(ctx context.Context, in *KeygenRequest, opts ...grpc.CallOption) (TssService_KeygenClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TssService_ServiceDesc.Streams[0], TssService_Keygen_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tssServiceKeygenClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TssService_KeygenClient interface {
+	Recv() (*KeygenUpdate, error)
+	grpc.ClientStream
+}
+
+type tssServiceKeygenClient struct {
+	grpc.ClientStream
+}
+
+func (x *tssServiceKeygenClient) Recv() (*KeygenUpdate, error) {
+	m := new(KeygenUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tssServiceClient) Keysign(ctx context.Context, in *KeysignRequest, opts ...grpc.CallOption) (TssService_KeysignClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TssService_ServiceDesc.Streams[1], TssService_Keysign_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tssServiceKeysignClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TssService_KeysignClient interface {
+	Recv() (*KeysignUpdate, error)
+	grpc.ClientStream
+}
+
+type tssServiceKeysignClient struct {
+	grpc.ClientStream
+}
+
+func (x *tssServiceKeysignClient) Recv() (*KeysignUpdate, error) {
+	m := new(KeysignUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tssServiceClient) KeyRegroup(ctx context.Context, in *KeyRegroupRequest, opts ...grpc.CallOption) (TssService_KeyRegroupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TssService_ServiceDesc.Streams[2], TssService_KeyRegroup_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tssServiceKeyRegroupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TssService_KeyRegroupClient interface {
+	Recv() (*KeyRegroupUpdate, error)
+	grpc.ClientStream
+}
+
+type tssServiceKeyRegroupClient struct {
+	grpc.ClientStream
+}
+
+func (x *tssServiceKeyRegroupClient) Recv() (*KeyRegroupUpdate, error) {
+	m := new(KeyRegroupUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tssServiceClient) GetP2PID(ctx context.Context, in *GetP2PIDRequest, opts ...grpc.CallOption) (*GetP2PIDResponse, error) {
+	out := new(GetP2PIDResponse)
+	err := c.cc.Invoke(ctx, TssService_GetP2PID_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tssServiceClient) ListPools(ctx context.Context, in *ListPoolsRequest, opts ...grpc.CallOption) (*ListPoolsResponse, error) {
+	out := new(ListPoolsResponse)
+	err := c.cc.Invoke(ctx, TssService_ListPools_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TssServiceServer is the server API for TssService service.
+// All implementations must embed UnimplementedTssServiceServer
+// for forward compatibility
+type TssServiceServer interface {
+	// Keygen streams the progress of the keygen, the last update is the response
+	Keygen(*KeygenRequest, TssService_KeygenServer) error
+	// Keysign streams the progress of the keysign, the last update is the response
+	Keysign(*KeysignRequest, TssService_KeysignServer) error
+	// KeyRegroup streams the progress of the regroup, the last update is the response
+	KeyRegroup(*KeyRegroupRequest, TssService_KeyRegroupServer) error
+	GetP2PID(context.Context, *GetP2PIDRequest) (*GetP2PIDResponse, error)
+	ListPools(context.Context, *ListPoolsRequest) (*ListPoolsResponse, error)
+	mustEmbedUnimplementedTssServiceServer()
+}
+
+// UnimplementedTssServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTssServiceServer struct {
+}
+
+func (UnimplementedTssServiceServer) Keygen(*KeygenRequest, TssService_KeygenServer) error {
+	return status.Errorf(codes.Unimplemented, "method Keygen not implemented")
+}
+func (UnimplementedTssServiceServer) Keysign(*KeysignRequest, TssService_KeysignServer) error {
+	return status.Errorf(codes.Unimplemented, "method Keysign not implemented")
+}
+func (UnimplementedTssServiceServer) KeyRegroup(*KeyRegroupRequest, TssService_KeyRegroupServer) error {
+	return status.Errorf(codes.Unimplemented, "method KeyRegroup not implemented")
+}
+func (UnimplementedTssServiceServer) GetP2PID(context.Context, *GetP2PIDRequest) (*GetP2PIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetP2PID not implemented")
+}
+func (UnimplementedTssServiceServer) ListPools(context.Context, *ListPoolsRequest) (*ListPoolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPools not implemented")
+}
+func (UnimplementedTssServiceServer) mustEmbedUnimplementedTssServiceServer() {}
+
+// UnsafeTssServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TssServiceServer will
+// result in compilation errors.
+type UnsafeTssServiceServer interface {
+	mustEmbedUnimplementedTssServiceServer()
+}
+
+func RegisterTssServiceServer(s grpc.ServiceRegistrar, srv TssServiceServer) {
+	s.RegisterService(&TssService_ServiceDesc, srv)
+}
+
+func _TssService_Keygen_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(KeygenRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TssServiceServer).Keygen(m, &tssServiceKeygenServer{stream})
+}
+
+type TssService_KeygenServer interface {
+	Send(*KeygenUpdate) error
+	grpc.ServerStream
+}
+
+type tssServiceKeygenServer struct {
+	grpc.ServerStream
+}
+
+func (x *tssServiceKeygenServer) Send(m *KeygenUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TssService_Keysign_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(KeysignRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TssServiceServer).Keysign(m, &tssServiceKeysignServer{stream})
+}
+
+type TssService_KeysignServer interface {
+	Send(*KeysignUpdate) error
+	grpc.ServerStream
+}
+
+type tssServiceKeysignServer struct {
+	grpc.ServerStream
+}
+
+func (x *tssServiceKeysignServer) Send(m *KeysignUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TssService_KeyRegroup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(KeyRegroupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TssServiceServer).KeyRegroup(m, &tssServiceKeyRegroupServer{stream})
+}
+
+type TssService_KeyRegroupServer interface {
+	Send(*KeyRegroupUpdate) error
+	grpc.ServerStream
+}
+
+type tssServiceKeyRegroupServer struct {
+	grpc.ServerStream
+}
+
+func (x *tssServiceKeyRegroupServer) Send(m *KeyRegroupUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TssService_GetP2PID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetP2PIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).GetP2PID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TssService_GetP2PID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).GetP2PID(ctx, req.(*GetP2PIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TssService_ListPools_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TssServiceServer).ListPools(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TssService_ListPools_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TssServiceServer).ListPools(ctx, req.(*ListPoolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TssService_ServiceDesc is the grpc.ServiceDesc for TssService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TssService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "messages.TssService",
+	HandlerType: (*TssServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetP2PID",
+			Handler:    _TssService_GetP2PID_Handler,
+		},
+		{
+			MethodName: "ListPools",
+			Handler:    _TssService_ListPools_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Keygen",
+			Handler:       _TssService_Keygen_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Keysign",
+			Handler:       _TssService_Keysign_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "KeyRegroup",
+			Handler:       _TssService_KeyRegroup_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tss_service.proto",
+}
@@ -13,10 +13,16 @@ type Metric struct {
 	keysignCounter   *prometheus.CounterVec
 	joinPartyCounter *prometheus.CounterVec
 	keyregroupCounter *prometheus.CounterVec
+	refreshCounter   *prometheus.CounterVec
 	keySignTime      prometheus.Gauge
 	keyGenTime       prometheus.Gauge
 	resharingTime prometheus.Gauge
+	refreshTime      prometheus.Gauge
 	joinPartyTime    *prometheus.GaugeVec
+	preParamsCounter *prometheus.CounterVec
+	preParamsTime    prometheus.Gauge
+	preParamsDepth   prometheus.Gauge
+	keysignRejected  *prometheus.CounterVec
 	logger           zerolog.Logger
 }
 
@@ -38,6 +44,15 @@ func (m *Metric) UpdateKeyRegroup(resharingTime time.Duration, success bool) {
 	}
 }
 
+func (m *Metric) UpdateRefresh(refreshTime time.Duration, success bool) {
+	if success {
+		m.refreshTime.Set(float64(refreshTime))
+		m.refreshCounter.WithLabelValues("success").Inc()
+	} else {
+		m.refreshCounter.WithLabelValues("failure").Inc()
+	}
+}
+
 func (m *Metric) UpdateKeySign(keysignTime time.Duration, success bool) {
 	if success {
 		m.keySignTime.Set(float64(keysignTime))
@@ -65,6 +80,15 @@ func (m Metric) KeyRegroupJoinParty(joinpartyTime time.Duration, success bool) {
 	}
 }
 
+func (m Metric) RefreshJoinParty(joinpartyTime time.Duration, success bool) {
+	if success {
+		m.joinPartyTime.WithLabelValues("refresh").Set(float64(joinpartyTime))
+		m.joinPartyCounter.WithLabelValues("refresh", "success").Inc()
+	} else {
+		m.joinPartyCounter.WithLabelValues("refresh", "failure").Inc()
+	}
+}
+
 func (m *Metric) KeysignJoinParty(joinpartyTime time.Duration, success bool) {
 	if success {
 		m.joinPartyTime.WithLabelValues("keysign").Set(float64(joinpartyTime))
@@ -74,6 +98,24 @@ func (m *Metric) KeysignJoinParty(joinpartyTime time.Duration, success bool) {
 	}
 }
 
+func (m *Metric) UpdatePreParamsGeneration(generationTime time.Duration, success bool) {
+	if success {
+		m.preParamsTime.Set(float64(generationTime))
+		m.preParamsCounter.WithLabelValues("success").Inc()
+	} else {
+		m.preParamsCounter.WithLabelValues("failure").Inc()
+	}
+}
+
+func (m *Metric) UpdatePreParamsDepth(depth int) {
+	m.preParamsDepth.Set(float64(depth))
+}
+
+// KeysignRejected counts the keysign requests rejected by the given signing policy
+func (m *Metric) KeysignRejected(policy string) {
+	m.keysignRejected.WithLabelValues(policy).Inc()
+}
+
 func (m *Metric) Enable() {
 	prometheus.MustRegister(m.keygenCounter)
 	prometheus.MustRegister(m.keysignCounter)
@@ -81,6 +123,12 @@ func (m *Metric) Enable() {
 	prometheus.MustRegister(m.keyGenTime)
 	prometheus.MustRegister(m.keySignTime)
 	prometheus.MustRegister(m.joinPartyTime)
+	prometheus.MustRegister(m.refreshCounter)
+	prometheus.MustRegister(m.refreshTime)
+	prometheus.MustRegister(m.preParamsCounter)
+	prometheus.MustRegister(m.preParamsTime)
+	prometheus.MustRegister(m.preParamsDepth)
+	prometheus.MustRegister(m.keysignRejected)
 }
 
 func NewMetric() *Metric {
@@ -126,6 +174,16 @@ func NewMetric() *Metric {
 			[]string{"status"},
 		),
 
+		refreshCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "Tss",
+				Subsystem: "Tss",
+				Name:      "refresh",
+				Help:      "Tss key share refresh success and failure counter",
+			},
+			[]string{"status"},
+		),
+
 		keyGenTime: prometheus.NewGauge(
 			prometheus.GaugeOpts{
 				Namespace: "Tss",
@@ -153,6 +211,15 @@ func NewMetric() *Metric {
 			},
 		),
 
+		refreshTime: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "Tss",
+				Subsystem: "Tss",
+				Name:      "refresh_time",
+				Help:      "the time spend for the latest key share refresh",
+			},
+		),
+
 		joinPartyTime: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: "Tss",
@@ -161,6 +228,44 @@ func NewMetric() *Metric {
 				Help:      "the time spend for the latest keysign/keygen join party",
 			}, []string{"type"}),
 
+		preParamsCounter: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "Tss",
+				Subsystem: "Tss",
+				Name:      "preparams",
+				Help:      "Tss pre-parameters generation success and failure counter",
+			},
+			[]string{"status"},
+		),
+
+		preParamsTime: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "Tss",
+				Subsystem: "Tss",
+				Name:      "preparams_time",
+				Help:      "the time spend for the latest pre-parameters generation",
+			},
+		),
+
+		preParamsDepth: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "Tss",
+				Subsystem: "Tss",
+				Name:      "preparams_pool_depth",
+				Help:      "the number of pre-parameters ready for the next keygens",
+			},
+		),
+
+		keysignRejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "Tss",
+				Subsystem: "Tss",
+				Name:      "keysign_rejected",
+				Help:      "Tss keysign requests rejected by the signing policy",
+			},
+			[]string{"policy"},
+		),
+
 		logger: log.With().Str("module", "tssMonitor").Logger(),
 	}
 	return &metrics
@@ -363,7 +363,7 @@ func (pc *PartyCoordinator) sendMsgToPeer(msgBuf []byte, msgID string, remotePee
 	return nil
 }
 
-func (pc *PartyCoordinator) joinPartyMember(msgID string, leader string, threshold int, sigChan chan string) ([]peer.ID, error) {
+func (pc *PartyCoordinator) joinPartyMember(ctx context.Context, msgID string, leader string, threshold int, sigChan chan string) ([]peer.ID, error) {
 	peerGroup, err := pc.createJoinPartyGroups(msgID, leader, []string{leader}, threshold)
 	if err != nil {
 		return nil, fmt.Errorf("fail to create join party:%w", err)
@@ -417,10 +417,17 @@ func (pc *PartyCoordinator) joinPartyMember(msgID string, leader string, thresho
 			sigNotify = result
 			close(done)
 			return
+		case <-ctx.Done():
+			close(done)
+			pc.logger.Info().Msg("join party is cancelled")
+			return
 		}
 	}()
 	wg.Wait()
 
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("fail to join party: %w", err)
+	}
 	if peerGroup.getLeaderResponse() == nil {
 		leaderPk, err := conversion.GetPubKeyFromPeerID(leader)
 		if err != nil {
@@ -453,7 +460,7 @@ func (pc *PartyCoordinator) joinPartyMember(msgID string, leader string, thresho
 	return pIDs, ErrJoinPartyTimeout
 }
 
-func (pc *PartyCoordinator) joinPartyLeader(msgID string, peers []string, threshold int, sigChan chan string) ([]peer.ID, error) {
+func (pc *PartyCoordinator) joinPartyLeader(ctx context.Context, msgID string, peers []string, threshold int, sigChan chan string) ([]peer.ID, error) {
 	peerGroup, err := pc.createJoinPartyGroups(msgID, pc.host.ID().String(), peers, threshold)
 	if err != nil {
 		pc.logger.Error().Err(err).Msg("fail to create the join party group")
@@ -484,6 +491,9 @@ func (pc *PartyCoordinator) joinPartyLeader(msgID string, peers []string, thresh
 				return
 			case result := <-sigChan:
 				sigNotify = result
+			case <-ctx.Done():
+				pc.logger.Info().Msg("join party is cancelled")
+				return
 			}
 		}
 	}()
@@ -504,6 +514,12 @@ func (pc *PartyCoordinator) joinPartyLeader(msgID string, peers []string, thresh
 		Type:    messages.JoinPartyLeaderComm_Success,
 		PeerIDs: tssNodes,
 	}
+	if err := ctx.Err(); err != nil {
+		// we notify the failure of the join party to everyone, so they don't wait for us
+		msg.Type = messages.JoinPartyLeaderComm_Timeout
+		pc.sendResponseToAll(&msg, allPeers)
+		return nil, fmt.Errorf("fail to join party: %w", err)
+	}
 	// we put ourselves(leader) in the online list, so need threshold +1
 	if len(onlinePeers) < threshold+1 {
 		// we notify the failure of the join party to everyone
@@ -518,21 +534,31 @@ func (pc *PartyCoordinator) joinPartyLeader(msgID string, peers []string, thresh
 }
 
 func (pc *PartyCoordinator) JoinPartyWithLeader(msgID string, blockHeight int64, peers []string, threshold int, signChan chan string) ([]peer.ID, string, error) {
+	return pc.JoinPartyWithLeaderContext(context.Background(), msgID, blockHeight, peers, threshold, signChan)
+}
+
+// JoinPartyWithLeaderContext is JoinPartyWithLeader which gives up as soon as the given context is done
+func (pc *PartyCoordinator) JoinPartyWithLeaderContext(ctx context.Context, msgID string, blockHeight int64, peers []string, threshold int, signChan chan string) ([]peer.ID, string, error) {
 	leader, err := LeaderNode(msgID, blockHeight, peers)
 	if err != nil {
 		return nil, "", err
 	}
 	if pc.host.ID().String() == leader {
-		onlines, err := pc.joinPartyLeader(msgID, peers, threshold, signChan)
+		onlines, err := pc.joinPartyLeader(ctx, msgID, peers, threshold, signChan)
 		return onlines, leader, err
 	}
 	// now we are just the normal peer
-	onlines, err := pc.joinPartyMember(msgID, leader, threshold, signChan)
+	onlines, err := pc.joinPartyMember(ctx, msgID, leader, threshold, signChan)
 	return onlines, leader, err
 }
 
 // JoinPartyWithRetry this method provide the functionality to join party with retry and back off
 func (pc *PartyCoordinator) JoinPartyWithRetry(msgID string, peers []string) ([]peer.ID, error) {
+	return pc.JoinPartyWithRetryContext(context.Background(), msgID, peers)
+}
+
+// JoinPartyWithRetryContext is JoinPartyWithRetry which gives up as soon as the given context is done
+func (pc *PartyCoordinator) JoinPartyWithRetryContext(ctx context.Context, msgID string, peers []string) ([]peer.ID, error) {
 	msg := messages.JoinPartyRequest{
 		ID: msgID,
 	}
@@ -580,11 +606,17 @@ func (pc *PartyCoordinator) JoinPartyWithRetry(msgID string, peers []string) ([]
 				// timeout
 				close(done)
 				return
+			case <-ctx.Done():
+				close(done)
+				return
 			}
 		}
 	}()
 
 	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("fail to join party: %w", err)
+	}
 	onlinePeers, _ := peerGroup.getPeersStatus()
 	pc.sendRequestToAll(msgID, msgSend, onlinePeers)
 	time.Sleep(5 * time.Second)
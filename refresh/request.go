@@ -0,0 +1,37 @@
+package refresh
+
+import "github.com/HyperCore-Team/go-tss/common"
+
+// Request request to refresh the key shares of a pool, the committee and the threshold of the pool stay the same
+type Request struct {
+	PoolPubKey  string `json:"pool_pub_key"`
+	BlockHeight int64  `json:"block_height"`
+	Version     string `json:"tss_version"`
+	Algo        string `json:"algo"`
+}
+
+// NewRequest create a new instance of refresh.Request
+func NewRequest(poolPubKey string, blockHeight int64, version string, algo string) Request {
+	return Request{
+		PoolPubKey:  poolPubKey,
+		BlockHeight: blockHeight,
+		Version:     version,
+		Algo:        algo,
+	}
+}
+
+// Validate checks the fields of the request before any party is contacted
+func (r Request) Validate() error {
+	var errs common.FieldErrors
+	errs.CheckPoolPubKey("pool_pub_key", r.PoolPubKey, r.Algo)
+	errs.CheckBlockHeight("block_height", r.BlockHeight)
+	errs.CheckVersion("tss_version", r.Version)
+	switch r.Algo {
+	case "ecdsa", "eddsa":
+	case "schnorr":
+		errs.Addf("algo", "the schnorr pools can't be refreshed")
+	default:
+		errs.Addf("algo", "invalid key refresh algo %q", r.Algo)
+	}
+	return errs.Err()
+}
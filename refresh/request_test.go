@@ -0,0 +1,49 @@
+package refresh
+
+import (
+	"errors"
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/common"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type RequestTestSuite struct{}
+
+var _ = Suite(&RequestTestSuite{})
+
+func (RequestTestSuite) TestValidate(c *C) {
+	const (
+		ecdsaPoolPubKey = "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq"
+		eddsaPoolPubKey = "D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY="
+	)
+	testCases := []struct {
+		name   string
+		req    Request
+		fields []string
+	}{
+		{name: "ecdsa", req: NewRequest(ecdsaPoolPubKey, 10, "0.14.0", "ecdsa")},
+		{name: "eddsa", req: NewRequest(eddsaPoolPubKey, 10, "0.14.0", "eddsa")},
+		{name: "pool pub key of another algo", req: NewRequest(ecdsaPoolPubKey, 10, "0.14.0", "eddsa"), fields: []string{"pool_pub_key"}},
+		{name: "negative block height", req: NewRequest(ecdsaPoolPubKey, -1, "0.14.0", "ecdsa"), fields: []string{"block_height"}},
+		{name: "schnorr", req: NewRequest(eddsaPoolPubKey, 10, "0.14.0", "schnorr"), fields: []string{"algo"}},
+		{name: "unknown algo", req: NewRequest(ecdsaPoolPubKey, 10, "0.14.0", "rsa"), fields: []string{"algo"}},
+	}
+	for _, tc := range testCases {
+		err := tc.req.Validate()
+		if len(tc.fields) == 0 {
+			c.Check(err, IsNil, Commentf(tc.name))
+			continue
+		}
+		var fieldErrs common.FieldErrors
+		c.Assert(errors.As(err, &fieldErrs), Equals, true, Commentf(tc.name))
+		var fields []string
+		for _, el := range fieldErrs {
+			fields = append(fields, el.Field)
+		}
+		c.Check(fields, DeepEquals, tc.fields, Commentf(tc.name))
+	}
+}
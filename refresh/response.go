@@ -0,0 +1,22 @@
+package refresh
+
+import (
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+)
+
+// Response refresh response
+type Response struct {
+	PubKey string        `json:"pub_key"`
+	Status common.Status `json:"status"`
+	Blame  blame.Blame   `json:"blame"`
+}
+
+// NewResponse create a new instance of refresh.Response
+func NewResponse(pk string, status common.Status, blame blame.Blame) Response {
+	return Response{
+		PubKey: pk,
+		Status: status,
+		Blame:  blame,
+	}
+}
@@ -39,6 +39,7 @@ type TssKeyReGroup struct {
 	stateManager    storage.LocalStateManager
 	commStopChan    chan struct{}
 	p2pComm         *p2p.Communication
+	msgType         messages.THORChainTSSMessageType // the type of the resharing messages we send
 }
 
 func NewTssKeyReGroup(localP2PID string,
@@ -63,6 +64,7 @@ func NewTssKeyReGroup(localP2PID string,
 		stateManager:    stateManager,
 		commStopChan:    make(chan struct{}),
 		p2pComm:         p2pComm,
+		msgType:         messages.TSSPartyReGroupMsg,
 	}
 }
 
@@ -86,9 +88,14 @@ func (tKeyReGroup *TssKeyReGroup) NewPartyInit(req keyRegroup.Request) (*btss.Re
 	}
 
 	// we are the new party
-	threshold, err := conversion.GetThreshold(len(req.NewPartyKeys))
+	threshold, err := conversion.ResolveThreshold(req.Threshold, len(req.NewPartyKeys))
 	if err != nil {
-		return nil, nil, nil, nil, errors.New("fail to get threshold")
+		return nil, nil, nil, nil, fmt.Errorf("fail to get threshold: %w", err)
+	}
+	// every party has to use the same old threshold, so it falls back to the default of the old committee
+	oldThreshold, err := conversion.ResolveThreshold(req.OldThreshold, len(req.OldPartyKeys))
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("fail to get the threshold of the old committee: %w", err)
 	}
 
 	if amNewParty {
@@ -109,10 +116,10 @@ func (tKeyReGroup *TssKeyReGroup) NewPartyInit(req keyRegroup.Request) (*btss.Re
 	ctxOld := btss.NewPeerContext(oldPartiesID)
 	var newParams, oldParams *btss.ReSharingParameters
 	if newLocalPartyID != nil {
-		newParams = btss.NewReSharingParameters(btcec.S256(), ctxOld, ctxNew, newLocalPartyID, len(req.OldPartyKeys), threshold, len(req.NewPartyKeys), threshold)
+		newParams = btss.NewReSharingParameters(btcec.S256(), ctxOld, ctxNew, newLocalPartyID, len(req.OldPartyKeys), oldThreshold, len(req.NewPartyKeys), threshold)
 	}
 	if oldLocalPartyID != nil {
-		oldParams = btss.NewReSharingParameters(btcec.S256(), ctxOld, ctxNew, oldLocalPartyID, len(req.OldPartyKeys), threshold, len(req.NewPartyKeys), threshold)
+		oldParams = btss.NewReSharingParameters(btcec.S256(), ctxOld, ctxNew, oldLocalPartyID, len(req.OldPartyKeys), oldThreshold, len(req.NewPartyKeys), threshold)
 	}
 
 	return newParams, oldParams, oldPartiesID, newPartiesID, nil
@@ -220,15 +227,38 @@ func (tKeyReGroup *TssKeyReGroup) GenerateNewKey(req keyRegroup.Request, localSt
 		}()
 	}
 
+	threshold, err := conversion.ResolveThreshold(req.Threshold, len(req.NewPartyKeys))
+	if err != nil {
+		return nil, err
+	}
 	keyGenLocalStateItem := storage.KeygenLocalState{
 		ParticipantKeys: req.NewPartyKeys,
 		LocalPartyKey:   tKeyReGroup.localNodePubKey,
+		Threshold:       threshold,
+	}
+	// the chain code stays the same, the new members can't compute it, so the old committee sends it to them
+	if oldKeyGenParty != nil {
+		keyGenLocalStateItem.ChainCode, err = localStateItem.GetChainCode()
+		if err != nil {
+			return nil, fmt.Errorf("fail to get the chain code: %w", err)
+		}
+		newMembers, err := conversion.GetPeerIDsFromPubKeys(newMemberKeys(req))
+		if err != nil {
+			return nil, fmt.Errorf("fail to get the peers of the new members: %w", err)
+		}
+		if err := tKeyReGroup.tssCommonStruct.SendChainCode(keyGenLocalStateItem.ChainCode, newMembers); err != nil {
+			return nil, err
+		}
+	}
+	oldThreshold, err := conversion.ResolveThreshold(req.OldThreshold, len(req.OldPartyKeys))
+	if err != nil {
+		return nil, err
 	}
 
 	keyGenWg.Add(1)
 	go tKeyReGroup.tssCommonStruct.ProcessInboundMessages(tKeyReGroup.commStopChan, &keyGenWg)
 
-	r, err, _ := tKeyReGroup.processKeyReGroup(errChan, outCh, endCh, oldKeyGenParty != nil && newKeyGenParty != nil, keyGenLocalStateItem, len(req.OldPartyKeys))
+	r, err, _ := tKeyReGroup.processKeyReGroup(errChan, outCh, endCh, oldKeyGenParty != nil && newKeyGenParty != nil, keyGenLocalStateItem, len(req.OldPartyKeys), oldThreshold)
 	if err != nil {
 		close(tKeyReGroup.commStopChan)
 		return nil, fmt.Errorf("fail to process key sign: %w", err)
@@ -247,7 +277,7 @@ func (tKeyReGroup *TssKeyReGroup) GenerateNewKey(req keyRegroup.Request, localSt
 
 func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 	outCh <-chan btss.Message,
-	endCh <-chan bkg.LocalPartySaveData, bothOldNewParty bool, keyGenLocalStateItem storage.KeygenLocalState, oldPartyNum int,
+	endCh <-chan bkg.LocalPartySaveData, bothOldNewParty bool, keyGenLocalStateItem storage.KeygenLocalState, oldPartyNum, oldThreshold int,
 ) (*bcrypto.ECPoint, error, string) {
 	// keyGenLocalStateItem storage.KeygenLocalState) (*bcrypto.ECPoint, error) {
 	defer tKeyReGroup.logger.Debug().Msg("finished regroup process")
@@ -318,7 +348,7 @@ func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 			}
 			// to old members
 			if msg.IsToOldCommittee() {
-				err := tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(msg, messages.TSSPartyReGroupMsg, common.OldParty)
+				err := tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(msg, tKeyReGroup.msgType, common.OldParty)
 				if err != nil {
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to process the message")
 					return nil, err, ""
@@ -327,7 +357,7 @@ func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 			}
 			// to new members
 			if !msg.IsToOldCommittee() && !msg.IsToOldAndNewCommittees() {
-				err := tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(msg, messages.TSSPartyReGroupMsg, common.NewParty)
+				err := tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(msg, tKeyReGroup.msgType, common.NewParty)
 				if err != nil {
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to process the message")
 					return nil, err, ""
@@ -364,12 +394,12 @@ func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 				oldTssMsg := btss.NewMessage(messageRoutingOld, data.Content(), msg.WireMsg())
 				newTssMsg := btss.NewMessage(messageRoutingNew, data.Content(), msg.WireMsg())
 
-				err = tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(oldTssMsg, messages.TSSPartyReGroupMsg, common.OldParty)
+				err = tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(oldTssMsg, tKeyReGroup.msgType, common.OldParty)
 				if err != nil {
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to process the message")
 					return nil, err, ""
 				}
-				err = tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(newTssMsg, messages.TSSPartyReGroupMsg, common.NewParty)
+				err = tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(newTssMsg, tKeyReGroup.msgType, common.NewParty)
 				if err != nil {
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to process the message")
 					return nil, err, ""
@@ -392,6 +422,13 @@ func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to marshal the result")
 					return nil, errors.New("fail to marshal the result"), ""
 				}
+				// a new member takes the chain code more members of the old committee sent than the threshold
+				if len(keyGenLocalStateItem.ChainCode) == 0 {
+					keyGenLocalStateItem.ChainCode, err = tKeyReGroup.tssCommonStruct.WaitChainCode(oldThreshold+1, tKeyReGroup.stopChan, tssConf.KeyRegroupTimeout)
+					if err != nil {
+						return nil, fmt.Errorf("fail to get the chain code: %w", err), ""
+					}
+				}
 				keyGenLocalStateItem.LocalData = marshaledMsg
 				keyGenLocalStateItem.PubKey = strPubKey
 				fmt.Println("Ks: ", msg.Ks)
@@ -417,3 +454,18 @@ func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 		}
 	}
 }
+
+// newMemberKeys returns the keys of the new committee which aren't in the old one
+func newMemberKeys(req keyRegroup.Request) []string {
+	oldMembers := make(map[string]bool, len(req.OldPartyKeys))
+	for _, el := range req.OldPartyKeys {
+		oldMembers[el] = true
+	}
+	var keys []string
+	for _, el := range req.NewPartyKeys {
+		if !oldMembers[el] {
+			keys = append(keys, el)
+		}
+	}
+	return keys
+}
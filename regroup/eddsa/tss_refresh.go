@@ -0,0 +1,30 @@
+package eddsa
+
+import (
+	"github.com/rs/zerolog/log"
+	tcrypto "github.com/tendermint/tendermint/crypto"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/go-tss/p2p"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+// NewTssKeyRefresh creates the instance to refresh the key shares of a pool, the pool is reshared to its own
+// committee with the refresh messages, so the pool pub key stays the same
+func NewTssKeyRefresh(localP2PID string,
+	conf common.TssConfig,
+	localNodePubKey string,
+	broadcastChan chan *messages.BroadcastMsgChan,
+	stopChan chan struct{},
+	msgID string,
+	stateManager storage.LocalStateManager,
+	privateKey tcrypto.PrivKey,
+	p2pComm *p2p.Communication) *TssKeyReGroup {
+	tKeyReGroup := NewTssKeyReGroup(localP2PID, conf, localNodePubKey, broadcastChan, stopChan, msgID, stateManager, privateKey, p2pComm)
+	tKeyReGroup.logger = log.With().
+		Str("module", "refresh").
+		Str("msgID", msgID).Logger()
+	tKeyReGroup.msgType = messages.TSSRefreshMsg
+	return tKeyReGroup
+}
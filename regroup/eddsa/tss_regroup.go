@@ -36,6 +36,7 @@ type TssKeyReGroup struct {
 	stateManager    storage.LocalStateManager
 	commStopChan    chan struct{}
 	p2pComm         *p2p.Communication
+	msgType         messages.THORChainTSSMessageType // the type of the resharing messages we send
 }
 
 func NewTssKeyReGroup(localP2PID string,
@@ -58,6 +59,7 @@ func NewTssKeyReGroup(localP2PID string,
 		stateManager:    stateManager,
 		commStopChan:    make(chan struct{}),
 		p2pComm:         p2pComm,
+		msgType:         messages.TSSPartyReGroupMsg,
 	}
 }
 
@@ -81,9 +83,14 @@ func (tKeyReGroup *TssKeyReGroup) NewPartyInit(req keyRegroup.Request) (*btss.Re
 	}
 
 	// we are the new party
-	threshold, err := conversion.GetThreshold(len(req.NewPartyKeys))
+	threshold, err := conversion.ResolveThreshold(req.Threshold, len(req.NewPartyKeys))
 	if err != nil {
-		return nil, nil, nil, nil, errors.New("fail to get threshold")
+		return nil, nil, nil, nil, fmt.Errorf("fail to get threshold: %w", err)
+	}
+	// every party has to use the same old threshold, so it falls back to the default of the old committee
+	oldThreshold, err := conversion.ResolveThreshold(req.OldThreshold, len(req.OldPartyKeys))
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("fail to get the threshold of the old committee: %w", err)
 	}
 
 	if amNewParty {
@@ -104,10 +111,10 @@ func (tKeyReGroup *TssKeyReGroup) NewPartyInit(req keyRegroup.Request) (*btss.Re
 	ctxOld := btss.NewPeerContext(oldPartiesID)
 	var newParams, oldParams *btss.ReSharingParameters
 	if newLocalPartyID != nil {
-		newParams = btss.NewReSharingParameters(btss.Edwards(), ctxOld, ctxNew, newLocalPartyID, len(req.OldPartyKeys), threshold, len(req.NewPartyKeys), threshold)
+		newParams = btss.NewReSharingParameters(btss.Edwards(), ctxOld, ctxNew, newLocalPartyID, len(req.OldPartyKeys), oldThreshold, len(req.NewPartyKeys), threshold)
 	}
 	if oldLocalPartyID != nil {
-		oldParams = btss.NewReSharingParameters(btss.Edwards(), ctxOld, ctxNew, oldLocalPartyID, len(req.OldPartyKeys), threshold, len(req.NewPartyKeys), threshold)
+		oldParams = btss.NewReSharingParameters(btss.Edwards(), ctxOld, ctxNew, oldLocalPartyID, len(req.OldPartyKeys), oldThreshold, len(req.NewPartyKeys), threshold)
 	}
 
 	return newParams, oldParams, oldPartiesID, newPartiesID, nil
@@ -208,9 +215,14 @@ func (tKeyReGroup *TssKeyReGroup) GenerateNewKey(req keyRegroup.Request, localSt
 		}()
 	}
 
+	threshold, err := conversion.ResolveThreshold(req.Threshold, len(req.NewPartyKeys))
+	if err != nil {
+		return nil, err
+	}
 	keyGenLocalStateItem := storage.KeygenLocalState{
 		ParticipantKeys: req.NewPartyKeys,
 		LocalPartyKey:   tKeyReGroup.localNodePubKey,
+		Threshold:       threshold,
 	}
 
 	keyGenWg.Add(1)
@@ -306,7 +318,7 @@ func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 			}
 			// to old members
 			if msg.IsToOldCommittee() {
-				err := tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(msg, messages.TSSPartyReGroupMsg, common.OldParty)
+				err := tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(msg, tKeyReGroup.msgType, common.OldParty)
 				if err != nil {
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to process the message")
 					return nil, err, ""
@@ -315,7 +327,7 @@ func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 			}
 			// to new members
 			if !msg.IsToOldCommittee() && !msg.IsToOldAndNewCommittees() {
-				err := tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(msg, messages.TSSPartyReGroupMsg, common.NewParty)
+				err := tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(msg, tKeyReGroup.msgType, common.NewParty)
 				if err != nil {
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to process the message")
 					return nil, err, ""
@@ -352,12 +364,12 @@ func (tKeyReGroup *TssKeyReGroup) processKeyReGroup(errChan chan struct{},
 				oldTssMsg := btss.NewMessage(messageRoutingOld, data.Content(), msg.WireMsg())
 				newTssMsg := btss.NewMessage(messageRoutingNew, data.Content(), msg.WireMsg())
 
-				err = tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(oldTssMsg, messages.TSSPartyReGroupMsg, common.OldParty)
+				err = tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(oldTssMsg, tKeyReGroup.msgType, common.OldParty)
 				if err != nil {
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to process the message")
 					return nil, err, ""
 				}
-				err = tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(newTssMsg, messages.TSSPartyReGroupMsg, common.NewParty)
+				err = tKeyReGroup.tssCommonStruct.ProcessRegroupOutCh(newTssMsg, tKeyReGroup.msgType, common.NewParty)
 				if err != nil {
 					tKeyReGroup.logger.Error().Err(err).Msg("fail to process the message")
 					return nil, err, ""
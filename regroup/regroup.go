@@ -1,12 +1,23 @@
 package keyRegroup
 
 import (
+	"errors"
+
 	"github.com/HyperCore-Team/go-tss/common"
 	"github.com/HyperCore-Team/go-tss/p2p"
 	"github.com/HyperCore-Team/go-tss/storage"
 	bcrypto "github.com/HyperCore-Team/tss-lib/crypto"
 )
 
+var (
+	// ErrInvalidAlgo is returned for a key regroup request of an unknown algo or of an algo whose pools can't be
+	// regrouped
+	ErrInvalidAlgo = errors.New("invalid key regroup algo")
+	// ErrThresholdMismatch is returned when the old threshold of the request isn't the threshold the pool was created
+	// with, the resharing would fail
+	ErrThresholdMismatch = errors.New("the old threshold doesn't match the pool")
+)
+
 type TssKeyRegroup interface {
 	GetTssKeyGenChannels() chan *p2p.Message
 	GetTssCommonStruct() *common.TssCommon
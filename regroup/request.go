@@ -1,5 +1,12 @@
 package keyRegroup
 
+import (
+	"fmt"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
+)
+
 // Request request to do keygen
 type Request struct {
 	PoolPubKey   string   `json:"pool_address"`
@@ -8,6 +15,11 @@ type Request struct {
 	BlockHeight  int64    `json:"block_height"`
 	Version      string   `json:"tss_version"`
 	Algo         string   `json:"algo"`
+	// Threshold of the new committee, the default threshold is used if it is zero
+	Threshold int `json:"threshold,omitempty"`
+	// OldThreshold of the old committee, the default threshold of the old committee is used if it is zero.
+	// All the parties must use the same value, so it has to be set if the pool has a custom threshold
+	OldThreshold int `json:"old_threshold,omitempty"`
 }
 
 // NewRequest create a new instance of keygen.Request
@@ -21,3 +33,53 @@ func NewRequest(poolAddress string, oldPartyKeys, newPartyKeys []string, blockHe
 		Algo:         algo,
 	}
 }
+
+// ConfirmRequest confirms the regroup of the given pool, so the old key share can be retired
+type ConfirmRequest struct {
+	PoolPubKey string `json:"pool_pub_key"`
+}
+
+// Validate checks the fields of the request before any party is contacted, whether the keys are whitelisted and
+// include the local node is checked by the TssServer
+func (r Request) Validate() error {
+	var errs common.FieldErrors
+	// the new members don't hold a key share of the pool, they leave the pool pub key empty
+	if r.PoolPubKey != "" {
+		errs.CheckPoolPubKey("pool_address", r.PoolPubKey, r.Algo)
+	}
+	if len(r.OldPartyKeys) < 2 {
+		errs.Addf("old_party_keys", "the old committee needs at least 2 keys, got %d", len(r.OldPartyKeys))
+	}
+	errs.CheckNodePubKeys("old_party_keys", r.OldPartyKeys)
+	if len(r.NewPartyKeys) < 2 {
+		errs.Addf("new_party_keys", "the new committee needs at least 2 keys, got %d", len(r.NewPartyKeys))
+	}
+	errs.CheckNodePubKeys("new_party_keys", r.NewPartyKeys)
+	errs.CheckBlockHeight("block_height", r.BlockHeight)
+	errs.CheckVersion("tss_version", r.Version)
+	switch r.Algo {
+	case "ecdsa", "eddsa":
+	case "schnorr":
+		errs.Add("algo", fmt.Errorf("%w: the schnorr pools can't be regrouped", ErrInvalidAlgo))
+	default:
+		errs.Add("algo", fmt.Errorf("%w: %q", ErrInvalidAlgo, r.Algo))
+	}
+	if len(r.NewPartyKeys) >= 2 {
+		if _, err := conversion.ResolveThreshold(r.Threshold, len(r.NewPartyKeys)); err != nil {
+			errs.Add("threshold", err)
+		}
+	}
+	if len(r.OldPartyKeys) >= 2 {
+		if _, err := conversion.ResolveThreshold(r.OldThreshold, len(r.OldPartyKeys)); err != nil {
+			errs.Add("old_threshold", err)
+		}
+	}
+	return errs.Err()
+}
+
+// Validate checks the pool pub key of the request is given
+func (r ConfirmRequest) Validate() error {
+	var errs common.FieldErrors
+	errs.CheckPoolPubKey("pool_pub_key", r.PoolPubKey, "")
+	return errs.Err()
+}
@@ -0,0 +1,81 @@
+package keyRegroup
+
+import (
+	"errors"
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/common"
+)
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type RequestTestSuite struct{}
+
+var _ = Suite(&RequestTestSuite{})
+
+const testPoolPubKey = "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq"
+
+var testNodePubKeys = []string{
+	"D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=",
+	"8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=",
+	"Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU=",
+	"jzTMn5m27Cmt6EuCAuKnIzxNbVYY4EIywP0a9grmSok=",
+}
+
+func (RequestTestSuite) TestValidate(c *C) {
+	valid := func() Request {
+		oldKeys := append([]string{}, testNodePubKeys[:3]...)
+		newKeys := append([]string{}, testNodePubKeys[1:]...)
+		return NewRequest(testPoolPubKey, oldKeys, newKeys, 10, "0.14.0", "ecdsa")
+	}
+	testCases := []struct {
+		name   string
+		modify func(r *Request)
+		fields []string
+	}{
+		{name: "valid", modify: func(r *Request) {}},
+		{name: "new member", modify: func(r *Request) { r.PoolPubKey = "" }},
+		{name: "custom thresholds", modify: func(r *Request) {
+			r.Threshold = 2
+			r.OldThreshold = 1
+		}},
+		{name: "pool pub key of another algo", modify: func(r *Request) { r.Algo = "eddsa" }, fields: []string{"pool_address"}},
+		{name: "no old committee", modify: func(r *Request) { r.OldPartyKeys = nil }, fields: []string{"old_party_keys"}},
+		{name: "single new member", modify: func(r *Request) { r.NewPartyKeys = r.NewPartyKeys[:1] }, fields: []string{"new_party_keys"}},
+		{name: "duplicate old key", modify: func(r *Request) { r.OldPartyKeys[1] = r.OldPartyKeys[0] }, fields: []string{"old_party_keys[1]"}},
+		{name: "invalid new key", modify: func(r *Request) { r.NewPartyKeys[0] = "A" }, fields: []string{"new_party_keys[0]"}},
+		{name: "no version", modify: func(r *Request) { r.Version = "" }, fields: []string{"tss_version"}},
+		{name: "schnorr", modify: func(r *Request) {
+			r.PoolPubKey = ""
+			r.Algo = "schnorr"
+		}, fields: []string{"algo"}},
+		{name: "unknown algo", modify: func(r *Request) { r.Algo = "" }, fields: []string{"algo"}},
+		{name: "threshold of the whole committee", modify: func(r *Request) { r.Threshold = 3 }, fields: []string{"threshold"}},
+		{name: "negative old threshold", modify: func(r *Request) { r.OldThreshold = -1 }, fields: []string{"old_threshold"}},
+	}
+	for _, tc := range testCases {
+		req := valid()
+		tc.modify(&req)
+		err := req.Validate()
+		if len(tc.fields) == 0 {
+			c.Check(err, IsNil, Commentf(tc.name))
+			continue
+		}
+		var fieldErrs common.FieldErrors
+		c.Assert(errors.As(err, &fieldErrs), Equals, true, Commentf(tc.name))
+		var fields []string
+		for _, el := range fieldErrs {
+			fields = append(fields, el.Field)
+		}
+		c.Check(fields, DeepEquals, tc.fields, Commentf(tc.name))
+	}
+
+	req := valid()
+	req.Algo = "schnorr"
+	c.Assert(errors.Is(req.Validate(), ErrInvalidAlgo), Equals, true)
+
+	c.Assert(ConfirmRequest{PoolPubKey: testPoolPubKey}.Validate(), IsNil)
+	c.Assert(ConfirmRequest{}.Validate(), NotNil)
+}
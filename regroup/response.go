@@ -11,8 +11,17 @@ type Response struct {
 	PoolAddress string        `json:"pool_address"`
 	Status      common.Status `json:"status"`
 	Blame       blame.Blame   `json:"blame"`
+	// Retirement tells what happens to the old key share of a node which is not a member of the new committee
+	Retirement string `json:"retirement,omitempty"`
 }
 
+const (
+	// RetirementKept the old key share is kept, as no retirement policy is configured
+	RetirementKept = "kept"
+	// RetirementPending the old key share will be retired once the regroup is confirmed
+	RetirementPending = "pending"
+)
+
 // NewResponse create a new instance of keygen.Response
 func NewResponse(pk, addr string, status common.Status, blame blame.Blame) Response {
 	return Response{
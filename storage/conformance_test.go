@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	maddr "github.com/multiformats/go-multiaddr"
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+const testPoolPubKey2 = "A5USsme4piKC377RzQr9U3k9yvcWe9oynB9XooXd6akm"
+
+// StateMgrConformanceSuite is run against every LocalStateManager implementation
+type StateMgrConformanceSuite struct {
+	name string
+	// newMgr creates a manager persisting to the given folder
+	newMgr func(c *C, folder string) LocalStateManager
+	// persistent tells whether a new manager on the same folder sees the saved state
+	persistent bool
+}
+
+var (
+	_ = Suite(&StateMgrConformanceSuite{
+		name: "file",
+		newMgr: func(c *C, folder string) LocalStateManager {
+			fsm, err := NewFileStateMgr(folder)
+			c.Assert(err, IsNil)
+			return fsm
+		},
+		persistent: true,
+	})
+	_ = Suite(&StateMgrConformanceSuite{
+		name: "encrypted file",
+		newMgr: func(c *C, folder string) LocalStateManager {
+			fsm, err := NewEncryptedFileStateMgr(folder, []byte("passphrase"))
+			c.Assert(err, IsNil)
+			return fsm
+		},
+		persistent: true,
+	})
+	_ = Suite(&StateMgrConformanceSuite{
+		name: "kv",
+		newMgr: func(c *C, folder string) LocalStateManager {
+			kv, err := NewKVStateMgr(filepath.Join(folder, "localstate.db"))
+			c.Assert(err, IsNil)
+			return kv
+		},
+		persistent: true,
+	})
+	_ = Suite(&StateMgrConformanceSuite{
+		name: "encrypted kv",
+		newMgr: func(c *C, folder string) LocalStateManager {
+			kv, err := NewEncryptedKVStateMgr(filepath.Join(folder, "localstate.db"), []byte("passphrase"))
+			c.Assert(err, IsNil)
+			return kv
+		},
+		persistent: true,
+	})
+	_ = Suite(&StateMgrConformanceSuite{
+		name: "memory",
+		newMgr: func(c *C, folder string) LocalStateManager {
+			return NewMemStateMgr()
+		},
+	})
+)
+
+// reopen closes the manager if it holds the folder open, and creates a new manager on the same folder
+func (s *StateMgrConformanceSuite) reopen(c *C, mgr LocalStateManager, folder string) LocalStateManager {
+	if closer, ok := mgr.(io.Closer); ok {
+		c.Assert(closer.Close(), IsNil)
+	}
+	return s.newMgr(c, folder)
+}
+
+func newTestPeerID(c *C) peer.ID {
+	_, pubKey, err := crypto.GenerateEd25519Key(rand.Reader)
+	c.Assert(err, IsNil)
+	id, err := peer.IDFromPublicKey(pubKey)
+	c.Assert(err, IsNil)
+	return id
+}
+
+func (s *StateMgrConformanceSuite) TestSaveAndGetLocalState(c *C) {
+	c.Log(s.name)
+	folder := c.MkDir()
+	mgr := s.newMgr(c, folder)
+	state := testLocalState()
+	c.Assert(mgr.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+	loaded, err := mgr.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+
+	// the returned state doesn't share memory with the stored one
+	loaded.LocalData[0] = 'x'
+	loaded.ParticipantKeys[0] = "X"
+	loaded, err = mgr.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+
+	// overwrite the local state of the pool
+	state.ParticipantKeys = []string{"A", "B", "C", "D"}
+	c.Assert(mgr.SaveLocalState(state, messages.ECDSAKEYREGROUP), IsNil)
+	loaded, err = mgr.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+
+	// the other pools are independent
+	other := testLocalState()
+	other.PubKey = testPoolPubKey2
+	c.Assert(mgr.SaveLocalState(other, messages.ECDSAKEYGEN), IsNil)
+	loaded, err = mgr.GetLocalState(testPoolPubKey2, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, other)
+	loaded, err = mgr.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+
+	eddsaState := testLocalState()
+	eddsaState.PubKey = conversion.GetRandomPubKey()
+	c.Assert(mgr.SaveLocalState(eddsaState, messages.EDDSAKEYGEN), IsNil)
+	loaded, err = mgr.GetLocalState(eddsaState.PubKey, messages.EDDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, eddsaState)
+
+	if !s.persistent {
+		return
+	}
+	reopened := s.reopen(c, mgr, folder)
+	loaded, err = reopened.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+	loaded, err = reopened.GetLocalState(eddsaState.PubKey, messages.EDDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, eddsaState)
+}
+
+func (s *StateMgrConformanceSuite) TestGetLocalStateErrors(c *C) {
+	c.Log(s.name)
+	mgr := s.newMgr(c, c.MkDir())
+	_, err := mgr.GetLocalState("", messages.ECDSAKEYSIGN)
+	c.Assert(err, NotNil)
+	_, err = mgr.GetLocalState("invalid pub key", messages.ECDSAKEYSIGN)
+	c.Assert(err, NotNil)
+	_, err = mgr.GetLocalState(testPoolPubKey, messages.ECDSAKEYSIGN)
+	c.Assert(errors.Is(err, os.ErrNotExist), Equals, true)
+
+	state := testLocalState()
+	state.PubKey = "invalid pub key"
+	c.Assert(mgr.SaveLocalState(state, messages.ECDSAKEYGEN), NotNil)
+	state.PubKey = testPoolPubKey
+	c.Assert(mgr.SaveLocalState(state, messages.Algo(-1)), NotNil)
+}
+
+func (s *StateMgrConformanceSuite) TestAddressBook(c *C) {
+	c.Log(s.name)
+	folder := c.MkDir()
+	mgr := s.newMgr(c, folder)
+	_, err := mgr.RetrieveP2PAddresses()
+	c.Assert(err, NotNil)
+
+	id := newTestPeerID(c)
+	remote, err := maddr.NewMultiaddr("/ip4/192.168.0.1/tcp/6668")
+	c.Assert(err, IsNil)
+	loopback, err := maddr.NewMultiaddr("/ip4/127.0.0.1/tcp/6668")
+	c.Assert(err, IsNil)
+	c.Assert(mgr.SaveAddressBook(map[peer.ID][]maddr.Multiaddr{
+		id: {remote, loopback},
+	}), IsNil)
+	expected, err := maddr.NewMultiaddr(remote.String() + "/p2p/" + id.String())
+	c.Assert(err, IsNil)
+
+	addrs, err := mgr.RetrieveP2PAddresses()
+	c.Assert(err, IsNil)
+	c.Assert(addrs, HasLen, 1)
+	c.Assert(addrs[0].Equal(expected), Equals, true)
+
+	if !s.persistent {
+		return
+	}
+	addrs, err = s.reopen(c, mgr, folder).RetrieveP2PAddresses()
+	c.Assert(err, IsNil)
+	c.Assert(addrs, HasLen, 1)
+	c.Assert(addrs[0].Equal(expected), Equals, true)
+}
+
+func (s *StateMgrConformanceSuite) TestLocalStateLifecycle(c *C) {
+	c.Log(s.name)
+	folder := c.MkDir()
+	mgr := s.newMgr(c, folder)
+	states, err := mgr.ListLocalStates()
+	c.Assert(err, IsNil)
+	c.Assert(states, HasLen, 0)
+
+	state := testLocalState()
+	other := testLocalState()
+	other.PubKey = testPoolPubKey2
+	c.Assert(mgr.SaveLocalState(other, messages.ECDSAKEYGEN), IsNil)
+	c.Assert(mgr.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+	states, err = mgr.ListLocalStates()
+	c.Assert(err, IsNil)
+	c.Assert(states, DeepEquals, []KeygenLocalState{other, state})
+
+	c.Assert(mgr.ArchiveLocalState(state.PubKey, messages.ECDSAKEYGEN), IsNil)
+	_, err = mgr.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(errors.Is(err, os.ErrNotExist), Equals, true)
+	states, err = mgr.ListLocalStates()
+	c.Assert(err, IsNil)
+	c.Assert(states, DeepEquals, []KeygenLocalState{other})
+	err = mgr.ArchiveLocalState(state.PubKey, messages.ECDSAKEYGEN)
+	c.Assert(errors.Is(err, os.ErrNotExist), Equals, true)
+
+	// the pool can be created again once it is archived
+	c.Assert(mgr.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+	c.Assert(mgr.ArchiveLocalState(state.PubKey, messages.ECDSAKEYGEN), IsNil)
+
+	c.Assert(mgr.DeleteLocalState(other.PubKey, messages.ECDSAKEYGEN), IsNil)
+	_, err = mgr.GetLocalState(other.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(errors.Is(err, os.ErrNotExist), Equals, true)
+	err = mgr.DeleteLocalState(other.PubKey, messages.ECDSAKEYGEN)
+	c.Assert(errors.Is(err, os.ErrNotExist), Equals, true)
+	c.Assert(mgr.DeleteLocalState("invalid pub key", messages.ECDSAKEYGEN), NotNil)
+
+	states, err = mgr.ListLocalStates()
+	c.Assert(err, IsNil)
+	c.Assert(states, HasLen, 0)
+	if !s.persistent {
+		return
+	}
+	states, err = s.reopen(c, mgr, folder).ListLocalStates()
+	c.Assert(err, IsNil)
+	c.Assert(states, HasLen, 0)
+}
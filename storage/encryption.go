@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedStateVersion = 1
+	encryptedStateKDF     = "scrypt"
+	encryptedStateCipher  = "aes-256-gcm"
+
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 32
+)
+
+// ErrWrongPassphrase is returned when the local state can't be decrypted with the given passphrase
+var ErrWrongPassphrase = errors.New("fail to decrypt local state, wrong passphrase or corrupted file")
+
+// encryptedState is the on-disk envelope of an encrypted KeygenLocalState
+type encryptedState struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Cipher     string `json:"cipher"`
+	Nonce      []byte `json:"nonce"`
+	CipherText []byte `json:"ciphertext"`
+}
+
+// stateCipher encrypts and decrypts the local state with a key derived from a passphrase.
+// the salt is generated once per instance, so the expensive key derivation is done only once
+// for the files we write, the derived keys of the files we read are cached by their salt.
+type stateCipher struct {
+	passphrase []byte
+	salt       []byte
+	keysLock   *sync.Mutex
+	keys       map[string][]byte
+}
+
+func newStateCipher(passphrase []byte) (*stateCipher, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase is empty")
+	}
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("fail to generate salt: %w", err)
+	}
+	return &stateCipher{
+		passphrase: append([]byte{}, passphrase...),
+		salt:       salt,
+		keysLock:   &sync.Mutex{},
+		keys:       make(map[string][]byte),
+	}, nil
+}
+
+func (sc *stateCipher) deriveKey(salt []byte, n, r, p int) ([]byte, error) {
+	cacheKey := fmt.Sprintf("%x-%d-%d-%d", salt, n, r, p)
+	sc.keysLock.Lock()
+	defer sc.keysLock.Unlock()
+	if key, ok := sc.keys[cacheKey]; ok {
+		return key, nil
+	}
+	key, err := scrypt.Key(sc.passphrase, salt, n, r, p, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("fail to derive the key from passphrase: %w", err)
+	}
+	sc.keys[cacheKey] = key
+	return key, nil
+}
+
+func (sc *stateCipher) encrypt(plainText []byte) ([]byte, error) {
+	key, err := sc.deriveKey(sc.salt, scryptN, scryptR, scryptP)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("fail to generate nonce: %w", err)
+	}
+	envelope := encryptedState{
+		Version:    encryptedStateVersion,
+		KDF:        encryptedStateKDF,
+		N:          scryptN,
+		R:          scryptR,
+		P:          scryptP,
+		Salt:       sc.salt,
+		Cipher:     encryptedStateCipher,
+		Nonce:      nonce,
+		CipherText: aead.Seal(nil, nonce, plainText, nil),
+	}
+	return json.Marshal(envelope)
+}
+
+func (sc *stateCipher) decrypt(buf []byte) ([]byte, error) {
+	var envelope encryptedState
+	if err := json.Unmarshal(buf, &envelope); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal the encrypted local state: %w", err)
+	}
+	if envelope.Version != encryptedStateVersion || envelope.KDF != encryptedStateKDF || envelope.Cipher != encryptedStateCipher {
+		return nil, fmt.Errorf("unsupported encrypted local state (version %d, kdf %s, cipher %s)", envelope.Version, envelope.KDF, envelope.Cipher)
+	}
+	key, err := sc.deriveKey(envelope.Salt, envelope.N, envelope.R, envelope.P)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Nonce) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+	plainText, err := aead.Open(nil, envelope.Nonce, envelope.CipherText, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plainText, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create the block cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// isEncryptedState tells whether the given file content is an encrypted envelope rather than a plaintext KeygenLocalState
+func isEncryptedState(buf []byte) bool {
+	var probe struct {
+		CipherText []byte `json:"ciphertext"`
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(buf), &probe); err != nil {
+		return false
+	}
+	return len(probe.CipherText) > 0
+}
+
+// DecryptLocalState return the plaintext json of the given local state file content, plaintext content is returned as is
+func DecryptLocalState(buf, passphrase []byte) ([]byte, error) {
+	if !isEncryptedState(buf) {
+		return buf, nil
+	}
+	sc, err := newStateCipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return sc.decrypt(buf)
+}
@@ -0,0 +1,284 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	maddr "github.com/multiformats/go-multiaddr"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+const (
+	kvDatabaseVersion = 1
+	// kvOpenTimeout is how long we wait for the lock of a database file which is opened by another process
+	kvOpenTimeout = time.Second
+
+	kvBucketMeta        = "meta"
+	kvBucketLocalState  = "localstate"
+	kvBucketArchive     = "archive"
+	kvBucketAddressBook = "addressbook"
+	kvKeyVersion        = "version"
+	kvKeyAddressBook    = "seed"
+)
+
+// KVStateMgr save the local state of all the pools to a single bbolt database file, every write is a transaction,
+// so a crash never leaves a partially written key share behind.
+// The database file is locked while it is open, it has to be closed before another KVStateMgr can open it.
+type KVStateMgr struct {
+	db     *bolt.DB
+	cipher *stateCipher
+}
+
+// NewKVStateMgr open the database file at the given path, which is created if it doesn't exist, and return a
+// KVStateMgr which implements LocalStateManager
+func NewKVStateMgr(path string) (*KVStateMgr, error) {
+	if len(path) == 0 {
+		return nil, errors.New("database path is empty")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, localStateFileMode, &bolt.Options{Timeout: kvOpenTimeout})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, fmt.Errorf("the database file(%s) is opened by another process", path)
+		}
+		return nil, fmt.Errorf("fail to open the database file(%s): %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists([]byte(kvBucketMeta))
+		if err != nil {
+			return err
+		}
+		if value := meta.Get([]byte(kvKeyVersion)); value != nil {
+			version, err := strconv.Atoi(string(value))
+			if err != nil || version != kvDatabaseVersion {
+				return fmt.Errorf("unsupported database version %s", value)
+			}
+		} else if err := meta.Put([]byte(kvKeyVersion), []byte(strconv.Itoa(kvDatabaseVersion))); err != nil {
+			return err
+		}
+		for _, name := range []string{kvBucketLocalState, kvBucketArchive, kvBucketAddressBook} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("fail to initialise the database file(%s): %w", path, err)
+	}
+	return &KVStateMgr{
+		db: db,
+	}, nil
+}
+
+// NewEncryptedKVStateMgr open the database file like NewKVStateMgr, the local states are encrypted with a key derived
+// from the given passphrase, the existing plaintext local states are encrypted in place
+func NewEncryptedKVStateMgr(path string, passphrase []byte) (*KVStateMgr, error) {
+	sc, err := newStateCipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	kv, err := NewKVStateMgr(path)
+	if err != nil {
+		return nil, err
+	}
+	kv.cipher = sc
+	err = kv.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(kvBucketLocalState))
+		plaintext := make(map[string][]byte)
+		err := bucket.ForEach(func(key, value []byte) error {
+			if !isEncryptedState(value) {
+				plaintext[string(key)] = value
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// the bucket must not be modified while we iterate over it
+		for key, value := range plaintext {
+			encrypted, err := sc.encrypt(value)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(key), encrypted); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = kv.Close()
+		return nil, fmt.Errorf("fail to encrypt the existing local state: %w", err)
+	}
+	return kv, nil
+}
+
+// Close closes the database file and releases its lock
+func (kv *KVStateMgr) Close() error {
+	return kv.db.Close()
+}
+
+// SaveLocalState save the local state to the database
+func (kv *KVStateMgr) SaveLocalState(state KeygenLocalState, algo messages.Algo) error {
+	key, err := localStateKey(state.PubKey, algo)
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("fail to marshal KeygenLocalState to json: %w", err)
+	}
+	if kv.cipher != nil {
+		buf, err = kv.cipher.encrypt(buf)
+		if err != nil {
+			return fmt.Errorf("fail to encrypt KeygenLocalState: %w", err)
+		}
+	}
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(kvBucketLocalState)).Put([]byte(key), buf)
+	})
+}
+
+// get returns a copy of the value, as the values of bbolt are only valid during the transaction
+func (kv *KVStateMgr) get(bucket, key string) ([]byte, error) {
+	var buf []byte
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		if value := tx.Bucket([]byte(bucket)).Get([]byte(key)); value != nil {
+			buf = append([]byte{}, value...)
+		}
+		return nil
+	})
+	return buf, err
+}
+
+// GetLocalState read the local state from the database
+func (kv *KVStateMgr) GetLocalState(pubKey string, algo messages.Algo) (KeygenLocalState, error) {
+	if len(pubKey) == 0 {
+		return KeygenLocalState{}, errors.New("pub key is empty")
+	}
+	key, err := localStateKey(pubKey, algo)
+	if err != nil {
+		return KeygenLocalState{}, err
+	}
+	buf, err := kv.get(kvBucketLocalState, key)
+	if err != nil {
+		return KeygenLocalState{}, err
+	}
+	if buf == nil {
+		return KeygenLocalState{}, fmt.Errorf("local state of pool %s: %w", pubKey, os.ErrNotExist)
+	}
+	localState, err := kv.decodeLocalState(buf)
+	if err != nil {
+		return KeygenLocalState{}, err
+	}
+	if localState.PubKey != pubKey {
+		return KeygenLocalState{}, fmt.Errorf("local state of pool %s holds pub key %s", pubKey, localState.PubKey)
+	}
+	return localState, nil
+}
+
+func (kv *KVStateMgr) decodeLocalState(buf []byte) (KeygenLocalState, error) {
+	var err error
+	if isEncryptedState(buf) {
+		if kv.cipher == nil {
+			return KeygenLocalState{}, errors.New("local state is encrypted, but no passphrase is given")
+		}
+		buf, err = kv.cipher.decrypt(buf)
+		if err != nil {
+			return KeygenLocalState{}, err
+		}
+	}
+	var localState KeygenLocalState
+	if err := json.Unmarshal(buf, &localState); nil != err {
+		return KeygenLocalState{}, fmt.Errorf("fail to unmarshal KeygenLocalState: %w", err)
+	}
+	return localState, nil
+}
+
+// ListLocalStates read the local state of all the pools from the database
+func (kv *KVStateMgr) ListLocalStates() ([]KeygenLocalState, error) {
+	var states []KeygenLocalState
+	err := kv.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(kvBucketLocalState)).ForEach(func(_, value []byte) error {
+			localState, err := kv.decodeLocalState(value)
+			if err != nil {
+				return err
+			}
+			states = append(states, localState)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if states == nil {
+		states = []KeygenLocalState{}
+	}
+	sortLocalStates(states)
+	return states, nil
+}
+
+// DeleteLocalState removes the local state of the given pool from the database, the key share isn't wiped, bbolt
+// copies the pages it writes, so its bytes stay in the free pages of the file until they are reused
+func (kv *KVStateMgr) DeleteLocalState(pubKey string, algo messages.Algo) error {
+	return kv.removeLocalState(pubKey, algo, false)
+}
+
+// ArchiveLocalState moves the local state of the given pool to the archive bucket of the database
+func (kv *KVStateMgr) ArchiveLocalState(pubKey string, algo messages.Algo) error {
+	return kv.removeLocalState(pubKey, algo, true)
+}
+
+func (kv *KVStateMgr) removeLocalState(pubKey string, algo messages.Algo, archive bool) error {
+	key, err := localStateKey(pubKey, algo)
+	if err != nil {
+		return err
+	}
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(kvBucketLocalState))
+		value := bucket.Get([]byte(key))
+		if value == nil {
+			return fmt.Errorf("local state of pool %s: %w", pubKey, os.ErrNotExist)
+		}
+		if archive {
+			archiveKey := fmt.Sprintf("%s-%d", key, time.Now().UnixNano())
+			if err := tx.Bucket([]byte(kvBucketArchive)).Put([]byte(archiveKey), value); err != nil {
+				return err
+			}
+		}
+		return bucket.Delete([]byte(key))
+	})
+}
+
+func (kv *KVStateMgr) SaveAddressBook(address map[peer.ID][]maddr.Multiaddr) error {
+	buf, err := encodeAddressBook(address)
+	if err != nil {
+		return err
+	}
+	return kv.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(kvBucketAddressBook)).Put([]byte(kvKeyAddressBook), buf)
+	})
+}
+
+func (kv *KVStateMgr) RetrieveP2PAddresses() ([]maddr.Multiaddr, error) {
+	buf, err := kv.get(kvBucketAddressBook, kvKeyAddressBook)
+	if err != nil {
+		return nil, err
+	}
+	if buf == nil {
+		return nil, fmt.Errorf("address book: %w", os.ErrNotExist)
+	}
+	return decodeAddressBook(buf)
+}
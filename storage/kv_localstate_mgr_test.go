@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+type KVStateMgrTestSuite struct{}
+
+var _ = Suite(&KVStateMgrTestSuite{})
+
+func (s *KVStateMgrTestSuite) TestNewKVStateMgr(c *C) {
+	_, err := NewKVStateMgr("")
+	c.Assert(err, NotNil)
+
+	dbPath := filepath.Join(c.MkDir(), "db", "localstate.db")
+	kv, err := NewKVStateMgr(dbPath)
+	c.Assert(err, IsNil)
+	info, err := os.Stat(dbPath)
+	c.Assert(err, IsNil)
+	c.Assert(info.Mode().Perm(), Equals, os.FileMode(localStateFileMode))
+
+	// the database file is locked while it is open
+	_, err = NewKVStateMgr(dbPath)
+	c.Assert(err, NotNil)
+	c.Assert(kv.Close(), IsNil)
+	kv, err = NewKVStateMgr(dbPath)
+	c.Assert(err, IsNil)
+
+	c.Assert(kv.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(kvBucketMeta)).Put([]byte(kvKeyVersion), []byte("99"))
+	}), IsNil)
+	c.Assert(kv.Close(), IsNil)
+	_, err = NewKVStateMgr(dbPath)
+	c.Assert(err, NotNil)
+
+	c.Assert(ioutil.WriteFile(dbPath, []byte("not a database"), localStateFileMode), IsNil)
+	_, err = NewKVStateMgr(dbPath)
+	c.Assert(err, NotNil)
+}
+
+func (s *KVStateMgrTestSuite) TestFailedTransaction(c *C) {
+	dbPath := filepath.Join(c.MkDir(), "localstate.db")
+	kv, err := NewKVStateMgr(dbPath)
+	c.Assert(err, IsNil)
+	defer kv.Close()
+	state := testLocalState()
+	c.Assert(kv.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+
+	key, err := localStateKey(state.PubKey, messages.ECDSAKEYGEN)
+	c.Assert(err, IsNil)
+	err = kv.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket([]byte(kvBucketLocalState)).Put([]byte(key), []byte("garbage")); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	})
+	c.Assert(err, NotNil)
+
+	loaded, err := kv.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+}
+
+func (s *KVStateMgrTestSuite) TestEncryptedKVStateMgr(c *C) {
+	dbPath := filepath.Join(c.MkDir(), "localstate.db")
+	plainKv, err := NewKVStateMgr(dbPath)
+	c.Assert(err, IsNil)
+	state := testLocalState()
+	c.Assert(plainKv.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+	c.Assert(plainKv.Close(), IsNil)
+
+	kv, err := NewEncryptedKVStateMgr(dbPath, []byte("passphrase"))
+	c.Assert(err, IsNil)
+	key, err := localStateKey(state.PubKey, messages.ECDSAKEYGEN)
+	c.Assert(err, IsNil)
+	buf, err := kv.get(kvBucketLocalState, key)
+	c.Assert(err, IsNil)
+	c.Assert(isEncryptedState(buf), Equals, true)
+	loaded, err := kv.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+	c.Assert(kv.Close(), IsNil)
+
+	reopened, err := NewKVStateMgr(dbPath)
+	c.Assert(err, IsNil)
+	_, err = reopened.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, NotNil)
+	c.Assert(reopened.Close(), IsNil)
+
+	wrongKv, err := NewEncryptedKVStateMgr(dbPath, []byte("wrong"))
+	c.Assert(err, IsNil)
+	_, err = wrongKv.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, Equals, ErrWrongPassphrase)
+	c.Assert(wrongKv.Close(), IsNil)
+
+	_, err = NewEncryptedKVStateMgr(dbPath, nil)
+	c.Assert(err, NotNil)
+}
@@ -11,8 +11,10 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/HyperCore-Team/go-tss/messages"
 
@@ -26,6 +28,36 @@ type KeygenLocalState struct {
 	LocalData       []byte   `json:"local_data"`
 	ParticipantKeys []string `json:"participant_keys"` // the paticipant of last key gen
 	LocalPartyKey   string   `json:"local_party_key"`
+	// Threshold of the pool, it is zero for the pools created before the threshold was configurable
+	Threshold int `json:"threshold,omitempty"`
+	// RetirementPolicy is set once this node left the pool in a regroup, the key share is retired with this policy
+	// when the regroup is confirmed
+	RetirementPolicy string `json:"retirement_policy,omitempty"`
+	// RetireAt is the unix time the key share is retired at without a confirmation, zero waits for the confirmation
+	RetireAt int64 `json:"retire_at,omitempty"`
+	// ChainCode of the ECDSA pool, the child keys are derived with it, the keygen takes it from the key shares and the
+	// regroup keeps it, it is empty for the pools created before the key derivation
+	ChainCode []byte `json:"chain_code,omitempty"`
+	// Algo of the pool when the pub key alone doesn't tell it, the x-only keys of the schnorr pools have the same
+	// length as the eddsa ones
+	Algo string `json:"algo,omitempty"`
+}
+
+// AlgoSchnorr marks the key shares of the schnorr pools
+const AlgoSchnorr = "schnorr"
+
+// GetThreshold returns the threshold of the pool, the pools without a stored threshold use the default one
+func (s KeygenLocalState) GetThreshold() (int, error) {
+	return conversion.ResolveThreshold(s.Threshold, len(s.ParticipantKeys))
+}
+
+// GetChainCode returns the chain code of the ECDSA pool, the pools created before the key derivation have no stored
+// chain code, theirs is computed from the pub key, so their child keys stay the same
+func (s KeygenLocalState) GetChainCode() ([]byte, error) {
+	if len(s.ChainCode) > 0 {
+		return s.ChainCode, nil
+	}
+	return conversion.ChainCodeFromPubKey(s.PubKey)
 }
 
 // LocalStateManager provide necessary methods to manage the local state, save it , and read it back
@@ -35,12 +67,24 @@ type LocalStateManager interface {
 	GetLocalState(pubKey string, algo messages.Algo) (KeygenLocalState, error)
 	SaveAddressBook(addressBook map[peer.ID][]maddr.Multiaddr) error
 	RetrieveP2PAddresses() ([]maddr.Multiaddr, error)
+	// ListLocalStates returns the local state of all the pools this node holds a key share of
+	ListLocalStates() ([]KeygenLocalState, error)
+	// DeleteLocalState removes the key share of the given pool for good
+	DeleteLocalState(pubKey string, algo messages.Algo) error
+	// ArchiveLocalState moves the key share of the given pool out of the active pools, it is kept for recovery only
+	ArchiveLocalState(pubKey string, algo messages.Algo) error
 }
 
+const (
+	localStateFileMode = 0o600
+	archiveFolderName  = "archive"
+)
+
 // FileStateMgr save the local state to file
 type FileStateMgr struct {
 	folder    string
 	writeLock *sync.RWMutex
+	cipher    *stateCipher
 }
 
 // NewFileStateMgr create a new instance of the FileStateMgr which implements LocalStateManager
@@ -59,7 +103,27 @@ func NewFileStateMgr(folder string) (*FileStateMgr, error) {
 	}, nil
 }
 
-func (fsm *FileStateMgr) getFilePathName(pubKey string, algo messages.Algo) (string, error) {
+// NewEncryptedFileStateMgr create a new instance of the FileStateMgr which encrypts the local state with a key derived from
+// the given passphrase, the existing plaintext local state files in the folder are encrypted in place
+func NewEncryptedFileStateMgr(folder string, passphrase []byte) (*FileStateMgr, error) {
+	fsm, err := NewFileStateMgr(folder)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := newStateCipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	fsm.cipher = sc
+	if err := fsm.migrateLocalStates(); err != nil {
+		return nil, fmt.Errorf("fail to encrypt the existing local state: %w", err)
+	}
+	return fsm, nil
+}
+
+// localStateKey validates the given pool pub key and returns its hex encoding, which is used by all the
+// LocalStateManager implementations to identify the local state of the pool
+func localStateKey(pubKey string, algo messages.Algo) (string, error) {
 	ret, err := conversion.CheckKeyOnCurve(pubKey, algo)
 	if err != nil {
 		return "", err
@@ -71,7 +135,14 @@ func (fsm *FileStateMgr) getFilePathName(pubKey string, algo messages.Algo) (str
 	if err != nil {
 		return "", err
 	}
-	hx := hex.EncodeToString(pubKeyBytes)
+	return hex.EncodeToString(pubKeyBytes), nil
+}
+
+func (fsm *FileStateMgr) getFilePathName(pubKey string, algo messages.Algo) (string, error) {
+	hx, err := localStateKey(pubKey, algo)
+	if err != nil {
+		return "", err
+	}
 	localFileName := fmt.Sprintf("localstate-%s.json", hx)
 	if len(fsm.folder) > 0 {
 		return filepath.Join(fsm.folder, localFileName), nil
@@ -81,15 +152,114 @@ func (fsm *FileStateMgr) getFilePathName(pubKey string, algo messages.Algo) (str
 
 // SaveLocalState save the local state to file
 func (fsm *FileStateMgr) SaveLocalState(state KeygenLocalState, algo messages.Algo) error {
+	filePathName, err := fsm.getFilePathName(state.PubKey, algo)
+	if err != nil {
+		return err
+	}
+	fsm.writeLock.Lock()
+	defer fsm.writeLock.Unlock()
+	return fsm.writeLocalState(filePathName, state)
+}
+
+// writeLocalState replaces the local state file atomically, so a crash never leaves a partially written key share behind
+func (fsm *FileStateMgr) writeLocalState(filePathName string, state KeygenLocalState) error {
 	buf, err := json.Marshal(state)
 	if err != nil {
 		return fmt.Errorf("fail to marshal KeygenLocalState to json: %w", err)
 	}
-	filePathName, err := fsm.getFilePathName(state.PubKey, algo)
+	if fsm.cipher != nil {
+		buf, err = fsm.cipher.encrypt(buf)
+		if err != nil {
+			return fmt.Errorf("fail to encrypt KeygenLocalState: %w", err)
+		}
+	}
+	return writeFileAtomic(filePathName, buf)
+}
+
+// writeFileAtomic writes the data to a temporary file which then replaces the given file, the file is only readable by us
+func writeFileAtomic(filePathName string, data []byte) error {
+	dir := filepath.Dir(filePathName)
+	f, err := ioutil.TempFile(dir, filepath.Base(filePathName)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("fail to create the temporary file: %w", err)
+	}
+	tmpName := f.Name()
+	defer func() {
+		// it is a no-op once the file is renamed
+		_ = os.Remove(tmpName)
+	}()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("fail to write the temporary file: %w", err)
+	}
+	if err := f.Chmod(localStateFileMode); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("fail to sync the temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, filePathName); err != nil {
+		return fmt.Errorf("fail to replace the file(%s): %w", filePathName, err)
+	}
+	// sync the folder as well, so the rename survives a crash
+	d, err := os.Open(dir)
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(filePathName, buf, 0o655)
+	defer d.Close()
+	return d.Sync()
+}
+
+// readLocalState read the local state from the given file, it reports whether the file is still in plaintext
+func (fsm *FileStateMgr) readLocalState(filePathName string) (KeygenLocalState, bool, error) {
+	buf, err := ioutil.ReadFile(filePathName)
+	if err != nil {
+		return KeygenLocalState{}, false, fmt.Errorf("file to read from file(%s): %w", filePathName, err)
+	}
+	encrypted := isEncryptedState(buf)
+	if encrypted {
+		if fsm.cipher == nil {
+			return KeygenLocalState{}, false, fmt.Errorf("local state file(%s) is encrypted, but no passphrase is given", filePathName)
+		}
+		buf, err = fsm.cipher.decrypt(buf)
+		if err != nil {
+			return KeygenLocalState{}, false, err
+		}
+	}
+	var localState KeygenLocalState
+	if err := json.Unmarshal(buf, &localState); nil != err {
+		return KeygenLocalState{}, false, fmt.Errorf("fail to unmarshal KeygenLocalState: %w", err)
+	}
+	return localState, !encrypted, nil
+}
+
+// migrateLocalStates encrypts all the plaintext local state files in the folder, it is only done when the manager is
+// created, so reading a local state never rewrites the file
+func (fsm *FileStateMgr) migrateLocalStates() error {
+	fsm.writeLock.Lock()
+	defer fsm.writeLock.Unlock()
+	files, err := filepath.Glob(filepath.Join(fsm.folder, "localstate-*.json"))
+	if err != nil {
+		return err
+	}
+	for _, el := range files {
+		localState, plaintext, err := fsm.readLocalState(el)
+		if err != nil {
+			return err
+		}
+		if !plaintext {
+			continue
+		}
+		if err := fsm.writeLocalState(el, localState); err != nil {
+			return fmt.Errorf("fail to encrypt the plaintext local state file(%s): %w", el, err)
+		}
+	}
+	return nil
 }
 
 // GetLocalState read the local state from file system
@@ -104,25 +274,107 @@ func (fsm *FileStateMgr) GetLocalState(pubKey string, algo messages.Algo) (Keyge
 	if _, err := os.Stat(filePathName); os.IsNotExist(err) {
 		return KeygenLocalState{}, err
 	}
-
-	buf, err := ioutil.ReadFile(filePathName)
+	localState, _, err := fsm.readLocalState(filePathName)
 	if err != nil {
-		return KeygenLocalState{}, fmt.Errorf("file to read from file(%s): %w", filePathName, err)
+		return KeygenLocalState{}, err
 	}
-	var localState KeygenLocalState
-	if err := json.Unmarshal(buf, &localState); nil != err {
-		return KeygenLocalState{}, fmt.Errorf("fail to unmarshal KeygenLocalState: %w", err)
+	if localState.PubKey != pubKey {
+		return KeygenLocalState{}, fmt.Errorf("local state file(%s) holds pub key %s rather than %s", filePathName, localState.PubKey, pubKey)
 	}
 	return localState, nil
 }
 
+// ListLocalStates read the local state of all the pools from the folder
+func (fsm *FileStateMgr) ListLocalStates() ([]KeygenLocalState, error) {
+	files, err := filepath.Glob(filepath.Join(fsm.folder, "localstate-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	states := make([]KeygenLocalState, 0, len(files))
+	for _, el := range files {
+		localState, _, err := fsm.readLocalState(el)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, localState)
+	}
+	sortLocalStates(states)
+	return states, nil
+}
+
+// DeleteLocalState overwrites the local state file of the given pool with zeros before removing it
+func (fsm *FileStateMgr) DeleteLocalState(pubKey string, algo messages.Algo) error {
+	filePathName, err := fsm.getFilePathName(pubKey, algo)
+	if err != nil {
+		return err
+	}
+	fsm.writeLock.Lock()
+	defer fsm.writeLock.Unlock()
+	info, err := os.Stat(filePathName)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filePathName, os.O_WRONLY, localStateFileMode)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(make([]byte, info.Size()))
+	if err == nil {
+		err = f.Sync()
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return fmt.Errorf("fail to wipe the local state file(%s): %w", filePathName, err)
+	}
+	return os.Remove(filePathName)
+}
+
+// ArchiveLocalState moves the local state file of the given pool to the archive folder
+func (fsm *FileStateMgr) ArchiveLocalState(pubKey string, algo messages.Algo) error {
+	filePathName, err := fsm.getFilePathName(pubKey, algo)
+	if err != nil {
+		return err
+	}
+	fsm.writeLock.Lock()
+	defer fsm.writeLock.Unlock()
+	if _, err := os.Stat(filePathName); err != nil {
+		return err
+	}
+	archiveFolder := filepath.Join(fsm.folder, archiveFolderName)
+	if err := os.MkdirAll(archiveFolder, 0o700); err != nil {
+		return err
+	}
+	fileName := strings.TrimSuffix(filepath.Base(filePathName), ".json")
+	archivePathName := filepath.Join(archiveFolder, fmt.Sprintf("%s-%d.json", fileName, time.Now().UnixNano()))
+	return os.Rename(filePathName, archivePathName)
+}
+
+// sortLocalStates sorts the local states by the pool pub key, so all the implementations list the pools in the same order
+func sortLocalStates(states []KeygenLocalState) {
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].PubKey < states[j].PubKey
+	})
+}
+
 func (fsm *FileStateMgr) SaveAddressBook(address map[peer.ID][]maddr.Multiaddr) error {
 	if len(fsm.folder) < 1 {
 		return errors.New("base file path is invalid")
 	}
 	filePathName := filepath.Join(fsm.folder, "address_book.seed")
-	var buf bytes.Buffer
+	buf, err := encodeAddressBook(address)
+	if err != nil {
+		return err
+	}
+	fsm.writeLock.Lock()
+	defer fsm.writeLock.Unlock()
+	return ioutil.WriteFile(filePathName, buf, 0o655)
+}
 
+// encodeAddressBook serialises the address book as one multiaddr per line
+func encodeAddressBook(address map[peer.ID][]maddr.Multiaddr) ([]byte, error) {
+	var buf bytes.Buffer
 	for peer, addrs := range address {
 		for _, addr := range addrs {
 			// we do not save the loopback addr
@@ -132,13 +384,29 @@ func (fsm *FileStateMgr) SaveAddressBook(address map[peer.ID][]maddr.Multiaddr)
 			record := addr.String() + "/p2p/" + peer.String() + "\n"
 			_, err := buf.WriteString(record)
 			if err != nil {
-				return errors.New("fail to write the record to buffer")
+				return nil, errors.New("fail to write the record to buffer")
 			}
 		}
 	}
-	fsm.writeLock.Lock()
-	defer fsm.writeLock.Unlock()
-	return ioutil.WriteFile(filePathName, buf.Bytes(), 0o655)
+	return buf.Bytes(), nil
+}
+
+// decodeAddressBook parses the address book written by encodeAddressBook
+func decodeAddressBook(input []byte) ([]maddr.Multiaddr, error) {
+	data := strings.Split(string(input), "\n")
+	var peerAddresses []maddr.Multiaddr
+	for _, el := range data {
+		// we skip the empty entry
+		if len(el) == 0 {
+			continue
+		}
+		addr, err := maddr.NewMultiaddr(el)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address in address book %w", err)
+		}
+		peerAddresses = append(peerAddresses, addr)
+	}
+	return peerAddresses, nil
 }
 
 func (fsm *FileStateMgr) RetrieveP2PAddresses() ([]maddr.Multiaddr, error) {
@@ -158,18 +426,5 @@ func (fsm *FileStateMgr) RetrieveP2PAddresses() ([]maddr.Multiaddr, error) {
 		return nil, err
 	}
 	fsm.writeLock.RUnlock()
-	data := strings.Split(string(input), "\n")
-	var peerAddresses []maddr.Multiaddr
-	for _, el := range data {
-		// we skip the empty entry
-		if len(el) == 0 {
-			continue
-		}
-		addr, err := maddr.NewMultiaddr(el)
-		if err != nil {
-			return nil, fmt.Errorf("invalid address in address book %w", err)
-		}
-		peerAddresses = append(peerAddresses, addr)
-	}
-	return peerAddresses, nil
+	return decodeAddressBook(input)
 }
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+const testPoolPubKey = "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq"
+
+func TestPackage(t *testing.T) { TestingT(t) }
+
+type FileStateMgrTestSuite struct{}
+
+var _ = Suite(&FileStateMgrTestSuite{})
+
+func testLocalState() KeygenLocalState {
+	return KeygenLocalState{
+		PubKey:          testPoolPubKey,
+		LocalData:       []byte(`{"Xi":"secret share"}`),
+		ParticipantKeys: []string{"A", "B", "C"},
+		LocalPartyKey:   "A",
+	}
+}
+
+func (s *FileStateMgrTestSuite) TestEncryptedFileStateMgr(c *C) {
+	folder := c.MkDir()
+	fsm, err := NewEncryptedFileStateMgr(folder, []byte("passphrase"))
+	c.Assert(err, IsNil)
+	state := testLocalState()
+	c.Assert(fsm.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+
+	filePathName, err := fsm.getFilePathName(state.PubKey, messages.ECDSAKEYGEN)
+	c.Assert(err, IsNil)
+	info, err := os.Stat(filePathName)
+	c.Assert(err, IsNil)
+	c.Assert(info.Mode().Perm(), Equals, os.FileMode(localStateFileMode))
+	buf, err := ioutil.ReadFile(filePathName)
+	c.Assert(err, IsNil)
+	c.Assert(isEncryptedState(buf), Equals, true)
+	var plain KeygenLocalState
+	c.Assert(json.Unmarshal(buf, &plain), IsNil)
+	c.Assert(plain.LocalData, IsNil)
+
+	loaded, err := fsm.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+
+	// a plaintext manager can't read the encrypted share
+	plainFsm, err := NewFileStateMgr(folder)
+	c.Assert(err, IsNil)
+	_, err = plainFsm.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, NotNil)
+
+	// neither can a manager with the wrong passphrase
+	wrongFsm, err := NewEncryptedFileStateMgr(c.MkDir(), []byte("wrong"))
+	c.Assert(err, IsNil)
+	wrongFsm.folder = folder
+	_, err = wrongFsm.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, Equals, ErrWrongPassphrase)
+
+	_, err = NewEncryptedFileStateMgr(folder, nil)
+	c.Assert(err, NotNil)
+}
+
+func (s *FileStateMgrTestSuite) TestMigratePlaintextLocalState(c *C) {
+	folder := c.MkDir()
+	state := testLocalState()
+	plainFsm, err := NewFileStateMgr(folder)
+	c.Assert(err, IsNil)
+	c.Assert(plainFsm.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+	filePathName, err := plainFsm.getFilePathName(state.PubKey, messages.ECDSAKEYGEN)
+	c.Assert(err, IsNil)
+	// the share saved by the old version is readable by everyone
+	c.Assert(os.Chmod(filePathName, 0o655), IsNil)
+
+	fsm, err := NewEncryptedFileStateMgr(folder, []byte("passphrase"))
+	c.Assert(err, IsNil)
+	buf, err := ioutil.ReadFile(filePathName)
+	c.Assert(err, IsNil)
+	c.Assert(isEncryptedState(buf), Equals, true)
+	info, err := os.Stat(filePathName)
+	c.Assert(err, IsNil)
+	c.Assert(info.Mode().Perm(), Equals, os.FileMode(localStateFileMode))
+
+	loaded, err := fsm.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+
+	decrypted, err := DecryptLocalState(buf, []byte("passphrase"))
+	c.Assert(err, IsNil)
+	var fromFile KeygenLocalState
+	c.Assert(json.Unmarshal(decrypted, &fromFile), IsNil)
+	c.Assert(fromFile, DeepEquals, state)
+}
+
+func (s *FileStateMgrTestSuite) TestReadDoesNotRewriteLocalState(c *C) {
+	folder := c.MkDir()
+	fsm, err := NewEncryptedFileStateMgr(folder, []byte("passphrase"))
+	c.Assert(err, IsNil)
+	// a plaintext share showing up after the migration is only read
+	plainFsm, err := NewFileStateMgr(folder)
+	c.Assert(err, IsNil)
+	state := testLocalState()
+	c.Assert(plainFsm.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+	filePathName, err := plainFsm.getFilePathName(state.PubKey, messages.ECDSAKEYGEN)
+	c.Assert(err, IsNil)
+
+	loaded, err := fsm.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	c.Assert(loaded, DeepEquals, state)
+	states, err := fsm.ListLocalStates()
+	c.Assert(err, IsNil)
+	c.Assert(states, HasLen, 1)
+	buf, err := ioutil.ReadFile(filePathName)
+	c.Assert(err, IsNil)
+	c.Assert(isEncryptedState(buf), Equals, false)
+	// no temporary file is left behind by the writes
+	files, err := filepath.Glob(filepath.Join(folder, "*.tmp-*"))
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0)
+}
+
+func (s *FileStateMgrTestSuite) TestArchiveLocalState(c *C) {
+	folder := c.MkDir()
+	fsm, err := NewFileStateMgr(folder)
+	c.Assert(err, IsNil)
+	state := testLocalState()
+	c.Assert(fsm.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+	c.Assert(fsm.ArchiveLocalState(state.PubKey, messages.ECDSAKEYGEN), IsNil)
+
+	files, err := filepath.Glob(filepath.Join(folder, archiveFolderName, "localstate-*.json"))
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 1)
+	loaded, plaintext, err := fsm.readLocalState(files[0])
+	c.Assert(err, IsNil)
+	c.Assert(plaintext, Equals, true)
+	c.Assert(loaded, DeepEquals, state)
+}
+
+func (s *FileStateMgrTestSuite) TestGetThreshold(c *C) {
+	state := testLocalState()
+	// the pools created before the threshold is stored use the default threshold
+	threshold, err := state.GetThreshold()
+	c.Assert(err, IsNil)
+	c.Assert(threshold, Equals, 1)
+
+	state.ParticipantKeys = []string{"A", "B", "C", "D", "E"}
+	state.Threshold = 4
+	fsm, err := NewFileStateMgr(c.MkDir())
+	c.Assert(err, IsNil)
+	c.Assert(fsm.SaveLocalState(state, messages.ECDSAKEYGEN), IsNil)
+	loaded, err := fsm.GetLocalState(state.PubKey, messages.ECDSAKEYSIGN)
+	c.Assert(err, IsNil)
+	threshold, err = loaded.GetThreshold()
+	c.Assert(err, IsNil)
+	c.Assert(threshold, Equals, 4)
+
+	state.Threshold = 5
+	_, err = state.GetThreshold()
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	maddr "github.com/multiformats/go-multiaddr"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+// MemStateMgr keeps the local state in memory, it is meant for tests and nodes that don't need to survive a restart
+type MemStateMgr struct {
+	lock        *sync.RWMutex
+	states      map[string]KeygenLocalState
+	archived    map[string][]KeygenLocalState
+	addressBook []byte
+}
+
+// NewMemStateMgr create a new instance of the MemStateMgr which implements LocalStateManager
+func NewMemStateMgr() *MemStateMgr {
+	return &MemStateMgr{
+		lock:     &sync.RWMutex{},
+		states:   make(map[string]KeygenLocalState),
+		archived: make(map[string][]KeygenLocalState),
+	}
+}
+
+// copyLocalState returns a deep copy of the given state, so the callers can't modify what we hold
+func copyLocalState(state KeygenLocalState) KeygenLocalState {
+	cp := state
+	if state.LocalData != nil {
+		cp.LocalData = append([]byte{}, state.LocalData...)
+	}
+	if state.ParticipantKeys != nil {
+		cp.ParticipantKeys = append([]string{}, state.ParticipantKeys...)
+	}
+	return cp
+}
+
+// SaveLocalState save the local state in memory
+func (msm *MemStateMgr) SaveLocalState(state KeygenLocalState, algo messages.Algo) error {
+	key, err := localStateKey(state.PubKey, algo)
+	if err != nil {
+		return err
+	}
+	msm.lock.Lock()
+	defer msm.lock.Unlock()
+	msm.states[key] = copyLocalState(state)
+	return nil
+}
+
+// GetLocalState read the local state from memory
+func (msm *MemStateMgr) GetLocalState(pubKey string, algo messages.Algo) (KeygenLocalState, error) {
+	if len(pubKey) == 0 {
+		return KeygenLocalState{}, fmt.Errorf("pub key is empty")
+	}
+	key, err := localStateKey(pubKey, algo)
+	if err != nil {
+		return KeygenLocalState{}, err
+	}
+	msm.lock.RLock()
+	defer msm.lock.RUnlock()
+	state, ok := msm.states[key]
+	if !ok {
+		return KeygenLocalState{}, fmt.Errorf("local state of pool %s: %w", pubKey, os.ErrNotExist)
+	}
+	return copyLocalState(state), nil
+}
+
+// ListLocalStates returns the local state of all the pools held in memory
+func (msm *MemStateMgr) ListLocalStates() ([]KeygenLocalState, error) {
+	msm.lock.RLock()
+	defer msm.lock.RUnlock()
+	states := make([]KeygenLocalState, 0, len(msm.states))
+	for _, state := range msm.states {
+		states = append(states, copyLocalState(state))
+	}
+	sortLocalStates(states)
+	return states, nil
+}
+
+// DeleteLocalState removes the local state of the given pool from memory
+func (msm *MemStateMgr) DeleteLocalState(pubKey string, algo messages.Algo) error {
+	return msm.removeLocalState(pubKey, algo, false)
+}
+
+// ArchiveLocalState moves the local state of the given pool to the archived states
+func (msm *MemStateMgr) ArchiveLocalState(pubKey string, algo messages.Algo) error {
+	return msm.removeLocalState(pubKey, algo, true)
+}
+
+func (msm *MemStateMgr) removeLocalState(pubKey string, algo messages.Algo, archive bool) error {
+	key, err := localStateKey(pubKey, algo)
+	if err != nil {
+		return err
+	}
+	msm.lock.Lock()
+	defer msm.lock.Unlock()
+	state, ok := msm.states[key]
+	if !ok {
+		return fmt.Errorf("local state of pool %s: %w", pubKey, os.ErrNotExist)
+	}
+	if archive {
+		msm.archived[key] = append(msm.archived[key], state)
+	}
+	delete(msm.states, key)
+	return nil
+}
+
+func (msm *MemStateMgr) SaveAddressBook(address map[peer.ID][]maddr.Multiaddr) error {
+	buf, err := encodeAddressBook(address)
+	if err != nil {
+		return err
+	}
+	msm.lock.Lock()
+	defer msm.lock.Unlock()
+	msm.addressBook = buf
+	return nil
+}
+
+func (msm *MemStateMgr) RetrieveP2PAddresses() ([]maddr.Multiaddr, error) {
+	msm.lock.RLock()
+	defer msm.lock.RUnlock()
+	if msm.addressBook == nil {
+		return nil, fmt.Errorf("address book: %w", os.ErrNotExist)
+	}
+	return decodeAddressBook(msm.addressBook)
+}
@@ -25,3 +25,15 @@ func (s *MockLocalStateManager) SaveAddressBook(address map[peer.ID][]maddr.Mult
 func (s *MockLocalStateManager) RetrieveP2PAddresses() ([]maddr.Multiaddr, error) {
 	return nil, nil
 }
+
+func (s *MockLocalStateManager) ListLocalStates() ([]KeygenLocalState, error) {
+	return nil, nil
+}
+
+func (s *MockLocalStateManager) DeleteLocalState(pubKey string, algo messages.Algo) error {
+	return nil
+}
+
+func (s *MockLocalStateManager) ArchiveLocalState(pubKey string, algo messages.Algo) error {
+	return nil
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const preParamsFileSuffix = ".preparams"
+
+// PreParamsItem is a set of pre-parameters persisted by the PreParamsStore, the data is opaque to the store
+type PreParamsItem struct {
+	ID   string
+	Data []byte
+}
+
+// PreParamsStore persists the pre-parameters generated ahead of the ECDSA keygens, each set is stored in its own file,
+// so handing one to a keygen never rewrites the others
+type PreParamsStore struct {
+	folder string
+	cipher *stateCipher
+}
+
+// NewPreParamsStore create a new instance of the PreParamsStore which keeps the pre-parameters in the given folder
+func NewPreParamsStore(folder string) (*PreParamsStore, error) {
+	if len(folder) == 0 {
+		return nil, errors.New("pre-parameters folder is empty")
+	}
+	if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return &PreParamsStore{
+		folder: folder,
+	}, nil
+}
+
+// NewEncryptedPreParamsStore create a new instance of the PreParamsStore which encrypts the pre-parameters with a key
+// derived from the given passphrase
+func NewEncryptedPreParamsStore(folder string, passphrase []byte) (*PreParamsStore, error) {
+	store, err := NewPreParamsStore(folder)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := newStateCipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	store.cipher = sc
+	return store, nil
+}
+
+func (s *PreParamsStore) filePathName(id string) (string, error) {
+	if len(id) == 0 || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("invalid pre-parameters id(%s)", id)
+	}
+	return filepath.Join(s.folder, id+preParamsFileSuffix), nil
+}
+
+// Save persists the given pre-parameters
+func (s *PreParamsStore) Save(item PreParamsItem) error {
+	filePathName, err := s.filePathName(item.ID)
+	if err != nil {
+		return err
+	}
+	buf := item.Data
+	if s.cipher != nil {
+		buf, err = s.cipher.encrypt(buf)
+		if err != nil {
+			return fmt.Errorf("fail to encrypt the pre-parameters: %w", err)
+		}
+	}
+	return writeFileAtomic(filePathName, buf)
+}
+
+// Load returns all the persisted pre-parameters ordered by their id
+func (s *PreParamsStore) Load() ([]PreParamsItem, error) {
+	files, err := ioutil.ReadDir(s.folder)
+	if err != nil {
+		return nil, err
+	}
+	items := []PreParamsItem{}
+	for _, el := range files {
+		if el.IsDir() || !strings.HasSuffix(el.Name(), preParamsFileSuffix) {
+			continue
+		}
+		buf, err := ioutil.ReadFile(filepath.Join(s.folder, el.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if isEncryptedState(buf) {
+			if s.cipher == nil {
+				return nil, errors.New("pre-parameters are encrypted, but no passphrase is given")
+			}
+			buf, err = s.cipher.decrypt(buf)
+			if err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, PreParamsItem{
+			ID:   strings.TrimSuffix(el.Name(), preParamsFileSuffix),
+			Data: buf,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+// Delete removes the given pre-parameters, they must never be handed to a keygen again
+func (s *PreParamsStore) Delete(id string) error {
+	filePathName, err := s.filePathName(id)
+	if err != nil {
+		return err
+	}
+	return os.Remove(filePathName)
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type PreParamsStoreTestSuite struct{}
+
+var _ = Suite(&PreParamsStoreTestSuite{})
+
+func (s *PreParamsStoreTestSuite) TestPreParamsStore(c *C) {
+	_, err := NewPreParamsStore("")
+	c.Assert(err, NotNil)
+	folder := filepath.Join(c.MkDir(), "preparams")
+	store, err := NewPreParamsStore(folder)
+	c.Assert(err, IsNil)
+	items, err := store.Load()
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 0)
+
+	c.Assert(store.Save(PreParamsItem{ID: "2", Data: []byte("second")}), IsNil)
+	c.Assert(store.Save(PreParamsItem{ID: "1", Data: []byte("first")}), IsNil)
+	c.Assert(store.Save(PreParamsItem{ID: "../1", Data: []byte("first")}), NotNil)
+	// other files in the folder are ignored
+	c.Assert(ioutil.WriteFile(filepath.Join(folder, "readme"), []byte("hello"), localStateFileMode), IsNil)
+	items, err = store.Load()
+	c.Assert(err, IsNil)
+	c.Assert(items, DeepEquals, []PreParamsItem{
+		{ID: "1", Data: []byte("first")},
+		{ID: "2", Data: []byte("second")},
+	})
+
+	c.Assert(store.Delete("1"), IsNil)
+	c.Assert(store.Delete("1"), NotNil)
+	items, err = store.Load()
+	c.Assert(err, IsNil)
+	c.Assert(items, HasLen, 1)
+}
+
+func (s *PreParamsStoreTestSuite) TestEncryptedPreParamsStore(c *C) {
+	folder := c.MkDir()
+	store, err := NewEncryptedPreParamsStore(folder, []byte("passphrase"))
+	c.Assert(err, IsNil)
+	c.Assert(store.Save(PreParamsItem{ID: "1", Data: []byte(`{"secret":"primes"}`)}), IsNil)
+	buf, err := ioutil.ReadFile(filepath.Join(folder, "1"+preParamsFileSuffix))
+	c.Assert(err, IsNil)
+	c.Assert(isEncryptedState(buf), Equals, true)
+
+	items, err := store.Load()
+	c.Assert(err, IsNil)
+	c.Assert(items, DeepEquals, []PreParamsItem{{ID: "1", Data: []byte(`{"secret":"primes"}`)}})
+
+	plainStore, err := NewPreParamsStore(folder)
+	c.Assert(err, IsNil)
+	_, err = plainStore.Load()
+	c.Assert(err, NotNil)
+	wrongStore, err := NewEncryptedPreParamsStore(folder, []byte("wrong"))
+	c.Assert(err, IsNil)
+	_, err = wrongStore.Load()
+	c.Assert(err, Equals, ErrWrongPassphrase)
+}
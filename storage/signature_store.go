@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+const signatureFileSuffix = ".sig"
+
+// SignatureRecord are the signatures of a finished keysign, the signatures are opaque to the store
+type SignatureRecord struct {
+	MsgID      string        `json:"msg_id"`
+	PoolPubKey string        `json:"pool_pub_key"`
+	Algo       messages.Algo `json:"algo"`
+	// Messages are the messages in the order of the signatures
+	Messages   [][]byte `json:"messages"`
+	Signatures [][]byte `json:"signatures"`
+	CreatedAt  int64    `json:"created_at"`
+}
+
+// SignatureStore persists the signatures of the recent keysigns, so a node which restarted can answer a keysign it
+// finished before, it keeps at most maxItems signatures and each of them for the ttl only
+type SignatureStore struct {
+	folder   string
+	maxItems int
+	ttl      time.Duration
+	lock     *sync.Mutex
+	now      func() time.Time
+}
+
+// NewSignatureStore create a new instance of the SignatureStore which keeps the signatures in the given folder, the
+// expired signatures of a former run are removed
+func NewSignatureStore(folder string, maxItems int, ttl time.Duration) (*SignatureStore, error) {
+	if len(folder) == 0 {
+		return nil, errors.New("signatures folder is empty")
+	}
+	if maxItems <= 0 {
+		return nil, fmt.Errorf("invalid number of signatures to keep(%d)", maxItems)
+	}
+	if err := os.MkdirAll(folder, 0o700); err != nil {
+		return nil, err
+	}
+	s := &SignatureStore{
+		folder:   folder,
+		maxItems: maxItems,
+		ttl:      ttl,
+		lock:     &sync.Mutex{},
+		now:      time.Now,
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.prune(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SignatureStore) filePathName(msgID string) (string, error) {
+	if len(msgID) == 0 || strings.ContainsAny(msgID, `/\.`) {
+		return "", fmt.Errorf("invalid msgID(%s)", msgID)
+	}
+	return filepath.Join(s.folder, msgID+signatureFileSuffix), nil
+}
+
+func (s *SignatureStore) expired(createdAt time.Time) bool {
+	return s.ttl > 0 && s.now().Sub(createdAt) > s.ttl
+}
+
+// Save persists the signatures of the keysign, the oldest signatures are removed once there are too many
+func (s *SignatureStore) Save(rec SignatureRecord) error {
+	filePathName, err := s.filePathName(rec.MsgID)
+	if err != nil {
+		return err
+	}
+	if rec.CreatedAt == 0 {
+		rec.CreatedAt = s.now().Unix()
+	}
+	buf, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("fail to marshal the signatures to json: %w", err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := writeFileAtomic(filePathName, buf); err != nil {
+		return err
+	}
+	return s.prune()
+}
+
+// Get returns the signatures of the keysign, the error is os.ErrNotExist if there are none or they expired
+func (s *SignatureStore) Get(msgID string) (SignatureRecord, error) {
+	filePathName, err := s.filePathName(msgID)
+	if err != nil {
+		return SignatureRecord{}, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	buf, err := ioutil.ReadFile(filePathName)
+	if err != nil {
+		return SignatureRecord{}, err
+	}
+	var rec SignatureRecord
+	if err := json.Unmarshal(buf, &rec); err != nil {
+		return SignatureRecord{}, fmt.Errorf("fail to unmarshal the signatures: %w", err)
+	}
+	if s.expired(time.Unix(rec.CreatedAt, 0)) {
+		return SignatureRecord{}, fmt.Errorf("signatures of %s expired: %w", msgID, os.ErrNotExist)
+	}
+	return rec, nil
+}
+
+// prune removes the expired signatures and the oldest ones beyond maxItems, the files are told apart by the time
+// they were written
+func (s *SignatureStore) prune() error {
+	files, err := ioutil.ReadDir(s.folder)
+	if err != nil {
+		return err
+	}
+	kept := make([]os.FileInfo, 0, len(files))
+	for _, el := range files {
+		if el.IsDir() || !strings.HasSuffix(el.Name(), signatureFileSuffix) {
+			continue
+		}
+		if s.expired(el.ModTime()) {
+			if err := os.Remove(filepath.Join(s.folder, el.Name())); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		kept = append(kept, el)
+	}
+	if len(kept) <= s.maxItems {
+		return nil
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].ModTime().Before(kept[j].ModTime())
+	})
+	for _, el := range kept[:len(kept)-s.maxItems] {
+		if err := os.Remove(filepath.Join(s.folder, el.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+type SignatureStoreTestSuite struct{}
+
+var _ = Suite(&SignatureStoreTestSuite{})
+
+func (s *SignatureStoreTestSuite) TestSignatureStore(c *C) {
+	_, err := NewSignatureStore("", 10, time.Hour)
+	c.Assert(err, NotNil)
+	folder := filepath.Join(c.MkDir(), "signatures")
+	_, err = NewSignatureStore(folder, 0, time.Hour)
+	c.Assert(err, NotNil)
+	store, err := NewSignatureStore(folder, 2, time.Hour)
+	c.Assert(err, IsNil)
+
+	rec := SignatureRecord{
+		MsgID:      "a",
+		PoolPubKey: "pool",
+		Algo:       messages.ECDSAKEYSIGN,
+		Messages:   [][]byte{[]byte("hello")},
+		Signatures: [][]byte{[]byte("signature")},
+	}
+	c.Assert(store.Save(rec), IsNil)
+	got, err := store.Get("a")
+	c.Assert(err, IsNil)
+	c.Assert(got.CreatedAt, Not(Equals), int64(0))
+	rec.CreatedAt = got.CreatedAt
+	c.Assert(got, DeepEquals, rec)
+	_, err = store.Get("b")
+	c.Assert(errors.Is(err, os.ErrNotExist), Equals, true)
+	c.Assert(store.Save(SignatureRecord{MsgID: "../a"}), NotNil)
+
+	// the oldest signatures are removed once there are too many
+	for _, el := range []string{"b", "c"} {
+		time.Sleep(10 * time.Millisecond)
+		c.Assert(store.Save(SignatureRecord{MsgID: el, PoolPubKey: "pool"}), IsNil)
+	}
+	_, err = store.Get("a")
+	c.Assert(errors.Is(err, os.ErrNotExist), Equals, true)
+	_, err = store.Get("c")
+	c.Assert(err, IsNil)
+
+	// the expired signatures aren't handed out
+	store.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, err = store.Get("c")
+	c.Assert(errors.Is(err, os.ErrNotExist), Equals, true)
+
+	// the signatures of a former run are kept until they expire
+	store, err = NewSignatureStore(folder, 2, time.Minute)
+	c.Assert(err, IsNil)
+	files, err := filepath.Glob(filepath.Join(folder, "*"+signatureFileSuffix))
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 2)
+	store.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	c.Assert(store.prune(), IsNil)
+	files, err = filepath.Glob(filepath.Join(folder, "*"+signatureFileSuffix))
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0)
+}
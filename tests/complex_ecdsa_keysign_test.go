@@ -2,6 +2,7 @@ package tests
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -101,6 +102,13 @@ func Test_Complex_ECDSA_Keygen_Multiple_Peers(t *testing.T) {
 			}
 			req = keygen.NewRequest(localPubKeys, 10, "0.13.0", "ecdsa")
 			res, err := s.servers[idx].Keygen(req)
+			if idx == partyNum {
+				// the keys with a duplicate are rejected before the node joins the party
+				if !errors.Is(err, tss.ErrInvalidRequest) {
+					panic(fmt.Sprintf("the duplicate key isn't rejected: %v", err))
+				}
+				return
+			}
 			if err != nil {
 				panic(err)
 			}
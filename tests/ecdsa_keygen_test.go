@@ -45,7 +45,7 @@ func (s *FourNodeTestSuite) getEcdsaServer(index int, conf common.TssConfig, boo
 	} else {
 		peerIDs = nil
 	}
-	instance, err := tss.NewTss(peerIDs, s.ports[index], priKey, "Asgard", baseHome, conf, nil, "", messages.ECDSAKEYGEN, pubKeyWhitelist)
+	instance, err := tss.NewTss(peerIDs, s.ports[index], priKey, "Asgard", baseHome, conf, nil, "", messages.ECDSAKEYGEN, pubKeyWhitelist, tss.Options{})
 	if err != nil {
 		panic(err)
 	}
@@ -42,7 +42,7 @@ func (s *FourNodeTestSuite) getEddsaServer(index int, conf common.TssConfig, boo
 	} else {
 		peerIDs = nil
 	}
-	instance, err := tss.NewTss(peerIDs, s.ports[index], priKey, "Asgard", baseHome, conf, nil, "", messages.EDDSAKEYGEN, whiteList)
+	instance, err := tss.NewTss(peerIDs, s.ports[index], priKey, "Asgard", baseHome, conf, nil, "", messages.EDDSAKEYGEN, whiteList, tss.Options{})
 	return instance
 }
 
@@ -69,12 +69,12 @@ func (c *Config) getTssServer(index int, conf common.TssConfig, bootstrap string
 	}
 	var instance *tss.TssServer
 	if algo == messages.ECDSAKEYREGROUP {
-		instance, err = tss.NewTss(peerIDs, c.ports[index], priKey, "Asgard", baseHome, conf, c.preParams[index], "", algo, whiteList)
+		instance, err = tss.NewTss(peerIDs, c.ports[index], priKey, "Asgard", baseHome, conf, c.preParams[index], "", algo, whiteList, tss.Options{})
 		if err != nil {
 			panic(err)
 		}
 	} else {
-		instance, err = tss.NewTss(peerIDs, c.ports[index], priKey, "Asgard", baseHome, conf, nil, "", algo, whiteList)
+		instance, err = tss.NewTss(peerIDs, c.ports[index], priKey, "Asgard", baseHome, conf, nil, "", algo, whiteList, tss.Options{})
 	}
 	return instance
 }
@@ -0,0 +1,118 @@
+package tss
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/HyperCore-Team/go-tss/audit"
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+)
+
+type auditKey struct{}
+
+// ceremonyAudit collects what the audit log records about a ceremony, the methods do nothing on a nil ceremonyAudit,
+// which is used if there is no audit log
+type ceremonyAudit struct {
+	lock  sync.Mutex
+	entry audit.Entry
+	start time.Time
+}
+
+// beginAudit starts the record of a ceremony, it is passed on in the returned context so the join party can add the
+// leader
+func (t *TssServer) beginAudit(ctx context.Context, operation, poolPubKey, algo string) (context.Context, *ceremonyAudit) {
+	if t.auditLog == nil {
+		return ctx, nil
+	}
+	a := &ceremonyAudit{
+		entry: audit.Entry{Operation: operation, PoolPubKey: poolPubKey, Algo: algo},
+		start: time.Now(),
+	}
+	return context.WithValue(ctx, auditKey{}, a), a
+}
+
+func auditFromContext(ctx context.Context) *ceremonyAudit {
+	a, _ := ctx.Value(auditKey{}).(*ceremonyAudit)
+	return a
+}
+
+func (a *ceremonyAudit) setMsgID(msgID string) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.entry.MsgID = msgID
+}
+
+func (a *ceremonyAudit) setPoolPubKey(poolPubKey string) {
+	if a == nil || poolPubKey == "" {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.entry.PoolPubKey = poolPubKey
+}
+
+func (a *ceremonyAudit) setParticipants(participants []string) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.entry.Participants = append([]string(nil), participants...)
+}
+
+// setMessages records the hashes of the messages to sign, not the messages themselves
+func (a *ceremonyAudit) setMessages(msgs [][]byte) {
+	if a == nil {
+		return
+	}
+	hashes := make([]string, len(msgs))
+	for i, el := range msgs {
+		hash := sha256.Sum256(el)
+		hashes[i] = hex.EncodeToString(hash[:])
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.entry.MessageHashes = hashes
+}
+
+func (a *ceremonyAudit) joinedParty(participants []string, leader string, joinPartyTime time.Duration) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.entry.Participants = append([]string(nil), participants...)
+	a.entry.Leader = leader
+	a.entry.JoinPartyTime = joinPartyTime
+}
+
+// finishAudit appends the ceremony to the audit log, a failure to write it is logged as the ceremony is over already
+func (t *TssServer) finishAudit(a *ceremonyAudit, status common.Status, blameNodes blame.Blame, err error) {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	entry := a.entry
+	a.lock.Unlock()
+	entry.Duration = time.Since(a.start)
+	entry.Outcome = audit.OutcomeSuccess
+	if err != nil || status != common.Success {
+		entry.Outcome = audit.OutcomeFailure
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if blameNodes.FailReason != "" || len(blameNodes.BlameNodes) > 0 {
+		entry.Blame = &blameNodes
+	}
+	if _, err := t.auditLog.Append(entry); err != nil {
+		t.logger.Error().Err(err).Str("msgID", entry.MsgID).Msgf("fail to append the %s to the audit log", entry.Operation)
+	}
+}
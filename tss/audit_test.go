@@ -0,0 +1,53 @@
+package tss
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/audit"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/monitor"
+)
+
+func TestKeySignIsAudited(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog, err := audit.Open(path)
+	assert.Nil(t, err)
+	server := &TssServer{
+		logger:        zerolog.Nop(),
+		tssMetrics:    monitor.NewMetric(),
+		signingPolicy: PoolAllowlistPolicy("other"),
+		auditLog:      auditLog,
+	}
+	msg := base64.StdEncoding.EncodeToString([]byte("hello"))
+	signers := []string{testNodePubKeys[0], testNodePubKeys[1]}
+	_, err = server.KeySign(keysign.NewRequest(testPoolPubKey, []string{msg}, 10, signers, "0.14.0", "ecdsa"))
+	assert.NotNil(t, err)
+	assert.Nil(t, auditLog.Close())
+
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+	var entries []audit.Entry
+	_, err = audit.Verify(file, func(e audit.Entry, _ string) error {
+		entries = append(entries, e)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	hash := sha256.Sum256([]byte("hello"))
+	assert.Equal(t, "keysign", entries[0].Operation)
+	assert.Equal(t, testPoolPubKey, entries[0].PoolPubKey)
+	assert.NotEqual(t, "", entries[0].MsgID)
+	assert.Equal(t, []string{hex.EncodeToString(hash[:])}, entries[0].MessageHashes)
+	assert.Equal(t, []string{testNodePubKeys[0], testNodePubKeys[1]}, entries[0].Participants)
+	assert.Equal(t, audit.OutcomeFailure, entries[0].Outcome)
+	assert.Contains(t, entries[0].Error, "pool_allowlist")
+}
@@ -0,0 +1,95 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/keysign"
+)
+
+// KeySignBatch signs the messages of every pool of the batch
+func (t *TssServer) KeySignBatch(req keysign.BatchRequest) (keysign.BatchResponse, error) {
+	return t.KeySignBatchWithContext(context.Background(), req)
+}
+
+// KeySignBatchWithContext runs the keysigns of the batch concurrently, at most BatchKeySignWorkers at a time, every
+// keysign has its own msgID and join party. The error is only set if the batch can't be started, the errors of the
+// keysigns are in their results.
+func (t *TssServer) KeySignBatchWithContext(ctx context.Context, req keysign.BatchRequest) (keysign.BatchResponse, error) {
+	t.logger.Info().Int("requests", len(req.Requests)).Msg("received batch keysign request")
+	if err := cancelledError(ctx, "batch keysign"); err != nil {
+		return keysign.BatchResponse{}, err
+	}
+	if err := req.Validate(); err != nil {
+		return keysign.BatchResponse{}, invalidRequest(err)
+	}
+	if err := t.checkBatchMsgIDs(req.Requests); err != nil {
+		return keysign.BatchResponse{}, invalidRequest(err)
+	}
+	results := runBatch(ctx, req.Requests, t.conf.BatchKeySignWorkers, t.KeySignWithContext)
+	resp := keysign.NewBatchResponse(results)
+	t.logger.Info().Int("requests", len(req.Requests)).Bool("success", resp.Status == common.Success).Msg("batch keysign is done")
+	return resp, nil
+}
+
+// checkBatchMsgIDs rejects the requests with the msgID of an earlier request of the batch, the msgID doesn't tell the
+// pool, so their keysigns would wait for each other and be answered with the signatures of the first pool
+func (t *TssServer) checkBatchMsgIDs(reqs []keysign.Request) error {
+	var errs common.FieldErrors
+	msgIDs := make(map[string]int, len(reqs))
+	for i, req := range reqs {
+		field := fmt.Sprintf("requests[%d]", i)
+		msgID, err := t.requestToMsgId(copyRequest(req))
+		if err != nil {
+			errs.Add(field, err)
+			continue
+		}
+		if j, ok := msgIDs[msgID]; ok {
+			errs.Addf(field, "the keysign has the msgID of requests[%d], the pools have to sign different messages", j)
+			continue
+		}
+		msgIDs[msgID] = i
+	}
+	return errs.Err()
+}
+
+// copyRequest copies the keys and the messages of the request, the msgID sorts them and the caller may share them
+// between the requests
+func copyRequest(req keysign.Request) keysign.Request {
+	req.SignerPubKeys = append([]string(nil), req.SignerPubKeys...)
+	req.Messages = append([]string(nil), req.Messages...)
+	return req
+}
+
+// runBatch runs the keysigns with the given number of workers, the requests are started in their order, so the nodes
+// of a pool join its party at about the same time
+func runBatch(ctx context.Context, reqs []keysign.Request, workers int, sign func(context.Context, keysign.Request) (keysign.Response, error)) []keysign.BatchResult {
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(reqs) {
+		workers = len(reqs)
+	}
+	results := make([]keysign.BatchResult, len(reqs))
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				req := copyRequest(reqs[idx])
+				resp, err := sign(ctx, req)
+				results[idx] = keysign.BatchResult{PoolPubKey: req.PoolPubKey, Response: resp, Err: err}
+			}
+		}()
+	}
+	for i := range reqs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
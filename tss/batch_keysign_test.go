@@ -0,0 +1,97 @@
+package tss
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/keysign"
+)
+
+func TestRunBatch(t *testing.T) {
+	msg := base64.StdEncoding.EncodeToString([]byte("hello"))
+	var reqs []keysign.Request
+	for i := 0; i < 10; i++ {
+		// the requests share the signers, the keysigns sort them
+		reqs = append(reqs, keysign.NewRequest(string(rune('a'+i)), []string{msg}, 10, testNodePubKeys, "0.14.0", "ecdsa"))
+	}
+	lock := &sync.Mutex{}
+	running, maxRunning := 0, 0
+	sign := func(ctx context.Context, req keysign.Request) (keysign.Response, error) {
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		lock.Unlock()
+		sort.Sort(sort.Reverse(sort.StringSlice(req.SignerPubKeys)))
+		time.Sleep(10 * time.Millisecond)
+		lock.Lock()
+		running--
+		lock.Unlock()
+		if req.PoolPubKey == "c" {
+			return keysign.NewResponse(nil, common.Fail, blame.NewBlame(blame.TssTimeout, nil)), keysign.ErrKeysignFailed
+		}
+		return keysign.NewResponse(nil, common.Success, blame.Blame{}), nil
+	}
+
+	results := runBatch(context.Background(), reqs, 3, sign)
+	assert.Equal(t, 3, maxRunning)
+	assert.Len(t, results, len(reqs))
+	for i, el := range results {
+		assert.Equal(t, reqs[i].PoolPubKey, el.PoolPubKey)
+		if el.PoolPubKey == "c" {
+			assert.ErrorIs(t, el.Err, keysign.ErrKeysignFailed)
+			assert.Equal(t, blame.TssTimeout, el.Response.Blame.FailReason)
+			continue
+		}
+		assert.Nil(t, el.Err)
+		assert.Equal(t, common.Success, el.Response.Status)
+	}
+	// the signers of the caller are untouched
+	assert.Equal(t, "D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=", testNodePubKeys[0])
+
+	// a single worker runs the keysigns one by one
+	maxRunning = 0
+	runBatch(context.Background(), reqs[:4], 0, sign)
+	assert.Equal(t, 1, maxRunning)
+}
+
+func TestKeySignBatchWithContext(t *testing.T) {
+	server := &TssServer{logger: zerolog.Nop()}
+	_, err := server.KeySignBatchWithContext(context.Background(), keysign.NewBatchRequest())
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+	var fieldErrs common.FieldErrors
+	assert.True(t, errors.As(err, &fieldErrs))
+
+	// the msgID doesn't tell the pool, two pools can't sign the same messages in a batch
+	msg := base64.StdEncoding.EncodeToString([]byte("hello"))
+	other := base64.StdEncoding.EncodeToString([]byte("world"))
+	reqs := []keysign.Request{
+		keysign.NewRequest(testPoolPubKey, []string{msg, other}, 10, testNodePubKeys, "0.14.0", "ecdsa"),
+		keysign.NewRequest("pool2", []string{msg}, 10, testNodePubKeys, "0.14.0", "ecdsa"),
+		keysign.NewRequest("pool3", []string{other, msg}, 10, testNodePubKeys, "0.14.0", "ecdsa"),
+	}
+	err = server.checkBatchMsgIDs(reqs)
+	fieldErrs = nil
+	assert.True(t, errors.As(err, &fieldErrs))
+	assert.Len(t, fieldErrs, 1)
+	assert.Equal(t, "requests[2]", fieldErrs[0].Field)
+	// the messages of the caller are untouched
+	assert.Equal(t, []string{other, msg}, reqs[2].Messages)
+	assert.Nil(t, server.checkBatchMsgIDs(reqs[:2]))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = server.KeySignBatchWithContext(ctx, keysign.NewBatchRequest())
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,61 @@
+package tss
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/keygen"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/refresh"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+)
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(time.Second):
+		return false
+	}
+}
+
+func TestCeremonyStopChan(t *testing.T) {
+	server := newRefreshTestServer()
+	server.stopChan = make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopChan, release := server.ceremonyStopChan(ctx)
+	cancel()
+	assert.True(t, isClosed(stopChan))
+	release()
+
+	// releasing the ceremony doesn't stop it
+	stopChan, release = server.ceremonyStopChan(context.Background())
+	release()
+	release()
+	assert.False(t, isClosed(stopChan))
+
+	stopChan, release = server.ceremonyStopChan(context.Background())
+	defer release()
+	close(server.stopChan)
+	assert.True(t, isClosed(stopChan))
+}
+
+func TestCancelledCeremonies(t *testing.T) {
+	server := newRefreshTestServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := server.KeygenWithContext(ctx, keygen.NewRequest([]string{testPoolPubKey}, 10, "0.14.0", "ecdsa"))
+	assert.True(t, errors.Is(err, context.Canceled))
+	_, err = server.KeySignWithContext(ctx, keysign.NewRequest(testPoolPubKey, []string{"aGVsbG8="}, 10, nil, "0.14.0", "ecdsa"))
+	assert.True(t, errors.Is(err, context.Canceled))
+	_, err = server.KeyRegroupWithContext(ctx, keyRegroup.Request{PoolPubKey: testPoolPubKey, Algo: "ecdsa"})
+	assert.True(t, errors.Is(err, context.Canceled))
+	_, err = server.RefreshWithContext(ctx, refresh.NewRequest(testPoolPubKey, 10, "0.14.0", "ecdsa"))
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,74 @@
+package tss
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/p2p"
+)
+
+var (
+	// ErrInvalidRequest is returned for a request which is malformed or asks for what the pool can't do, no ceremony
+	// is started for it
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrInsufficientSigners is returned when the request gives fewer signers than the threshold of the pool needs
+	ErrInsufficientSigners = errors.New("not enough signers")
+	// ErrJoinPartyTimeout is returned when the party isn't formed in time, the blame of the response tells the nodes
+	// which didn't join
+	ErrJoinPartyTimeout = p2p.ErrJoinPartyTimeout
+	// ErrCeremonyTimeout is returned when the parties stop answering after the party is formed, the blame of the
+	// response tells the nodes which were waited for
+	ErrCeremonyTimeout = blame.ErrTssTimeOut
+	// ErrCeremonyFailed is returned when the ceremony fails for any other reason, e.g. a party sends an invalid share
+	ErrCeremonyFailed = errors.New("ceremony failed")
+	// ErrInvalidLocalState is returned when the kept key share of the pool can't be used, e.g. its threshold doesn't
+	// fit its participants
+	ErrInvalidLocalState = errors.New("invalid local state")
+
+	// errJoinPartyNotStarted is returned when this node fails before it asks the peers to join the party
+	errJoinPartyNotStarted = errors.New("fail to start the join party")
+)
+
+// invalidRequest marks the error as the one of an invalid request
+func invalidRequest(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+}
+
+// poolNotFound tells the missing local state of the pool by ErrPoolNotFound, the error of the state manager is kept
+func poolNotFound(pubKey string, err error) error {
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no key share of pool %s: %w: %w", pubKey, ErrPoolNotFound, err)
+	}
+	return fmt.Errorf("fail to get local keygen state: %w", err)
+}
+
+// joinPartyError is the error of the party which isn't formed, the leader which isn't reachable fails the party in
+// time as well
+func joinPartyError(operation string, err error) error {
+	if errors.Is(err, ErrJoinPartyTimeout) {
+		return fmt.Errorf("fail to form the %s party: %w", operation, err)
+	}
+	return fmt.Errorf("fail to form the %s party: %w: %w", operation, ErrJoinPartyTimeout, err)
+}
+
+// legacyError drops the errors which the methods without a context never returned, the failed party, and the failed
+// ceremony as well for the keysign, are told by the status and the blame of the response only
+func legacyError(err error, ceremony bool) error {
+	if errors.Is(err, ErrJoinPartyTimeout) || errors.Is(err, errJoinPartyNotStarted) {
+		return nil
+	}
+	if ceremony && (errors.Is(err, ErrCeremonyFailed) || errors.Is(err, ErrCeremonyTimeout)) {
+		return nil
+	}
+	return err
+}
+
+// ceremonyError is the error of the ceremony which fails after the party is formed
+func ceremonyError(operation string, err error) error {
+	if errors.Is(err, ErrCeremonyTimeout) {
+		return fmt.Errorf("%s timeout: %w", operation, err)
+	}
+	return fmt.Errorf("%w: %s: %w", ErrCeremonyFailed, operation, err)
+}
@@ -0,0 +1,79 @@
+package tss
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/keygen"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/monitor"
+	"github.com/HyperCore-Team/go-tss/p2p"
+)
+
+func TestCeremonyErrors(t *testing.T) {
+	err := joinPartyError("keysign", p2p.ErrJoinPartyTimeout)
+	assert.ErrorIs(t, err, ErrJoinPartyTimeout)
+	// the leader which isn't reachable fails the party as well
+	err = joinPartyError("keysign", p2p.ErrLeaderNotReady)
+	assert.ErrorIs(t, err, ErrJoinPartyTimeout)
+	assert.ErrorIs(t, err, p2p.ErrLeaderNotReady)
+
+	err = ceremonyError("keygen", blame.ErrTssTimeOut)
+	assert.ErrorIs(t, err, ErrCeremonyTimeout)
+	assert.False(t, errors.Is(err, ErrCeremonyFailed))
+	errShare := errors.New("invalid share")
+	err = ceremonyError("keygen", errShare)
+	assert.ErrorIs(t, err, ErrCeremonyFailed)
+	assert.ErrorIs(t, err, errShare)
+
+	err = poolNotFound(testPoolPubKey, fmt.Errorf("stat: %w", os.ErrNotExist))
+	assert.ErrorIs(t, err, ErrPoolNotFound)
+	assert.False(t, errors.Is(poolNotFound(testPoolPubKey, errors.New("disk failure")), ErrPoolNotFound))
+}
+
+func TestLegacyErrors(t *testing.T) {
+	// the methods without a context return the blame of the failed party only
+	assert.Nil(t, legacyError(joinPartyError("keygen", p2p.ErrJoinPartyTimeout), false))
+	assert.Nil(t, legacyError(fmt.Errorf("%w: %w", errJoinPartyNotStarted, p2p.ErrLeaderNotReady), false))
+	// the failed keysign ceremony is returned in the response only, the other ceremonies return the error
+	errCeremony := ceremonyError("keysign", errors.New("invalid share"))
+	assert.Nil(t, legacyError(errCeremony, true))
+	assert.ErrorIs(t, legacyError(errCeremony, false), ErrCeremonyFailed)
+	assert.Nil(t, legacyError(ceremonyError("keysign", blame.ErrTssTimeOut), true))
+	errInvalid := invalidRequest(errors.New("invalid message"))
+	assert.ErrorIs(t, legacyError(errInvalid, true), ErrInvalidRequest)
+	assert.Nil(t, legacyError(nil, true))
+}
+
+func TestInvalidRequestErrors(t *testing.T) {
+	server := &TssServer{
+		logger:     zerolog.Nop(),
+		tssMetrics: monitor.NewMetric(),
+		scheduler:  newCeremonyScheduler(1, 0),
+	}
+	msg := base64.StdEncoding.EncodeToString([]byte("hello"))
+	testCases := []struct {
+		name string
+		req  keysign.Request
+	}{
+		{name: "invalid message", req: keysign.NewRequest(testPoolPubKey, []string{"!"}, 10, nil, "0.14.0", "ecdsa")},
+		{name: "child key of eddsa", req: keysign.Request{PoolPubKey: testPoolPubKey, Messages: []string{msg}, Algo: "eddsa", DerivationPath: "m/0"}},
+		{name: "invalid derivation path", req: keysign.Request{PoolPubKey: testPoolPubKey, Messages: []string{msg}, Algo: "ecdsa", DerivationPath: "m/x"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := server.KeySign(tc.req)
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+		})
+	}
+
+	_, err := server.Keygen(keygen.NewRequest(nil, 10, "0.14.0", "rsa"))
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+}
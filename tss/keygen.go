@@ -1,7 +1,8 @@
 package tss
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/HyperCore-Team/go-tss/keygen/ecdsa"
@@ -14,42 +15,100 @@ import (
 	"github.com/HyperCore-Team/go-tss/messages"
 )
 
+// Keygen creates the key shares of a new pool, a party which isn't formed is told by the blame of the response and
+// no error, KeygenWithContext returns the typed error for it
 func (t *TssServer) Keygen(req keygen.Request) (keygen.Response, error) {
-	t.tssKeyGenLocker.Lock()
-	defer t.tssKeyGenLocker.Unlock()
+	resp, err := t.KeygenWithContext(context.Background(), req)
+	return resp, legacyError(err, false)
+}
+
+// KeygenWithContext is Keygen which aborts the keygen of this node as soon as the given context is done
+func (t *TssServer) KeygenWithContext(ctx context.Context, req keygen.Request) (keygen.Response, error) {
+	ctx, record := t.beginAudit(ctx, "keygen", "", req.Algo)
+	record.setParticipants(req.Keys)
+	resp, err := t.runKeygen(ctx, req)
+	record.setPoolPubKey(resp.PubKey)
+	t.finishAudit(record, resp.Status, resp.Blame, err)
+	return resp, err
+}
+
+func (t *TssServer) runKeygen(ctx context.Context, req keygen.Request) (keygen.Response, error) {
+	if err := cancelledError(ctx, "keygen"); err != nil {
+		return keygen.Response{}, err
+	}
+	if err := t.validateRequest(req); err != nil {
+		return keygen.Response{}, err
+	}
 	status := common.Success
 	msgID, err := t.requestToMsgId(req)
 	if err != nil {
 		return keygen.Response{}, err
 	}
+	auditFromContext(ctx).setMsgID(msgID)
+	// the keygen of a new pool only conflicts with the same keygen
+	release, err := t.queueCeremony(ctx, msgID, "keygen")
+	if err != nil {
+		return keygen.Response{}, err
+	}
+	defer release()
+	// the threshold used by GenerateNewKey, it is reported in the response
+	threshold, err := conversion.ResolveThreshold(req.Threshold, len(req.Keys))
+	if err != nil {
+		return keygen.Response{}, invalidRequest(err)
+	}
 
+	stopChan, releaseStopChan := t.ceremonyStopChan(ctx)
+	defer releaseStopChan()
 	var keygenInstance keygen.TssKeyGen
 	switch req.Algo {
 	case "ecdsa":
+		preParams, err := t.takePreParams()
+		if err != nil {
+			return keygen.Response{}, err
+		}
 		keygenInstance = ecdsa.NewTssKeyGen(
 			t.p2pCommunication.GetLocalPeerID(),
 			t.conf,
 			t.localNodePubKey,
 			t.p2pCommunication.BroadcastMsgChan,
-			t.stopChan,
-			t.preParams,
+			stopChan,
+			preParams,
 			msgID,
 			t.stateManager,
 			t.privateKey,
 			t.p2pCommunication)
+	case "schnorr":
+		// the schnorr pools share their keys the same way as the ecdsa pools, only the pool key is saved x-only
+		preParams, err := t.takePreParams()
+		if err != nil {
+			return keygen.Response{}, err
+		}
+		schnorrKeygen := ecdsa.NewTssKeyGen(
+			t.p2pCommunication.GetLocalPeerID(),
+			t.conf,
+			t.localNodePubKey,
+			t.p2pCommunication.BroadcastMsgChan,
+			stopChan,
+			preParams,
+			msgID,
+			t.stateManager,
+			t.privateKey,
+			t.p2pCommunication)
+		schnorrKeygen.SetSchnorr()
+		keygenInstance = schnorrKeygen
 	case "eddsa":
 		keygenInstance = eddsa.NewTssKeyGen(
 			t.p2pCommunication.GetLocalPeerID(),
 			t.conf,
 			t.localNodePubKey,
 			t.p2pCommunication.BroadcastMsgChan,
-			t.stopChan,
+			stopChan,
 			msgID,
 			t.stateManager,
 			t.privateKey,
 			t.p2pCommunication)
 	default:
-		return keygen.Response{}, errors.New("invalid keygen algo")
+		return keygen.Response{}, invalidRequest(fmt.Errorf("%w: %s", keygen.ErrInvalidAlgo, req.Algo))
 	}
 
 	keygenMsgChannel := keygenInstance.GetTssKeyGenChannels()
@@ -69,24 +128,20 @@ func (t *TssServer) Keygen(req keygen.Request) (keygen.Response, error) {
 	}()
 	sigChan := make(chan string)
 	blameMgr := keygenInstance.GetTssCommonStruct().GetBlameMgr()
+	rounds := messages.ECDSAKEYGENROUNDS
+	if req.Algo == "eddsa" {
+		rounds = messages.EDDSAKEYGENROUNDS
+	}
+	keygenInstance.GetTssCommonStruct().SetRoundObserver(roundObserver(ctx, rounds))
 	joinPartyStartTime := time.Now()
-	onlinePeers, leader, errJoinParty := t.joinParty(msgID, req.Version, req.BlockHeight, req.Keys, len(req.Keys)-1, sigChan)
+	onlinePeers, leader, errJoinParty := t.joinParty(ctx, msgID, req.Version, req.BlockHeight, req.Keys, len(req.Keys)-1, sigChan)
 	joinPartyTime := time.Since(joinPartyStartTime)
-	threshold, err := conversion.GetThreshold(len(req.Keys) + 1)
-	if err != nil {
-		blameNodes, err := blameMgr.NodeSyncBlame(req.Keys, onlinePeers)
-		if err != nil {
-			t.logger.Err(errJoinParty).Msg("fail to get peers to blame")
-		}
-		return keygen.Response{
-			Status:    common.Fail,
-			Blame:     blameNodes,
-			Threshold: threshold,
-		}, errors.New("fail to get threshold")
-	}
 	if errJoinParty != nil {
 		t.tssMetrics.KeygenJoinParty(joinPartyTime, false)
 		t.tssMetrics.UpdateKeyGen(0, false)
+		if err := cancelledError(ctx, "keygen"); err != nil {
+			return keygen.NewResponse("", common.Fail, blame.Blame{}, "", threshold), err
+		}
 		// this indicate we are processing the leaderless join party
 		if leader == "NONE" {
 			if onlinePeers == nil {
@@ -95,7 +150,7 @@ func (t *TssServer) Keygen(req keygen.Request) (keygen.Response, error) {
 					Status:    common.Fail,
 					Blame:     blame.NewBlame(blame.InternalError, []blame.Node{}),
 					Threshold: threshold,
-				}, nil
+				}, fmt.Errorf("%w: %w", errJoinPartyNotStarted, errJoinParty)
 			}
 			blameNodes, err := blameMgr.NodeSyncBlame(req.Keys, onlinePeers)
 			if err != nil {
@@ -107,7 +162,7 @@ func (t *TssServer) Keygen(req keygen.Request) (keygen.Response, error) {
 				Status:    common.Fail,
 				Blame:     blameNodes,
 				Threshold: threshold,
-			}, nil
+			}, joinPartyError("keygen", errJoinParty)
 
 		}
 
@@ -135,7 +190,7 @@ func (t *TssServer) Keygen(req keygen.Request) (keygen.Response, error) {
 			Status:    common.Fail,
 			Blame:     blameNodes,
 			Threshold: threshold,
-		}, nil
+		}, joinPartyError("keygen", errJoinParty)
 
 	}
 
@@ -150,8 +205,11 @@ func (t *TssServer) Keygen(req keygen.Request) (keygen.Response, error) {
 	if err != nil {
 		t.tssMetrics.UpdateKeyGen(keygenTime, false)
 		t.logger.Error().Err(err).Msg("err in keygen")
+		if err := cancelledError(ctx, "keygen"); err != nil {
+			return keygen.NewResponse("", common.Fail, blame.Blame{}, "", threshold), err
+		}
 		blameNodes := *blameMgr.GetBlame()
-		return keygen.NewResponse("", common.Fail, blameNodes, "", threshold), err
+		return keygen.NewResponse("", common.Fail, blameNodes, "", threshold), ceremonyError("keygen", err)
 	} else {
 		t.tssMetrics.UpdateKeyGen(keygenTime, true)
 	}
@@ -163,6 +221,8 @@ func (t *TssServer) Keygen(req keygen.Request) (keygen.Response, error) {
 		newPubKey, err = conversion.GetTssPubKeyECDSA(k)
 	case "eddsa":
 		newPubKey, err = conversion.GetTssPubKeyEDDSA(k)
+	case "schnorr":
+		newPubKey, err = conversion.GetTssPubKeySchnorr(k)
 	default:
 		newPubKey, err = conversion.GetTssPubKeyECDSA(k)
 	}
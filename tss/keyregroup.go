@@ -1,8 +1,8 @@
 package tss
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/HyperCore-Team/go-tss/regroup/ecdsa"
 	"github.com/HyperCore-Team/go-tss/regroup/eddsa"
@@ -17,16 +17,56 @@ import (
 	"github.com/HyperCore-Team/go-tss/regroup"
 )
 
+// KeyRegroup reshares the pool to the new committee, a party which isn't formed is told by the blame of the response
+// and no error, KeyRegroupWithContext returns the typed error for it
 func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, error) {
-	t.tssKeyGenLocker.Lock()
-	defer t.tssKeyGenLocker.Unlock()
+	resp, err := t.KeyRegroupWithContext(context.Background(), req)
+	return resp, legacyError(err, false)
+}
+
+// KeyRegroupWithContext is KeyRegroup which aborts the regroup of this node as soon as the given context is done
+func (t *TssServer) KeyRegroupWithContext(ctx context.Context, req keyRegroup.Request) (keyRegroup.Response, error) {
+	ctx, record := t.beginAudit(ctx, "keyregroup", req.PoolPubKey, req.Algo)
+	record.setParticipants(append(append([]string{}, req.OldPartyKeys...), req.NewPartyKeys...))
+	resp, err := t.runKeyRegroup(ctx, req)
+	record.setPoolPubKey(resp.PubKey)
+	t.finishAudit(record, resp.Status, resp.Blame, err)
+	return resp, err
+}
+
+func (t *TssServer) runKeyRegroup(ctx context.Context, req keyRegroup.Request) (keyRegroup.Response, error) {
+	if err := cancelledError(ctx, "key regroup"); err != nil {
+		return keyRegroup.Response{}, err
+	}
+	if err := t.validateRequest(req); err != nil {
+		return keyRegroup.Response{}, err
+	}
 	status := common.Success
 	msgID, err := t.requestToMsgId(req)
 	if err != nil {
 		return keyRegroup.Response{}, err
 	}
+	auditFromContext(ctx).setMsgID(msgID)
+	// a new member doesn't hold a key share of the pool yet, the regroup only conflicts with the same regroup
+	ceremonyKey := req.PoolPubKey
+	if ceremonyKey == "" {
+		ceremonyKey = msgID
+	}
+	release, err := t.queueCeremony(ctx, ceremonyKey, "key regroup")
+	if err != nil {
+		return keyRegroup.Response{}, err
+	}
+	defer release()
+	if _, err := conversion.ResolveThreshold(req.Threshold, len(req.NewPartyKeys)); err != nil {
+		return keyRegroup.Response{}, invalidRequest(err)
+	}
+	oldThreshold, err := conversion.ResolveThreshold(req.OldThreshold, len(req.OldPartyKeys))
+	if err != nil {
+		return keyRegroup.Response{}, invalidRequest(err)
+	}
 
 	var localSaveData storage.KeygenLocalState
+	var preParams *keygen.LocalPreParams
 	if req.PoolPubKey != "" {
 		if req.Algo == "ecdsa" {
 			localSaveData, err = t.stateManager.GetLocalState(req.PoolPubKey, messages.ECDSAKEYREGROUP)
@@ -35,11 +75,31 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 		}
 		if err != nil {
 			t.logger.Error().Err(err).Msgf("fail to get the local State data")
+			return keyRegroup.NewResponse("", "", common.Fail, blame.Blame{}), poolNotFound(req.PoolPubKey, err)
+		}
+		// the resharing fails if the old committee doesn't use the threshold the pool was created with
+		poolThreshold, err := localSaveData.GetThreshold()
+		if err != nil {
 			return keyRegroup.NewResponse("", "", common.Fail, blame.Blame{}), err
 		}
+		if poolThreshold != oldThreshold {
+			return keyRegroup.NewResponse("", "", common.Fail, blame.Blame{}),
+				invalidRequest(fmt.Errorf("%w: the threshold of pool %s is %d rather than %d, the old threshold has to be set", keyRegroup.ErrThresholdMismatch, req.PoolPubKey, poolThreshold, oldThreshold))
+		}
+		if req.Algo == "ecdsa" {
+			// we keep the pre-parameters of our key share
+			preParams, err = poolPreParams(localSaveData)
+			if err != nil {
+				return keyRegroup.NewResponse("", "", common.Fail, blame.Blame{}), err
+			}
+		}
 	} else if req.Algo == "ecdsa" {
+		preParams, err = t.takePreParams()
+		if err != nil {
+			return keyRegroup.Response{}, err
+		}
 		var localData keygen.LocalPartySaveData
-		localData.LocalPreParams = *t.preParams
+		localData.LocalPreParams = *preParams
 		data, err := json.Marshal(localData)
 		if err != nil {
 			return keyRegroup.Response{}, fmt.Errorf("fail to unmarshal the local saved data")
@@ -47,6 +107,8 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 		localSaveData.LocalData = data
 	}
 
+	stopChan, releaseStopChan := t.ceremonyStopChan(ctx)
+	defer releaseStopChan()
 	var keyRegroupInstance keyRegroup.TssKeyRegroup
 	switch req.Algo {
 	case "ecdsa":
@@ -55,8 +117,8 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 			t.conf,
 			t.localNodePubKey,
 			t.p2pCommunication.BroadcastMsgChan,
-			t.stopChan,
-			t.preParams,
+			stopChan,
+			preParams,
 			msgID,
 			t.stateManager,
 			t.privateKey,
@@ -67,13 +129,15 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 			t.conf,
 			t.localNodePubKey,
 			t.p2pCommunication.BroadcastMsgChan,
-			t.stopChan,
+			stopChan,
 			msgID,
 			t.stateManager,
 			t.privateKey,
 			t.p2pCommunication)
+	case "schnorr":
+		return keyRegroup.Response{}, invalidRequest(fmt.Errorf("%w: the schnorr pools can't be regrouped", keyRegroup.ErrInvalidAlgo))
 	default:
-		return keyRegroup.Response{}, errors.New("invalid key regroup algo")
+		return keyRegroup.Response{}, invalidRequest(fmt.Errorf("%w: %s", keyRegroup.ErrInvalidAlgo, req.Algo))
 	}
 
 	keygenMsgChannel := keyRegroupInstance.GetTssKeyGenChannels()
@@ -82,18 +146,25 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 	t.p2pCommunication.SetSubscribe(messages.TSSPartReGroupVerMSg, msgID, keygenMsgChannel)
 	t.p2pCommunication.SetSubscribe(messages.TSSControlMsg, msgID, keygenMsgChannel)
 	t.p2pCommunication.SetSubscribe(messages.TSSTaskDone, msgID, keygenMsgChannel)
+	t.p2pCommunication.SetSubscribe(messages.TSSChainCodeMsg, msgID, keygenMsgChannel)
 	defer func() {
 		t.p2pCommunication.CancelSubscribe(messages.TSSPartyReGroupMsg, msgID)
 		t.p2pCommunication.CancelSubscribe(messages.TSSPartReGroupVerMSg, msgID)
 		t.p2pCommunication.CancelSubscribe(messages.TSSControlMsg, msgID)
 		t.p2pCommunication.CancelSubscribe(messages.TSSTaskDone, msgID)
+		t.p2pCommunication.CancelSubscribe(messages.TSSChainCodeMsg, msgID)
 	}()
 
 	sigChan := make(chan string)
 	blameMgr := keyRegroupInstance.GetTssCommonStruct().GetBlameMgr()
+	rounds := messages.ECDSAREGROUPROUNDS
+	if req.Algo == "eddsa" {
+		rounds = messages.EDDSAREGROUPROUNDS
+	}
+	keyRegroupInstance.GetTssCommonStruct().SetRoundObserver(roundObserver(ctx, rounds))
 	joinPartyStartTime := time.Now()
 	// TODO current, we ask all the old committee members to be involved in regroup to delete their shares
-	// TODO otherwise, the node need to delete the key share themselves.
+	// TODO otherwise, the node need to delete the key share themselves, or retire it with TssConfig.RetirementPolicy.
 	var allKeys []string
 	allKeysContainer := make(map[string]bool)
 	for _, el := range append(req.OldPartyKeys, req.NewPartyKeys...) {
@@ -102,11 +173,14 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 	for key, _ := range allKeysContainer {
 		allKeys = append(allKeys, key)
 	}
-	onlinePeers, leader, errJoinParty := t.joinParty(msgID, req.Version, req.BlockHeight, allKeys, len(allKeys)-1, sigChan)
+	onlinePeers, leader, errJoinParty := t.joinParty(ctx, msgID, req.Version, req.BlockHeight, allKeys, len(allKeys)-1, sigChan)
 	joinPartyTime := time.Since(joinPartyStartTime)
 	if errJoinParty != nil {
 		t.tssMetrics.KeyRegroupJoinParty(joinPartyTime, false)
 		t.tssMetrics.UpdateKeyRegroup(0, false)
+		if err := cancelledError(ctx, "key regroup"); err != nil {
+			return keyRegroup.NewResponse("", "", common.Fail, blame.Blame{}), err
+		}
 		// this indicate we are processing the leaderless join party
 		if leader == "NONE" {
 			if onlinePeers == nil {
@@ -114,7 +188,7 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 				return keyRegroup.Response{
 					Status: common.Fail,
 					Blame:  blame.NewBlame(blame.InternalError, []blame.Node{}),
-				}, nil
+				}, fmt.Errorf("%w: %w", errJoinPartyNotStarted, errJoinParty)
 			}
 			blameNodes, err := blameMgr.NodeSyncBlame(allKeys, onlinePeers)
 			if err != nil {
@@ -125,7 +199,7 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 			return keyRegroup.Response{
 				Status: common.Fail,
 				Blame:  blameNodes,
-			}, nil
+			}, joinPartyError("key regroup", errJoinParty)
 
 		}
 
@@ -152,7 +226,7 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 		return keyRegroup.Response{
 			Status: common.Fail,
 			Blame:  blameNodes,
-		}, nil
+		}, joinPartyError("key regroup", errJoinParty)
 
 	}
 
@@ -168,8 +242,11 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 	if err != nil {
 		t.tssMetrics.UpdateKeyRegroup(keygenTime, false)
 		t.logger.Error().Err(err).Msg("err in keygen")
+		if err := cancelledError(ctx, "key regroup"); err != nil {
+			return keyRegroup.NewResponse("", "", common.Fail, blame.Blame{}), err
+		}
 		blameNodes := *blameMgr.GetBlame()
-		return keyRegroup.NewResponse("", "", common.Fail, blameNodes), err
+		return keyRegroup.NewResponse("", "", common.Fail, blameNodes), ceremonyError("key regroup", err)
 	} else {
 		t.tssMetrics.UpdateKeyRegroup(keygenTime, true)
 	}
@@ -184,12 +261,24 @@ func (t *TssServer) KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, err
 
 	blameNodes := *blameMgr.GetBlame()
 	if !amNewMember {
-		return keyRegroup.NewResponse(
+		resp := keyRegroup.NewResponse(
 			"",
 			"",
 			common.Success,
 			blameNodes,
-		), nil
+		)
+		if req.PoolPubKey != "" {
+			algo := messages.EDDSAKEYREGROUP
+			if req.Algo == "ecdsa" {
+				algo = messages.ECDSAKEYREGROUP
+			}
+			resp.Retirement = t.scheduleRetirement(req.PoolPubKey, algo)
+		}
+		return resp, nil
+	}
+	if req.PoolPubKey != "" {
+		// we hold the new key share of the pool, it must not be retired
+		t.cancelRetirement(req.PoolPubKey)
 	}
 	var newPubKey, addr string
 	switch req.Algo {
@@ -0,0 +1,42 @@
+package tss
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/messages"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+func TestKeyRegroupOldThreshold(t *testing.T) {
+	stateManager := storage.NewMemStateMgr()
+	keys := append([]string{}, testNodePubKeys...)
+	assert.Nil(t, stateManager.SaveLocalState(storage.KeygenLocalState{
+		PubKey:          testPoolPubKey,
+		LocalData:       []byte("share"),
+		ParticipantKeys: keys,
+		LocalPartyKey:   keys[0],
+		Threshold:       1,
+	}, messages.ECDSAKEYGEN))
+	server := &TssServer{
+		logger:          zerolog.Nop(),
+		localNodePubKey: keys[0],
+		scheduler:       newCeremonyScheduler(1, 0),
+		stateManager:    stateManager,
+	}
+
+	// the pool has a custom threshold, the default threshold of the old committee doesn't match it
+	req := keyRegroup.NewRequest(testPoolPubKey, keys, keys, 10, "0.14.0", "ecdsa")
+	resp, err := server.KeyRegroup(req)
+	assert.ErrorIs(t, err, keyRegroup.ErrThresholdMismatch)
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+	assert.Equal(t, common.Fail, resp.Status)
+
+	req.OldThreshold = 5
+	_, err = server.KeyRegroup(req)
+	assert.NotNil(t, err)
+}
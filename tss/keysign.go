@@ -1,6 +1,7 @@
 package tss
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/HyperCore-Team/go-tss/keysign/ecdsa"
 	"github.com/HyperCore-Team/go-tss/keysign/eddsa"
+	"github.com/HyperCore-Team/go-tss/keysign/schnorr"
 
 	"github.com/HyperCore-Team/go-tss/blame"
 	"github.com/HyperCore-Team/go-tss/common"
@@ -23,28 +25,29 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-func (t *TssServer) waitForSignatures(msgID, poolPubKey string, msgsToSign [][]byte, sigChan chan string, algo messages.Algo) (keysign.Response, error) {
+func (t *TssServer) waitForSignatures(ctx context.Context, msgID, poolPubKey string, msgsToSign [][]byte, sigChan chan string, algo messages.Algo, req keysign.Request, participants []string) (keysign.Response, error) {
 	// TSS keysign include both form party and keysign itself, thus we wait twice of the timeout
-	data, err := t.signatureNotifier.WaitForSignature(msgID, msgsToSign, poolPubKey, t.conf.KeySignTimeout, sigChan, algo)
+	data, err := t.signatureNotifier.WaitForSignatureWithContext(ctx, msgID, msgsToSign, poolPubKey, t.conf.KeySignTimeout, sigChan, algo, req.HashMode)
 	if err != nil {
 		return keysign.Response{}, err
 	}
 	// for gg20, it wrap the signature R,S into ECSignature structure
 	if len(data) == 0 {
-		return keysign.Response{}, errors.New("keysign failed")
+		return keysign.Response{}, keysign.ErrKeysignFailed
 	}
+	t.cacheSignatures(msgID, req, algo, msgsToSign, data, participants)
 
-	return t.batchSignatures(data, msgsToSign), nil
+	return t.batchSignatures(data, msgsToSign, algo, req)
 }
 
-func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req keysign.Request, threshold int, allParticipants []string, localStateItem storage.KeygenLocalState, blameMgr *blame.Manager, keysignInstance keysign.TssKeySign, sigChan chan string) (keysign.Response, error) {
+func (t *TssServer) generateSignature(ctx context.Context, msgID string, msgsToSign [][]byte, req keysign.Request, algo messages.Algo, threshold int, allParticipants []string, localStateItem storage.KeygenLocalState, blameMgr *blame.Manager, keysignInstance keysign.TssKeySign, sigChan chan string) (keysign.Response, error) {
 	allPeersID, err := conversion.GetPeerIDsFromPubKeys(allParticipants)
 	if err != nil {
 		t.logger.Error().Msg("invalid block height or public key")
 		return keysign.Response{
 			Status: common.Fail,
 			Blame:  blame.NewBlame(blame.InternalError, []blame.Node{}),
-		}, nil
+		}, invalidRequest(fmt.Errorf("invalid participant: %w", err))
 	}
 
 	oldJoinParty, err := conversion.VersionLTCheck(req.Version, messages.NEWJOINPARTYVERSION)
@@ -52,7 +55,7 @@ func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req key
 		return keysign.Response{
 			Status: common.Fail,
 			Blame:  blame.NewBlame(blame.InternalError, []blame.Node{}),
-		}, errors.New("fail to parse the version")
+		}, invalidRequest(fmt.Errorf("fail to parse the version: %w", err))
 	}
 	// we use the old join party
 	if oldJoinParty {
@@ -77,7 +80,7 @@ func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req key
 	}
 
 	joinPartyStartTime := time.Now()
-	onlinePeers, leader, errJoinParty := t.joinParty(msgID, req.Version, req.BlockHeight, allParticipants, threshold, sigChan)
+	onlinePeers, leader, errJoinParty := t.joinParty(ctx, msgID, req.Version, req.BlockHeight, allParticipants, threshold, sigChan)
 	joinPartyTime := time.Since(joinPartyStartTime)
 	if errJoinParty != nil && len(onlinePeers) < threshold {
 		// we received the signature from waiting for signature
@@ -85,6 +88,9 @@ func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req key
 			return keysign.Response{}, errJoinParty
 		}
 		t.tssMetrics.KeysignJoinParty(joinPartyTime, false)
+		if err := cancelledError(ctx, "keysign"); err != nil {
+			return keysign.Response{Status: common.Fail}, err
+		}
 		// this indicate we are processing the leaderness join party
 		if leader == "NONE" {
 			if onlinePeers == nil {
@@ -93,7 +99,7 @@ func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req key
 				return keysign.Response{
 					Status: common.Fail,
 					Blame:  blame.NewBlame(blame.InternalError, []blame.Node{}),
-				}, nil
+				}, fmt.Errorf("%w: %w", errJoinPartyNotStarted, errJoinParty)
 			}
 
 			blameNodes, err := blameMgr.NodeSyncBlame(req.SignerPubKeys, onlinePeers)
@@ -106,7 +112,7 @@ func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req key
 			return keysign.Response{
 				Status: common.Fail,
 				Blame:  blameNodes,
-			}, nil
+			}, joinPartyError("keysign", errJoinParty)
 		}
 
 		var blameLeader blame.Blame
@@ -124,7 +130,7 @@ func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req key
 		return keysign.Response{
 			Status: common.Fail,
 			Blame:  blameLeader,
-		}, nil
+		}, joinPartyError("keysign", errJoinParty)
 
 	}
 	t.tssMetrics.KeysignJoinParty(joinPartyTime, true)
@@ -158,12 +164,15 @@ func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req key
 	if err != nil {
 		t.logger.Error().Err(err).Msg("err in keysign")
 		sigChan <- "signature generated"
+		if err := cancelledError(ctx, "keysign"); err != nil {
+			return keysign.Response{Status: common.Fail}, err
+		}
 		t.broadcastKeysignFailure(msgID, allPeersID)
 		blameNodes := *blameMgr.GetBlame()
 		return keysign.Response{
 			Status: common.Fail,
 			Blame:  blameNodes,
-		}, nil
+		}, ceremonyError("keysign", err)
 	}
 
 	sigChan <- "signature generated"
@@ -171,8 +180,12 @@ func (t *TssServer) generateSignature(msgID string, msgsToSign [][]byte, req key
 	if err := t.signatureNotifier.BroadcastSignature(msgID, signatureData, allPeersID); err != nil {
 		return keysign.Response{}, fmt.Errorf("fail to broadcast signature:%w", err)
 	}
+	if err := t.signatureNotifier.SaveSignatures(msgID, req.PoolPubKey, algo, msgsToSign, signatureData); err != nil {
+		t.logger.Error().Err(err).Msg("fail to save the signatures")
+	}
+	t.cacheSignatures(msgID, req, algo, msgsToSign, signatureData, allParticipants)
 
-	return t.batchSignatures(signatureData, msgsToSign), nil
+	return t.batchSignatures(signatureData, msgsToSign, algo, req)
 }
 
 func (t *TssServer) updateKeySignResult(result keysign.Response, timeSpent time.Duration) {
@@ -184,49 +197,126 @@ func (t *TssServer) updateKeySignResult(result keysign.Response, timeSpent time.
 	return
 }
 
+// KeySign signs the messages with the pool key, a party which isn't formed and a keysign which fails are told by the
+// blame of the response and no error, KeySignWithContext returns the typed errors for them
 func (t *TssServer) KeySign(req keysign.Request) (keysign.Response, error) {
+	resp, err := t.KeySignWithContext(context.Background(), req)
+	return resp, legacyError(err, true)
+}
+
+// KeySignWithContext is KeySign which aborts the keysign of this node as soon as the given context is done
+func (t *TssServer) KeySignWithContext(ctx context.Context, req keysign.Request) (keysign.Response, error) {
+	ctx, record := t.beginAudit(ctx, "keysign", req.PoolPubKey, req.Algo)
+	record.setParticipants(req.SignerPubKeys)
+	resp, err := t.runKeySign(ctx, req)
+	t.finishAudit(record, resp.Status, resp.Blame, err)
+	return resp, err
+}
+
+func (t *TssServer) runKeySign(ctx context.Context, req keysign.Request) (keysign.Response, error) {
 	t.logger.Info().Str("pool pub key", req.PoolPubKey).
 		Str("signer pub keys", strings.Join(req.SignerPubKeys, ",")).
 		Str("msg", strings.Join(req.Messages, ",")).
 		Msg("received keysign request")
 	emptyResp := keysign.Response{}
+	if err := cancelledError(ctx, "keysign"); err != nil {
+		return emptyResp, err
+	}
+	if err := t.validateRequest(req); err != nil {
+		return emptyResp, err
+	}
 	msgID, err := t.requestToMsgId(req)
 	if err != nil {
 		return emptyResp, err
 	}
+	auditFromContext(ctx).setMsgID(msgID)
+
+	var msgsToSign [][]byte
+	for _, val := range req.Messages {
+		msgToSign, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			return keysign.Response{}, invalidRequest(fmt.Errorf("fail to decode message(%s): %w", strings.Join(req.Messages, ","), err))
+		}
+		msgsToSign = append(msgsToSign, msgToSign)
+	}
+	auditFromContext(ctx).setMessages(msgsToSign)
+	var derivationPath []uint32
+	if req.DerivationPath != "" {
+		derivationPath, err = conversion.ParseDerivationPath(req.DerivationPath)
+		if err != nil {
+			return emptyResp, invalidRequest(fmt.Errorf("invalid derivation path: %w", err))
+		}
+	}
+	// the policy is consulted before we join the party, so the peers can't make us sign, and before the kept
+	// signatures are handed out, so a rejected request gets none
+	if err := t.checkSigningPolicy(req, msgsToSign); err != nil {
+		return emptyResp, err
+	}
+	// the same request waits for the running keysign of its msgID and is answered with its signatures
+	done, err := t.keysignCache.begin(ctx, msgID)
+	if err != nil {
+		return emptyResp, err
+	}
+	defer done()
+	if resp, ok := t.keptKeySign(msgID, req); ok {
+		t.logger.Info().Msgf("the keysign %s is answered from the kept signatures", msgID)
+		return resp, nil
+	}
 
+	stopChan, release := t.ceremonyStopChan(ctx)
+	defer release()
 	var keysignInstance keysign.TssKeySign
 
 	var algo messages.Algo
 	switch req.Algo {
 	case "ecdsa":
 		algo = messages.ECDSAKEYSIGN
-		keysignInstance = ecdsa.NewTssKeySign(
+		ecdsaKeySign := ecdsa.NewTssKeySign(
 			t.p2pCommunication.GetLocalPeerID(),
 			t.conf,
 			t.p2pCommunication.BroadcastMsgChan,
-			t.stopChan,
+			stopChan,
 			msgID,
 			t.privateKey,
 			t.p2pCommunication,
 			t.stateManager,
 			len(req.Messages),
 		)
+		ecdsaKeySign.SetDerivationPath(derivationPath)
+		ecdsaKeySign.SetHashMode(req.HashMode)
+		keysignInstance = ecdsaKeySign
 	case "eddsa":
 		algo = messages.EDDSAKEYSIGN
-		keysignInstance = eddsa.NewTssKeySign(
+		eddsaKeySign := eddsa.NewTssKeySign(
 			t.p2pCommunication.GetLocalPeerID(),
 			t.conf,
 			t.p2pCommunication.BroadcastMsgChan,
-			t.stopChan,
+			stopChan,
 			msgID,
 			t.privateKey,
 			t.p2pCommunication,
 			t.stateManager,
 			len(req.Messages),
 		)
+		eddsaKeySign.SetHashMode(req.HashMode)
+		keysignInstance = eddsaKeySign
+	case "schnorr":
+		algo = messages.SCHNORRKEYSIGN
+		schnorrKeySign := schnorr.NewTssKeySign(
+			t.p2pCommunication.GetLocalPeerID(),
+			t.conf,
+			t.p2pCommunication.BroadcastMsgChan,
+			stopChan,
+			msgID,
+			t.privateKey,
+			t.p2pCommunication,
+			t.stateManager,
+			len(req.Messages),
+		)
+		schnorrKeySign.SetHashMode(req.HashMode)
+		keysignInstance = schnorrKeySign
 	default:
-		return keysign.Response{}, errors.New("invalid keysign algo")
+		return keysign.Response{}, invalidRequest(fmt.Errorf("%w: %s", keysign.ErrInvalidAlgo, req.Algo))
 	}
 
 	keySignChannels := keysignInstance.GetTssKeySignChannels()
@@ -245,37 +335,29 @@ func (t *TssServer) KeySign(req keysign.Request) (keysign.Response, error) {
 		t.signatureNotifier.ReleaseStream(msgID)
 		t.partyCoordinator.ReleaseStream(msgID)
 	}()
-	var localStateItem storage.KeygenLocalState
-	if req.Algo == "eddsa" {
-		localStateItem, err = t.stateManager.GetLocalState(req.PoolPubKey, messages.EDDSAKEYSIGN)
-	} else {
-		localStateItem, err = t.stateManager.GetLocalState(req.PoolPubKey, messages.ECDSAKEYSIGN)
-	}
+	localStateItem, err := t.stateManager.GetLocalState(req.PoolPubKey, algo)
 	if err != nil {
-		return emptyResp, fmt.Errorf("fail to get local keygen state: %w", err)
+		return emptyResp, poolNotFound(req.PoolPubKey, err)
 	}
-
-	var msgsToSign [][]byte
-	for _, val := range req.Messages {
-		msgToSign, err := base64.StdEncoding.DecodeString(val)
+	// the signatures of a child key are verified against the child pub key
+	signPubKey := req.PoolPubKey
+	if len(derivationPath) > 0 {
+		chainCode, err := localStateItem.GetChainCode()
 		if err != nil {
-			return keysign.Response{}, fmt.Errorf("fail to decode message(%s): %w", strings.Join(req.Messages, ","), err)
+			return emptyResp, fmt.Errorf("fail to get the chain code: %w", err)
+		}
+		signPubKey, err = conversion.DeriveChildPubKey(req.PoolPubKey, chainCode, req.DerivationPath)
+		if err != nil {
+			return emptyResp, err
 		}
-		msgsToSign = append(msgsToSign, msgToSign)
 	}
 
 	sort.SliceStable(msgsToSign, func(i, j int) bool {
-		var algo messages.Algo
-		if req.Algo == "eddsa" {
-			algo = messages.EDDSAKEYSIGN
-		} else {
-			algo = messages.ECDSAKEYSIGN
-		}
-		ma, err := common.MsgToHashInt(msgsToSign[i], algo)
+		ma, err := common.MsgToHashIntWithMode(msgsToSign[i], algo, req.HashMode)
 		if err != nil {
 			t.logger.Error().Err(err).Msgf("fail to convert the hash value")
 		}
-		mb, err := common.MsgToHashInt(msgsToSign[j], algo)
+		mb, err := common.MsgToHashIntWithMode(msgsToSign[j], algo, req.HashMode)
 		if err != nil {
 			t.logger.Error().Err(err).Msgf("fail to convert the hash value")
 		}
@@ -290,24 +372,32 @@ func (t *TssServer) KeySign(req keysign.Request) (keysign.Response, error) {
 		return keysign.Response{
 			Status: common.Fail,
 			Blame:  blame.NewBlame(blame.InternalError, []blame.Node{}),
-		}, errors.New("fail to parse the version")
+		}, invalidRequest(fmt.Errorf("fail to parse the version: %w", err))
 	}
 
 	if len(req.SignerPubKeys) == 0 && oldJoinParty {
-		return emptyResp, errors.New("empty signer pub keys")
+		return emptyResp, fmt.Errorf("%w: empty signer pub keys", ErrInsufficientSigners)
 	}
 
-	threshold, err := conversion.GetThreshold(len(localStateItem.ParticipantKeys))
+	threshold, err := localStateItem.GetThreshold()
 	if err != nil {
 		t.logger.Error().Err(err).Msg("fail to get the threshold")
-		return emptyResp, errors.New("fail to get threshold")
+		return emptyResp, fmt.Errorf("%w: fail to get the threshold: %w", ErrInvalidLocalState, err)
 	}
 	if len(req.SignerPubKeys) <= threshold && oldJoinParty {
 		t.logger.Error().Msgf("not enough signers, threshold=%d and signers=%d", threshold, len(req.SignerPubKeys))
-		return emptyResp, errors.New("not enough signers")
+		return emptyResp, fmt.Errorf("%w: threshold=%d and signers=%d", ErrInsufficientSigners, threshold, len(req.SignerPubKeys))
 	}
 
 	blameMgr := keysignInstance.GetTssCommonStruct().GetBlameMgr()
+	rounds := messages.ECDSAKEYSIGNROUNDS
+	switch algo {
+	case messages.EDDSAKEYSIGN:
+		rounds = messages.EDDSAKEYSIGNROUNDS
+	case messages.SCHNORRKEYSIGN:
+		rounds = messages.SCHNORRKEYSIGNROUNDS
+	}
+	keysignInstance.GetTssCommonStruct().SetRoundObserver(roundObserver(ctx, rounds))
 
 	var receivedSig, generatedSig keysign.Response
 	var errWait, errGen error
@@ -318,7 +408,7 @@ func (t *TssServer) KeySign(req keysign.Request) (keysign.Response, error) {
 	// we wait for signatures
 	go func() {
 		defer wg.Done()
-		receivedSig, errWait = t.waitForSignatures(msgID, req.PoolPubKey, msgsToSign, sigChan, algo)
+		receivedSig, errWait = t.waitForSignatures(ctx, msgID, signPubKey, msgsToSign, sigChan, algo, req, localStateItem.ParticipantKeys)
 		// we received an valid signature indeed
 		if errWait == nil {
 			sigChan <- "signature received"
@@ -331,11 +421,15 @@ func (t *TssServer) KeySign(req keysign.Request) (keysign.Response, error) {
 	// we generate the signature ourselves
 	go func() {
 		defer wg.Done()
-		generatedSig, errGen = t.generateSignature(msgID, msgsToSign, req, threshold, localStateItem.ParticipantKeys, localStateItem, blameMgr, keysignInstance, sigChan)
+		generatedSig, errGen = t.generateSignature(ctx, msgID, msgsToSign, req, algo, threshold, localStateItem.ParticipantKeys, localStateItem, blameMgr, keysignInstance, sigChan)
 	}()
 	wg.Wait()
 	close(sigChan)
 	keysignTime := time.Since(keysignStartTime)
+	if err := cancelledError(ctx, "keysign"); err != nil && errWait != nil {
+		t.updateKeySignResult(keysign.Response{Status: common.Fail}, keysignTime)
+		return emptyResp, err
+	}
 	// we received the generated verified signature, so we return
 	if errWait == nil {
 		t.updateKeySignResult(receivedSig, keysignTime)
@@ -366,21 +460,32 @@ func (t *TssServer) isPartOfKeysignParty(parties []string) bool {
 	return false
 }
 
-func (t *TssServer) batchSignatures(sigs []*tsslibcommon.SignatureData, msgsToSign [][]byte) keysign.Response {
+// batchSignatures builds the response of the signatures, they are encoded as the request asks as well
+func (t *TssServer) batchSignatures(sigs []*tsslibcommon.SignatureData, msgsToSign [][]byte, algo messages.Algo, req keysign.Request) (keysign.Response, error) {
 	var signatures []keysign.Signature
 	for i, sig := range sigs {
 		msg := base64.StdEncoding.EncodeToString(msgsToSign[i])
 		r := base64.StdEncoding.EncodeToString(sig.R)
 		s := base64.StdEncoding.EncodeToString(sig.S)
 		recovery := base64.StdEncoding.EncodeToString(sig.SignatureRecovery)
+		encoded, err := keysign.EncodeSignature(sig, algo, req.Encoding, req.ChainID)
+		if err != nil {
+			return keysign.Response{
+				Status: common.Fail,
+				Blame:  blame.NewBlame(blame.InternalError, []blame.Node{}),
+			}, fmt.Errorf("fail to encode the signature: %w", err)
+		}
 		sig := base64.StdEncoding.EncodeToString(sig.Signature)
 
 		signature := keysign.NewSignature(msg, r, s, recovery, sig)
+		if len(encoded) > 0 {
+			signature.Encoded = base64.StdEncoding.EncodeToString(encoded)
+		}
 		signatures = append(signatures, signature)
 	}
 	return keysign.NewResponse(
 		signatures,
 		common.Success,
 		blame.Blame{},
-	)
+	), nil
 }
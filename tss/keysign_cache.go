@@ -0,0 +1,152 @@
+package tss
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tsslibcommon "github.com/HyperCore-Team/tss-lib/common"
+
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+// keysignCacheEntry are the signatures of a finished keysign, they are encoded for every request which asks for them
+// as the encoding isn't part of the msgID
+type keysignCacheEntry struct {
+	poolPubKey string
+	algo       messages.Algo
+	msgs       [][]byte
+	sigs       []*tsslibcommon.SignatureData
+	// participants are the pub keys of the parties of the pool, they are sent the signatures again
+	participants []string
+	expiresAt    time.Time
+}
+
+// keysignCache makes the keysigns idempotent, the signatures of the recent keysigns are kept by their msgID for the
+// window and only one keysign of a msgID runs at a time, a nil cache keeps nothing
+type keysignCache struct {
+	window  time.Duration
+	lock    *sync.Mutex
+	entries map[string]keysignCacheEntry
+	// running holds the msgIDs of the running keysigns, the channel is closed once the keysign is done
+	running map[string]chan struct{}
+	now     func() time.Time
+}
+
+func newKeysignCache(window time.Duration) *keysignCache {
+	return &keysignCache{
+		window:  window,
+		lock:    &sync.Mutex{},
+		entries: make(map[string]keysignCacheEntry),
+		running: make(map[string]chan struct{}),
+		now:     time.Now,
+	}
+}
+
+// begin waits until no other keysign of the msgID runs, the returned func marks the keysign as done
+func (c *keysignCache) begin(ctx context.Context, msgID string) (func(), error) {
+	if c == nil {
+		return func() {}, nil
+	}
+	for {
+		c.lock.Lock()
+		done, ok := c.running[msgID]
+		if !ok {
+			done = make(chan struct{})
+			c.running[msgID] = done
+			c.lock.Unlock()
+			return func() {
+				c.lock.Lock()
+				defer c.lock.Unlock()
+				delete(c.running, msgID)
+				close(done)
+			}, nil
+		}
+		c.lock.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, cancelledError(ctx, "keysign")
+		}
+	}
+}
+
+// add keeps the signatures of the keysign for the window
+func (c *keysignCache) add(msgID string, entry keysignCacheEntry) {
+	if c == nil || c.window <= 0 || len(entry.sigs) == 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	for id, el := range c.entries {
+		if now.After(el.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+	entry.expiresAt = now.Add(c.window)
+	c.entries[msgID] = entry
+}
+
+// get returns the signatures of the keysign if they are kept
+func (c *keysignCache) get(msgID string) (keysignCacheEntry, bool) {
+	if c == nil {
+		return keysignCacheEntry{}, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[msgID]
+	if !ok {
+		return keysignCacheEntry{}, false
+	}
+	if c.now().After(entry.expiresAt) {
+		delete(c.entries, msgID)
+		return keysignCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// cacheSignatures keeps the signatures of the finished keysign for the same requests
+func (t *TssServer) cacheSignatures(msgID string, req keysign.Request, algo messages.Algo, msgs [][]byte, sigs []*tsslibcommon.SignatureData, participants []string) {
+	t.keysignCache.add(msgID, keysignCacheEntry{
+		poolPubKey:   req.PoolPubKey,
+		algo:         algo,
+		msgs:         msgs,
+		sigs:         sigs,
+		participants: participants,
+	})
+}
+
+// keptKeySign answers the keysign from the signatures of the same keysign before, the cached ones first and the ones
+// of the signature store then, so a node which restarted answers the request as well. The msgID doesn't tell the
+// pool, so the signatures of another pool are never handed out. The cached signatures are sent to the parties of the
+// pool again, so the ones which still wait for them, e.g. as they joined late, don't time out.
+func (t *TssServer) keptKeySign(msgID string, req keysign.Request) (keysign.Response, bool) {
+	entry, ok := t.keysignCache.get(msgID)
+	if !ok {
+		entry, ok = t.storedSignatures(msgID)
+	}
+	if !ok || entry.poolPubKey != req.PoolPubKey {
+		return keysign.Response{}, false
+	}
+	resp, err := t.batchSignatures(entry.sigs, entry.msgs, entry.algo, req)
+	if err != nil {
+		t.logger.Error().Err(err).Msgf("fail to answer the keysign %s from the kept signatures", msgID)
+		return keysign.Response{}, false
+	}
+	if t.signatureNotifier != nil && len(entry.participants) > 0 {
+		peers, err := conversion.GetPeerIDsFromPubKeys(entry.participants)
+		if err != nil {
+			t.logger.Error().Err(err).Msg("fail to get the peers of the participants")
+			return resp, true
+		}
+		go func() {
+			if err := t.signatureNotifier.BroadcastSignature(msgID, entry.sigs, peers); err != nil {
+				t.logger.Error().Err(err).Msg("fail to broadcast the cached signatures")
+			}
+		}()
+	}
+	return resp, true
+}
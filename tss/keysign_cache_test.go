@@ -0,0 +1,137 @@
+package tss
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	tsslibcommon "github.com/HyperCore-Team/tss-lib/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/go-tss/monitor"
+)
+
+func TestKeysignCache(t *testing.T) {
+	cache := newKeysignCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	entry := keysignCacheEntry{
+		poolPubKey: testPoolPubKey,
+		algo:       messages.ECDSAKEYSIGN,
+		msgs:       [][]byte{[]byte("hello")},
+		sigs:       []*tsslibcommon.SignatureData{{R: []byte{1}, S: []byte{2}, Signature: []byte{1, 2}}},
+	}
+	cache.add("msg", entry)
+	// failed keysigns aren't cached
+	cache.add("failed", keysignCacheEntry{poolPubKey: testPoolPubKey})
+	got, ok := cache.get("msg")
+	assert.True(t, ok)
+	assert.Equal(t, entry.sigs, got.sigs)
+	_, ok = cache.get("failed")
+	assert.False(t, ok)
+
+	// the signatures expire after the window
+	now = now.Add(2 * time.Minute)
+	_, ok = cache.get("msg")
+	assert.False(t, ok)
+	assert.Len(t, cache.entries, 0)
+
+	// nothing is cached without a window or a cache
+	disabled := newKeysignCache(0)
+	disabled.add("msg", entry)
+	_, ok = disabled.get("msg")
+	assert.False(t, ok)
+	var nilCache *keysignCache
+	nilCache.add("msg", entry)
+	_, ok = nilCache.get("msg")
+	assert.False(t, ok)
+	done, err := nilCache.begin(context.Background(), "msg")
+	assert.Nil(t, err)
+	done()
+}
+
+func TestKeysignCacheBegin(t *testing.T) {
+	cache := newKeysignCache(time.Minute)
+	done, err := cache.begin(context.Background(), "msg")
+	assert.Nil(t, err)
+
+	// the same msgID waits for the running keysign, another one doesn't
+	otherDone, err := cache.begin(context.Background(), "other")
+	assert.Nil(t, err)
+	otherDone()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = cache.begin(ctx, "msg")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	started := make(chan struct{})
+	go func() {
+		secondDone, err := cache.begin(context.Background(), "msg")
+		assert.Nil(t, err)
+		close(started)
+		secondDone()
+	}()
+	select {
+	case <-started:
+		t.Fatal("the keysign started while the other one of its msgID runs")
+	case <-time.After(50 * time.Millisecond):
+	}
+	done()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the keysign didn't start once the other one of its msgID is done")
+	}
+}
+
+func TestCachedKeySign(t *testing.T) {
+	server := &TssServer{logger: zerolog.Nop(), tssMetrics: monitor.NewMetric(), keysignCache: newKeysignCache(time.Minute)}
+	// the keysign sorts the signers, they are copied as the other tests share them
+	signers := append([]string{}, testNodePubKeys[:3]...)
+	req := keysign.NewRequest(testPoolPubKey, []string{base64.StdEncoding.EncodeToString([]byte("hello"))}, 10, signers, "0.14.0", "ecdsa")
+	_, ok := server.keptKeySign("msg", req)
+	assert.False(t, ok)
+
+	sig := &tsslibcommon.SignatureData{R: []byte{1}, S: []byte{2}, SignatureRecovery: []byte{0}, Signature: []byte{1, 2}}
+	server.cacheSignatures("msg", req, messages.ECDSAKEYSIGN, [][]byte{[]byte("hello")}, []*tsslibcommon.SignatureData{sig}, nil)
+	resp, ok := server.keptKeySign("msg", req)
+	assert.True(t, ok)
+	assert.Len(t, resp.Signatures, 1)
+	assert.Equal(t, "", resp.Signatures[0].Encoded)
+
+	// the encoding isn't part of the msgID, the cached signatures are encoded as every request asks
+	derReq := req
+	derReq.Encoding = keysign.EncodingDER
+	resp, ok = server.keptKeySign("msg", derReq)
+	assert.True(t, ok)
+	encoded, err := keysign.EncodeSignature(sig, messages.ECDSAKEYSIGN, keysign.EncodingDER, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(encoded), resp.Signatures[0].Encoded)
+
+	// the same request is answered without a new ceremony, the server couldn't start one
+	msgID, err := server.requestToMsgId(keysign.NewRequest(req.PoolPubKey, req.Messages, req.BlockHeight,
+		append([]string{}, req.SignerPubKeys...), req.Version, req.Algo))
+	assert.Nil(t, err)
+	server.cacheSignatures(msgID, req, messages.ECDSAKEYSIGN, [][]byte{[]byte("hello")}, []*tsslibcommon.SignatureData{sig}, nil)
+	resp, err = server.KeySign(derReq)
+	assert.Nil(t, err)
+	assert.Equal(t, common.Success, resp.Status)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(encoded), resp.Signatures[0].Encoded)
+
+	// the policy is consulted before the kept signatures are handed out
+	server.signingPolicy = PoolAllowlistPolicy("Am2zsXR3KHkrsxI/o6M2W/pFbo1zGTaHr8ck4dRErm8y")
+	_, err = server.KeySign(derReq)
+	assert.ErrorIs(t, err, ErrSigningRejected)
+	server.signingPolicy = nil
+
+	// the msgID doesn't tell the pool
+	other := req
+	other.PoolPubKey = "Am2zsXR3KHkrsxI/o6M2W/pFbo1zGTaHr8ck4dRErm8y"
+	_, ok = server.keptKeySign("msg", other)
+	assert.False(t, ok)
+}
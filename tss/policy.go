@@ -0,0 +1,198 @@
+package tss
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HyperCore-Team/go-tss/keysign"
+)
+
+// ErrSigningRejected is wrapped by the errors of the keysign requests a SigningPolicy rejects
+var ErrSigningRejected = errors.New("keysign request rejected")
+
+// PolicyRejectedError tells which policy rejected a keysign request and why
+type PolicyRejectedError struct {
+	Policy string
+	Reason string
+	// Err is the error of the payload inspector, if any
+	Err error
+}
+
+func (e *PolicyRejectedError) Error() string {
+	return fmt.Sprintf("%s by the %s policy: %s", ErrSigningRejected, e.Policy, e.Reason)
+}
+
+func (e *PolicyRejectedError) Is(target error) bool {
+	return target == ErrSigningRejected
+}
+
+func (e *PolicyRejectedError) Unwrap() error {
+	return e.Err
+}
+
+// SigningPolicy vets the keysign requests before the node joins the party, the messages are base64 decoded already
+type SigningPolicy interface {
+	// Name labels the rejections of the policy in the metrics
+	Name() string
+	// Check returns a *PolicyRejectedError if the request may not be signed
+	Check(req keysign.Request, msgs [][]byte) error
+}
+
+func reject(policy, format string, args ...interface{}) error {
+	return &PolicyRejectedError{Policy: policy, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Policies combines the given policies, a request is rejected by the first policy which rejects it
+func Policies(policies ...SigningPolicy) SigningPolicy {
+	return chainPolicy(policies)
+}
+
+type chainPolicy []SigningPolicy
+
+func (c chainPolicy) Name() string {
+	return "chain"
+}
+
+func (c chainPolicy) Check(req keysign.Request, msgs [][]byte) error {
+	for _, el := range c {
+		if err := el.Check(req, msgs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PoolAllowlistPolicy only lets the given pools sign
+func PoolAllowlistPolicy(poolPubKeys ...string) SigningPolicy {
+	allowed := make(map[string]bool, len(poolPubKeys))
+	for _, el := range poolPubKeys {
+		allowed[el] = true
+	}
+	return poolAllowlist(allowed)
+}
+
+type poolAllowlist map[string]bool
+
+func (p poolAllowlist) Name() string {
+	return "pool_allowlist"
+}
+
+func (p poolAllowlist) Check(req keysign.Request, _ [][]byte) error {
+	if !p[req.PoolPubKey] {
+		return reject(p.Name(), "pool %s is not allowed to sign", req.PoolPubKey)
+	}
+	return nil
+}
+
+// MaxMessagesPolicy limits how many messages a single request may sign
+func MaxMessagesPolicy(max int) SigningPolicy {
+	return maxMessages(max)
+}
+
+type maxMessages int
+
+func (m maxMessages) Name() string {
+	return "max_messages"
+}
+
+func (m maxMessages) Check(_ keysign.Request, msgs [][]byte) error {
+	if len(msgs) > int(m) {
+		return reject(m.Name(), "%d messages are more than %d", len(msgs), int(m))
+	}
+	return nil
+}
+
+// PayloadInspector decodes and checks a message before it is signed for the given pool, the request is rejected if it
+// returns an error
+type PayloadInspector func(poolPubKey string, msg []byte) error
+
+// PayloadPolicy runs the inspector on every message of the request, the name labels its rejections
+func PayloadPolicy(name string, inspector PayloadInspector) SigningPolicy {
+	return &payloadPolicy{name: name, inspector: inspector}
+}
+
+type payloadPolicy struct {
+	name      string
+	inspector PayloadInspector
+}
+
+func (p *payloadPolicy) Name() string {
+	return p.name
+}
+
+func (p *payloadPolicy) Check(req keysign.Request, msgs [][]byte) error {
+	for i, el := range msgs {
+		if err := p.inspector(req.PoolPubKey, el); err != nil {
+			return &PolicyRejectedError{Policy: p.name, Reason: fmt.Sprintf("message %d: %s", i, err), Err: err}
+		}
+	}
+	return nil
+}
+
+// RateLimitPolicy lets every pool sign at most limit requests in any window, the accepted requests are counted even if
+// a later policy rejects them, so it should be the last of the Policies
+type RateLimitPolicy struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	lock  sync.Mutex
+	pools map[string][]time.Time
+}
+
+// NewRateLimitPolicy create a new rate limit of limit requests per pool in any window
+func NewRateLimitPolicy(limit int, window time.Duration) *RateLimitPolicy {
+	return &RateLimitPolicy{
+		limit:  limit,
+		window: window,
+		now:    time.Now,
+		pools:  make(map[string][]time.Time),
+	}
+}
+
+func (p *RateLimitPolicy) Name() string {
+	return "rate_limit"
+}
+
+func (p *RateLimitPolicy) Check(req keysign.Request, _ [][]byte) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	now := p.now()
+	// drop the requests which left the window
+	requests := p.pools[req.PoolPubKey]
+	i := 0
+	for i < len(requests) && now.Sub(requests[i]) >= p.window {
+		i++
+	}
+	requests = requests[i:]
+	if len(requests) >= p.limit {
+		p.pools[req.PoolPubKey] = requests
+		return reject(p.Name(), "pool %s signed %d requests in the last %s", req.PoolPubKey, len(requests), p.window)
+	}
+	p.pools[req.PoolPubKey] = append(requests, now)
+	return nil
+}
+
+// checkSigningPolicy consults the signing policy of the server, the rejections are counted in the metrics
+func (t *TssServer) checkSigningPolicy(req keysign.Request, msgs [][]byte) error {
+	if t.signingPolicy == nil {
+		return nil
+	}
+	err := t.signingPolicy.Check(req, msgs)
+	if err == nil {
+		return nil
+	}
+	policy := t.signingPolicy.Name()
+	var rejected *PolicyRejectedError
+	if errors.As(err, &rejected) {
+		policy = rejected.Policy
+	} else {
+		// a custom policy which doesn't return the typed error still rejects the request
+		err = &PolicyRejectedError{Policy: policy, Reason: err.Error(), Err: err}
+	}
+	t.tssMetrics.KeysignRejected(policy)
+	t.logger.Error().Err(err).Str("pool pub key", req.PoolPubKey).Msg("keysign request is rejected")
+	return err
+}
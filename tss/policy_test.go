@@ -0,0 +1,111 @@
+package tss
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/monitor"
+)
+
+func TestSigningPolicies(t *testing.T) {
+	errNotATransfer := errors.New("not a transfer")
+	inspector := func(_ string, msg []byte) error {
+		if string(msg) != "transfer" {
+			return errNotATransfer
+		}
+		return nil
+	}
+	testCases := []struct {
+		name     string
+		policy   SigningPolicy
+		pool     string
+		msgs     []string
+		rejected string
+	}{
+		{name: "allowed pool", policy: PoolAllowlistPolicy(testPoolPubKey), pool: testPoolPubKey, msgs: []string{"a"}},
+		{name: "unknown pool", policy: PoolAllowlistPolicy(testPoolPubKey), pool: "other", msgs: []string{"a"}, rejected: "pool_allowlist"},
+		{name: "few messages", policy: MaxMessagesPolicy(2), msgs: []string{"a", "b"}},
+		{name: "too many messages", policy: MaxMessagesPolicy(2), msgs: []string{"a", "b", "c"}, rejected: "max_messages"},
+		{name: "inspected payload", policy: PayloadPolicy("transfers", inspector), msgs: []string{"transfer"}},
+		{name: "rejected payload", policy: PayloadPolicy("transfers", inspector), msgs: []string{"transfer", "mint"}, rejected: "transfers"},
+		{
+			name:     "first rejection wins",
+			policy:   Policies(MaxMessagesPolicy(1), PoolAllowlistPolicy(testPoolPubKey)),
+			pool:     "other",
+			msgs:     []string{"a", "b"},
+			rejected: "max_messages",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var msgs [][]byte
+			for _, el := range tc.msgs {
+				msgs = append(msgs, []byte(el))
+			}
+			err := tc.policy.Check(keysign.Request{PoolPubKey: tc.pool}, msgs)
+			if tc.rejected == "" {
+				assert.Nil(t, err)
+				return
+			}
+			var rejected *PolicyRejectedError
+			assert.True(t, errors.As(err, &rejected))
+			assert.Equal(t, tc.rejected, rejected.Policy)
+			assert.True(t, errors.Is(err, ErrSigningRejected))
+		})
+	}
+	err := PayloadPolicy("transfers", inspector).Check(keysign.Request{}, [][]byte{[]byte("mint")})
+	assert.True(t, errors.Is(err, errNotATransfer))
+}
+
+func TestRateLimitPolicy(t *testing.T) {
+	now := time.Unix(1000, 0)
+	policy := NewRateLimitPolicy(2, time.Minute)
+	policy.now = func() time.Time { return now }
+	req := keysign.Request{PoolPubKey: testPoolPubKey}
+
+	assert.Nil(t, policy.Check(req, nil))
+	now = now.Add(10 * time.Second)
+	assert.Nil(t, policy.Check(req, nil))
+	assert.True(t, errors.Is(policy.Check(req, nil), ErrSigningRejected))
+	// the other pools have their own limit
+	assert.Nil(t, policy.Check(keysign.Request{PoolPubKey: "other"}, nil))
+	// the first request leaves the window
+	now = now.Add(50 * time.Second)
+	assert.Nil(t, policy.Check(req, nil))
+	assert.NotNil(t, policy.Check(req, nil))
+}
+
+func TestKeySignRejectedByPolicy(t *testing.T) {
+	server := &TssServer{
+		logger:        zerolog.Nop(),
+		tssMetrics:    monitor.NewMetric(),
+		signingPolicy: PoolAllowlistPolicy("other"),
+	}
+	msg := base64.StdEncoding.EncodeToString([]byte("hello"))
+	_, err := server.KeySign(keysign.NewRequest(testPoolPubKey, []string{msg}, 10, nil, "0.14.0", "ecdsa"))
+	var rejected *PolicyRejectedError
+	assert.True(t, errors.As(err, &rejected))
+	assert.Equal(t, "pool_allowlist", rejected.Policy)
+
+	// the errors of custom policies are turned into the typed error
+	server.signingPolicy = customPolicy{}
+	_, err = server.KeySign(keysign.NewRequest(testPoolPubKey, []string{msg}, 10, nil, "0.14.0", "ecdsa"))
+	assert.True(t, errors.As(err, &rejected))
+	assert.Equal(t, "custom", rejected.Policy)
+}
+
+type customPolicy struct{}
+
+func (customPolicy) Name() string {
+	return "custom"
+}
+
+func (customPolicy) Check(keysign.Request, [][]byte) error {
+	return errors.New("no")
+}
@@ -0,0 +1,149 @@
+package tss
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+var (
+	// ErrPoolNotFound is returned when this node doesn't hold a key share of the requested pool
+	ErrPoolNotFound = errors.New("pool not found")
+	// ErrInvalidPoolPubKey is returned when the pool pub key is neither an ecdsa, an eddsa nor a schnorr pub key
+	ErrInvalidPoolPubKey = errors.New("invalid pool pub key")
+)
+
+// PoolInfo describes a pool this node holds a key share of, the key share itself is never exposed
+type PoolInfo struct {
+	PubKey          string   `json:"pub_key"`
+	Algo            string   `json:"algo"`
+	ParticipantKeys []string `json:"participant_keys"`
+	LocalPartyKey   string   `json:"local_party_key"`
+	Threshold       int      `json:"threshold"`
+}
+
+// poolAlgo tells the algo of the pool from its pub key
+func poolAlgo(pubKey string) (messages.Algo, string, error) {
+	pubKeyBytes, err := base64.StdEncoding.DecodeString(pubKey)
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: %s", ErrInvalidPoolPubKey, err)
+	}
+	switch len(pubKeyBytes) {
+	case 33:
+		return messages.ECDSAKEYGEN, "ecdsa", nil
+	case 32:
+		// the x-only keys of the schnorr pools have the same length, about half of them are valid eddsa keys as well,
+		// the eddsa keys are tried first and the schnorr keys among them are told by the algo of their local state
+		if ok, _ := conversion.CheckKeyOnCurve(pubKey, messages.EDDSAKEYGEN); ok {
+			return messages.EDDSAKEYGEN, "eddsa", nil
+		}
+		if _, err := conversion.ParseSchnorrPubKey(pubKey); err != nil {
+			return 0, "", fmt.Errorf("%w: %s", ErrInvalidPoolPubKey, err)
+		}
+		return messages.SCHNORRKEYGEN, storage.AlgoSchnorr, nil
+	default:
+		return 0, "", fmt.Errorf("%w: unexpected length %d", ErrInvalidPoolPubKey, len(pubKeyBytes))
+	}
+}
+
+func newPoolInfo(state storage.KeygenLocalState) (PoolInfo, error) {
+	_, algo, err := poolAlgo(state.PubKey)
+	if err != nil {
+		return PoolInfo{}, err
+	}
+	if state.Algo != "" {
+		algo = state.Algo
+	}
+	threshold, err := state.GetThreshold()
+	if err != nil {
+		return PoolInfo{}, err
+	}
+	return PoolInfo{
+		PubKey:          state.PubKey,
+		Algo:            algo,
+		ParticipantKeys: state.ParticipantKeys,
+		LocalPartyKey:   state.LocalPartyKey,
+		Threshold:       threshold,
+	}, nil
+}
+
+// ListPools returns all the pools this node holds a key share of
+func (t *TssServer) ListPools() ([]PoolInfo, error) {
+	states, err := t.stateManager.ListLocalStates()
+	if err != nil {
+		return nil, fmt.Errorf("fail to list the local states: %w", err)
+	}
+	pools := make([]PoolInfo, 0, len(states))
+	for _, el := range states {
+		pool, err := newPoolInfo(el)
+		if err != nil {
+			t.logger.Error().Err(err).Msgf("skip the invalid local state of pool %s", el.PubKey)
+			continue
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+// poolState reads the local state of the pool and tells its algo, the key shares are kept by the pub key alone, so
+// a pub key never names an eddsa and a schnorr pool at the same time, the algo of the local state decides which one
+// it is
+func (t *TssServer) poolState(pubKey string) (messages.Algo, storage.KeygenLocalState, error) {
+	algo, _, err := poolAlgo(pubKey)
+	if err != nil {
+		return 0, storage.KeygenLocalState{}, err
+	}
+	state, err := t.stateManager.GetLocalState(pubKey, algo)
+	if err != nil {
+		return 0, storage.KeygenLocalState{}, poolNotFound(pubKey, err)
+	}
+	if state.Algo == storage.AlgoSchnorr {
+		algo = messages.SCHNORRKEYGEN
+	}
+	return algo, state, nil
+}
+
+// GetPool returns the pool with the given pub key
+func (t *TssServer) GetPool(pubKey string) (PoolInfo, error) {
+	_, state, err := t.poolState(pubKey)
+	if err != nil {
+		return PoolInfo{}, err
+	}
+	return newPoolInfo(state)
+}
+
+// DeletePool removes the key share of the given pool, it is moved to the archive instead if archive is true
+func (t *TssServer) DeletePool(pubKey string, archive bool) error {
+	if _, _, err := poolAlgo(pubKey); err != nil {
+		return err
+	}
+	// make sure we don't remove the share while a keygen or regroup is updating it
+	unlock, err := t.scheduler.lockKey(context.Background(), pubKey)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	algo, _, err := t.poolState(pubKey)
+	if err != nil {
+		return err
+	}
+	if archive {
+		err = t.stateManager.ArchiveLocalState(pubKey, algo)
+	} else {
+		err = t.stateManager.DeleteLocalState(pubKey, algo)
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrPoolNotFound
+		}
+		return err
+	}
+	t.logger.Info().Msgf("removed the key share of pool %s (archive: %t)", pubKey, archive)
+	return nil
+}
@@ -0,0 +1,105 @@
+package tss
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+// schnorrPubKeys returns an x-only pub key which is an eddsa pub key as well and one which isn't
+func schnorrPubKeys(t *testing.T) (string, string) {
+	var both, schnorrOnly string
+	for i := byte(1); both == "" || schnorrOnly == ""; i++ {
+		priv, _ := btcec.PrivKeyFromBytes([]byte{i})
+		pubKey := base64.StdEncoding.EncodeToString(schnorr.SerializePubKey(priv.PubKey()))
+		if ok, _ := conversion.CheckKeyOnCurve(pubKey, messages.EDDSAKEYGEN); ok {
+			both = pubKey
+		} else {
+			schnorrOnly = pubKey
+		}
+		if i == 255 {
+			t.Fatal("fail to find the x-only pub keys")
+		}
+	}
+	return both, schnorrOnly
+}
+
+func TestPoolAlgo(t *testing.T) {
+	both, schnorrOnly := schnorrPubKeys(t)
+
+	algo, name, err := poolAlgo(testPoolPubKey)
+	assert.Nil(t, err)
+	assert.Equal(t, messages.ECDSAKEYGEN, algo)
+	assert.Equal(t, "ecdsa", name)
+
+	algo, name, err = poolAlgo(schnorrOnly)
+	assert.Nil(t, err)
+	assert.Equal(t, messages.SCHNORRKEYGEN, algo)
+	assert.Equal(t, storage.AlgoSchnorr, name)
+
+	// the key can't be told from an eddsa key, the local state tells the algo
+	_, name, err = poolAlgo(both)
+	assert.Nil(t, err)
+	assert.Equal(t, "eddsa", name)
+	pool, err := newPoolInfo(storage.KeygenLocalState{PubKey: both, Algo: storage.AlgoSchnorr})
+	assert.Nil(t, err)
+	assert.Equal(t, storage.AlgoSchnorr, pool.Algo)
+
+	_, _, err = poolAlgo(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.ErrorIs(t, err, ErrInvalidPoolPubKey)
+}
+
+// algoStateMgr records the algo the key shares are removed with
+type algoStateMgr struct {
+	storage.LocalStateManager
+	removedWith messages.Algo
+}
+
+func (s *algoStateMgr) DeleteLocalState(pubKey string, algo messages.Algo) error {
+	s.removedWith = algo
+	return s.LocalStateManager.DeleteLocalState(pubKey, algo)
+}
+
+func (s *algoStateMgr) ArchiveLocalState(pubKey string, algo messages.Algo) error {
+	s.removedWith = algo
+	return s.LocalStateManager.ArchiveLocalState(pubKey, algo)
+}
+
+func TestRemoveSchnorrPoolWithEdDSAKey(t *testing.T) {
+	both, _ := schnorrPubKeys(t)
+	state := storage.KeygenLocalState{
+		PubKey:          both,
+		LocalData:       []byte("share"),
+		ParticipantKeys: []string{"A", "B", "C"},
+		LocalPartyKey:   "A",
+		Algo:            storage.AlgoSchnorr,
+	}
+
+	// the pool is removed as the schnorr pool its local state tells, not as the eddsa pool its pub key looks like
+	stateManager := &algoStateMgr{LocalStateManager: storage.NewMemStateMgr()}
+	assert.Nil(t, stateManager.SaveLocalState(state, messages.SCHNORRKEYGEN))
+	server := newRetirementTestServerWithState(common.RetireNone, 0, stateManager)
+	pool, err := server.GetPool(both)
+	assert.Nil(t, err)
+	assert.Equal(t, storage.AlgoSchnorr, pool.Algo)
+	assert.Nil(t, server.DeletePool(both, true))
+	assert.Equal(t, messages.SCHNORRKEYGEN, stateManager.removedWith)
+	_, err = server.GetPool(both)
+	assert.ErrorIs(t, err, ErrPoolNotFound)
+
+	stateManager = &algoStateMgr{LocalStateManager: storage.NewMemStateMgr()}
+	state.RetirementPolicy = string(common.RetireWipe)
+	assert.Nil(t, stateManager.SaveLocalState(state, messages.SCHNORRKEYGEN))
+	server = newRetirementTestServerWithState(common.RetireWipe, time.Hour, stateManager)
+	assert.Nil(t, server.ConfirmRegroup(both))
+	assert.Equal(t, messages.SCHNORRKEYGEN, stateManager.removedWith)
+}
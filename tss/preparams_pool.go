@@ -0,0 +1,262 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	bkeygen "github.com/HyperCore-Team/tss-lib/ecdsa/keygen"
+	"github.com/rs/zerolog"
+
+	"github.com/HyperCore-Team/go-tss/monitor"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+const (
+	// preParamsFolderName is the folder in the base folder which holds the persisted pre-parameters
+	preParamsFolderName = "preparams"
+	// preParamsRetryDelay is how long the pool waits before it tries again once the generation of pre-parameters fails
+	preParamsRetryDelay = 30 * time.Second
+	// defaultPreParamsPoolSize is the size of the pool if the config doesn't set one, so the keygens after the first
+	// one don't wait for the safe primes
+	defaultPreParamsPoolSize = 1
+)
+
+// PreParamsInfo describes the pre-parameters pool, the pre-parameters themselves are never exposed
+type PreParamsInfo struct {
+	Size               int    `json:"size"`
+	Available          int    `json:"available"`
+	Generating         bool   `json:"generating"`
+	LastGenerationTime string `json:"last_generation_time,omitempty"`
+	LastError          string `json:"last_error,omitempty"`
+}
+
+type preParamsEntry struct {
+	// id is empty for the pre-parameters given by the caller, they are not persisted
+	id     string
+	params *bkeygen.LocalPreParams
+}
+
+// preParamsPool keeps the pre-parameters of the ECDSA keygens and regroups, every set is handed to a single ceremony
+// only, a background worker generates and persists new ones until the pool holds size of them
+type preParamsPool struct {
+	logger   zerolog.Logger
+	size     int
+	timeout  time.Duration
+	store    *storage.PreParamsStore
+	metrics  *monitor.Metric
+	generate func(ctx context.Context, optionalConcurrency ...int) (*bkeygen.LocalPreParams, error)
+
+	lock               *sync.Mutex
+	entries            []preParamsEntry
+	generating         int
+	lastGenerationTime time.Duration
+	lastErr            error
+
+	wake chan struct{}
+	// ctx is cancelled once the pool is stopped, it aborts the generation in progress
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// newPreParamsPool creates the pool and loads the pre-parameters persisted in the store, the store may be nil
+func newPreParamsPool(size int, timeout time.Duration, store *storage.PreParamsStore, metrics *monitor.Metric, logger zerolog.Logger) (*preParamsPool, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &preParamsPool{
+		logger:   logger,
+		size:     size,
+		timeout:  timeout,
+		store:    store,
+		metrics:  metrics,
+		generate: bkeygen.GeneratePreParamsWithContext,
+		lock:     &sync.Mutex{},
+		wake:     make(chan struct{}, 1),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	if store == nil {
+		return p, nil
+	}
+	items, err := store.Load()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("fail to load the pre-parameters: %w", err)
+	}
+	for _, el := range items {
+		var params bkeygen.LocalPreParams
+		if err := json.Unmarshal(el.Data, &params); err != nil || !params.Validate() {
+			p.logger.Error().Err(err).Msgf("the pre-parameters %s are invalid, remove them", el.ID)
+			if err := store.Delete(el.ID); err != nil {
+				cancel()
+				return nil, fmt.Errorf("fail to remove the invalid pre-parameters %s: %w", el.ID, err)
+			}
+			continue
+		}
+		p.entries = append(p.entries, preParamsEntry{id: el.ID, params: &params})
+	}
+	p.metrics.UpdatePreParamsDepth(len(p.entries))
+	return p, nil
+}
+
+// start runs the background worker which keeps the pool filled
+func (p *preParamsPool) start() {
+	if p.size <= 0 {
+		return
+	}
+	go p.run()
+}
+
+// stop stops the background worker and aborts the generation in progress
+func (p *preParamsPool) stop() {
+	p.cancel()
+}
+
+func (p *preParamsPool) run() {
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		default:
+		}
+		if p.available() < p.size {
+			if _, err := p.generateOne(true); err != nil {
+				p.logger.Error().Err(err).Msg("fail to fill the pre-parameters pool")
+				select {
+				case <-p.ctx.Done():
+					return
+				case <-time.After(preParamsRetryDelay):
+				}
+			}
+			continue
+		}
+		select {
+		case <-p.wake:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// generateOne generates a set of pre-parameters, it is added to the pool if keep is true
+func (p *preParamsPool) generateOne(keep bool) (*bkeygen.LocalPreParams, error) {
+	p.lock.Lock()
+	p.generating++
+	p.lock.Unlock()
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(p.ctx, p.timeout)
+	params, err := p.generate(ctx)
+	cancel()
+	if err == nil && !params.Validate() {
+		err = errors.New("invalid preparams")
+	}
+	generationTime := time.Since(start)
+	p.metrics.UpdatePreParamsGeneration(generationTime, err == nil)
+
+	p.lock.Lock()
+	p.generating--
+	p.lastErr = err
+	if err == nil {
+		p.lastGenerationTime = generationTime
+	}
+	p.lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("fail to generate pre parameters: %w", err)
+	}
+	if keep {
+		select {
+		case <-p.ctx.Done():
+			return params, nil
+		default:
+		}
+		if err := p.add(params, true); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+// add adds the pre-parameters to the pool, they are persisted if persist is true
+func (p *preParamsPool) add(params *bkeygen.LocalPreParams, persist bool) error {
+	entry := preParamsEntry{params: params}
+	if persist && p.store != nil {
+		buf, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("fail to marshal the pre-parameters: %w", err)
+		}
+		entry.id = fmt.Sprintf("%020d", time.Now().UnixNano())
+		if err := p.store.Save(storage.PreParamsItem{ID: entry.id, Data: buf}); err != nil {
+			return fmt.Errorf("fail to persist the pre-parameters: %w", err)
+		}
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.entries = append(p.entries, entry)
+	p.metrics.UpdatePreParamsDepth(len(p.entries))
+	return nil
+}
+
+// take hands a set of pre-parameters to a single ceremony, they are generated right away if the pool is empty
+func (p *preParamsPool) take() (*bkeygen.LocalPreParams, error) {
+	defer p.notify()
+	p.lock.Lock()
+	if len(p.entries) == 0 {
+		p.lock.Unlock()
+		p.logger.Info().Msg("the pre-parameters pool is empty, generate them for this ceremony")
+		return p.generateOne(false)
+	}
+	entry := p.entries[0]
+	p.entries = p.entries[1:]
+	p.metrics.UpdatePreParamsDepth(len(p.entries))
+	p.lock.Unlock()
+	// the pre-parameters must not be handed out again after a restart
+	if entry.id != "" && p.store != nil {
+		if err := p.store.Delete(entry.id); err != nil {
+			return nil, fmt.Errorf("fail to remove the pre-parameters %s from the store: %w", entry.id, err)
+		}
+	}
+	return entry.params, nil
+}
+
+// peek returns the pre-parameters the next ceremony takes, it is nil if the pool is empty
+func (p *preParamsPool) peek() *bkeygen.LocalPreParams {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.entries) == 0 {
+		return nil
+	}
+	return p.entries[0].params
+}
+
+func (p *preParamsPool) available() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.entries)
+}
+
+// notify wakes up the background worker to replace the pre-parameters taken
+func (p *preParamsPool) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (p *preParamsPool) info() PreParamsInfo {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	info := PreParamsInfo{
+		Size:       p.size,
+		Available:  len(p.entries),
+		Generating: p.generating > 0,
+	}
+	if p.lastGenerationTime > 0 {
+		info.LastGenerationTime = p.lastGenerationTime.String()
+	}
+	if p.lastErr != nil {
+		info.LastError = p.lastErr.Error()
+	}
+	return info
+}
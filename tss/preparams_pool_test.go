@@ -0,0 +1,125 @@
+package tss
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	bkeygen "github.com/HyperCore-Team/tss-lib/ecdsa/keygen"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/monitor"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+func newTestPreParamsPool(store *storage.PreParamsStore) *preParamsPool {
+	pool, err := newPreParamsPool(0, time.Second, store, monitor.NewMetric(), zerolog.Nop())
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+func loadTestPreParams(t *testing.T) []*bkeygen.LocalPreParams {
+	buf, err := os.ReadFile("../test_data/preParam_test.data")
+	assert.Nil(t, err)
+	var result []*bkeygen.LocalPreParams
+	for _, item := range strings.Split(string(buf), ",") {
+		val, err := hex.DecodeString(item)
+		assert.Nil(t, err)
+		var preParams bkeygen.LocalPreParams
+		assert.Nil(t, json.Unmarshal(val, &preParams))
+		result = append(result, &preParams)
+	}
+	return result
+}
+
+func TestPreParamsPoolTake(t *testing.T) {
+	fixtures := loadTestPreParams(t)
+	store, err := storage.NewPreParamsStore(filepath.Join(t.TempDir(), preParamsFolderName))
+	assert.Nil(t, err)
+	pool := newTestPreParamsPool(store)
+	assert.Nil(t, pool.add(fixtures[0], true))
+	assert.Nil(t, pool.add(fixtures[1], false))
+	assert.Equal(t, 2, pool.info().Available)
+
+	// only the persisted pre-parameters survive a restart
+	reloaded := newTestPreParamsPool(store)
+	assert.Equal(t, 1, reloaded.info().Available)
+
+	taken, err := pool.take()
+	assert.Nil(t, err)
+	assert.Equal(t, fixtures[0], taken)
+	taken, err = pool.take()
+	assert.Nil(t, err)
+	assert.Equal(t, fixtures[1], taken)
+	// a taken set is never handed out again, not even after a restart
+	items, err := store.Load()
+	assert.Nil(t, err)
+	assert.Len(t, items, 0)
+	assert.Nil(t, pool.peek())
+
+	// the pool is empty, the pre-parameters are generated for the ceremony
+	pool.generate = func(_ context.Context, _ ...int) (*bkeygen.LocalPreParams, error) {
+		return fixtures[2], nil
+	}
+	taken, err = pool.take()
+	assert.Nil(t, err)
+	assert.Equal(t, fixtures[2], taken)
+	assert.Equal(t, 0, pool.info().Available)
+	assert.NotEqual(t, "", pool.info().LastGenerationTime)
+}
+
+func TestPreParamsPoolWorker(t *testing.T) {
+	fixtures := loadTestPreParams(t)
+	pool := newTestPreParamsPool(nil)
+	pool.size = 2
+	generated := make(chan struct{}, len(fixtures))
+	pool.generate = func(_ context.Context, _ ...int) (*bkeygen.LocalPreParams, error) {
+		generated <- struct{}{}
+		return fixtures[len(generated)-1], nil
+	}
+	pool.start()
+	defer pool.stop()
+	assert.Eventually(t, func() bool {
+		return pool.info().Available == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// the worker replaces the pre-parameters taken
+	_, err := pool.take()
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return len(generated) == 3 && pool.info().Available == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPreParamsPoolFailure(t *testing.T) {
+	pool := newTestPreParamsPool(nil)
+	pool.generate = func(ctx context.Context, _ ...int) (*bkeygen.LocalPreParams, error) {
+		<-ctx.Done()
+		return nil, errors.New("timeout")
+	}
+	_, err := pool.take()
+	assert.NotNil(t, err)
+	assert.Equal(t, "timeout", pool.info().LastError)
+	assert.False(t, pool.info().Generating)
+}
+
+func TestTakePreParams(t *testing.T) {
+	fixtures := loadTestPreParams(t)
+	server := newRefreshTestServer()
+	assert.Nil(t, server.preParams.add(fixtures[0], false))
+	assert.Equal(t, fixtures[0], server.GetPreParams())
+	taken, err := server.takePreParams()
+	assert.Nil(t, err)
+	assert.Equal(t, fixtures[0], taken)
+	// the pre-parameters are never handed to two ceremonies
+	assert.Nil(t, server.GetPreParams())
+}
@@ -0,0 +1,74 @@
+package tss
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+)
+
+// the phases of a ceremony reported to the ProgressFunc, PhaseDone and PhaseFailed are left to the caller, it knows
+// the outcome once the ceremony returns
+const (
+	PhaseQueued    = "queued"
+	PhaseJoinParty = "join_party"
+	PhaseRound     = "round"
+	PhaseDone      = "done"
+	PhaseFailed    = "failed"
+)
+
+// Progress is the phase of a ceremony, Round and Rounds are only set in PhaseRound
+type Progress struct {
+	Phase  string `json:"phase"`
+	Round  int    `json:"round,omitempty"`
+	Rounds int    `json:"rounds,omitempty"`
+}
+
+// ProgressFunc is told whenever a ceremony moves on, it is called from the goroutines of the ceremony
+type ProgressFunc func(progress Progress)
+
+type progressKey struct{}
+
+// roundPattern matches the round in the message types of tss-lib, e.g. binance.tsslib.ecdsa.signing.SignRound3Message
+var roundPattern = regexp.MustCompile(`Round(\d+)Message`)
+
+// WithProgress returns a context which makes Keygen, KeySign, KeyRegroup and Refresh report their progress to the
+// given func
+func WithProgress(ctx context.Context, progress ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+// ReportProgress tells the ProgressFunc of the context how the ceremony moves on, it does nothing if there is none
+func ReportProgress(ctx context.Context, progress Progress) {
+	if f, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && f != nil {
+		f(progress)
+	}
+}
+
+// roundObserver returns the observer of the messages sent by the ceremony, it reports the round every time it changes,
+// it returns nil if no one asked for the progress
+func roundObserver(ctx context.Context, rounds int) func(msgType string) {
+	if f, ok := ctx.Value(progressKey{}).(ProgressFunc); !ok || f == nil {
+		return nil
+	}
+	lastRound := 0
+	return func(msgType string) {
+		match := roundPattern.FindStringSubmatch(msgType)
+		if match == nil {
+			return
+		}
+		round, err := strconv.Atoi(match[1])
+		if err != nil {
+			return
+		}
+		// the last message may be sent after the last round, e.g. the 9th message of the ECDSA keysign
+		if round > rounds {
+			round = rounds
+		}
+		// the messages are sent by a single goroutine of the ceremony
+		if round <= lastRound {
+			return
+		}
+		lastRound = round
+		ReportProgress(ctx, Progress{Phase: PhaseRound, Round: round, Rounds: rounds})
+	}
+}
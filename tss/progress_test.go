@@ -0,0 +1,37 @@
+package tss
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/messages"
+)
+
+func TestRoundObserver(t *testing.T) {
+	assert.Nil(t, roundObserver(context.Background(), messages.ECDSAKEYSIGNROUNDS))
+
+	var reported []Progress
+	ctx := WithProgress(context.Background(), func(progress Progress) {
+		reported = append(reported, progress)
+	})
+	ReportProgress(ctx, Progress{Phase: PhaseJoinParty})
+	observer := roundObserver(ctx, messages.ECDSAKEYSIGNROUNDS)
+	for _, el := range []string{
+		"binance.tsslib.ecdsa.signing.SignRound1Message1",
+		"binance.tsslib.ecdsa.signing.SignRound1Message2",
+		"binance.tsslib.ecdsa.signing.SignRound2Message",
+		"not a round",
+		"binance.tsslib.ecdsa.signing.SignRound8Message",
+		"binance.tsslib.ecdsa.signing.SignRound9Message",
+	} {
+		observer(el)
+	}
+	assert.Equal(t, []Progress{
+		{Phase: PhaseJoinParty},
+		{Phase: PhaseRound, Round: 1, Rounds: messages.ECDSAKEYSIGNROUNDS},
+		{Phase: PhaseRound, Round: 2, Rounds: messages.ECDSAKEYSIGNROUNDS},
+		{Phase: PhaseRound, Round: 8, Rounds: messages.ECDSAKEYSIGNROUNDS},
+	}, reported)
+}
@@ -0,0 +1,215 @@
+package tss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HyperCore-Team/go-tss/blame"
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/go-tss/refresh"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+	"github.com/HyperCore-Team/go-tss/regroup/ecdsa"
+	"github.com/HyperCore-Team/go-tss/regroup/eddsa"
+)
+
+// Refresh re-randomises the key shares of all the members of the pool, the committee, the threshold and the pool
+// pub key stay the same, a party which isn't formed is told by the blame of the response and no error,
+// RefreshWithContext returns the typed error for it
+func (t *TssServer) Refresh(req refresh.Request) (refresh.Response, error) {
+	resp, err := t.RefreshWithContext(context.Background(), req)
+	return resp, legacyError(err, false)
+}
+
+// RefreshWithContext is Refresh which aborts the refresh of this node as soon as the given context is done
+func (t *TssServer) RefreshWithContext(ctx context.Context, req refresh.Request) (refresh.Response, error) {
+	ctx, record := t.beginAudit(ctx, "refresh", req.PoolPubKey, req.Algo)
+	resp, err := t.runRefresh(ctx, req)
+	t.finishAudit(record, resp.Status, resp.Blame, err)
+	return resp, err
+}
+
+func (t *TssServer) runRefresh(ctx context.Context, req refresh.Request) (refresh.Response, error) {
+	if err := cancelledError(ctx, "key refresh"); err != nil {
+		return refresh.Response{}, err
+	}
+	if err := t.validateRequest(req); err != nil {
+		return refresh.Response{}, err
+	}
+	msgID, err := t.requestToMsgId(req)
+	if err != nil {
+		return refresh.Response{}, err
+	}
+	auditFromContext(ctx).setMsgID(msgID)
+	release, err := t.queueCeremony(ctx, req.PoolPubKey, "key refresh")
+	if err != nil {
+		return refresh.Response{}, err
+	}
+	defer release()
+
+	var algo messages.Algo
+	switch req.Algo {
+	case "ecdsa":
+		algo = messages.ECDSAKEYREGROUP
+	case "eddsa":
+		algo = messages.EDDSAKEYREGROUP
+	case "schnorr":
+		return refresh.Response{}, invalidRequest(errors.New("the schnorr pools can't be refreshed"))
+	default:
+		return refresh.Response{}, invalidRequest(fmt.Errorf("invalid key refresh algo %s", req.Algo))
+	}
+	localSaveData, err := t.stateManager.GetLocalState(req.PoolPubKey, algo)
+	if err != nil {
+		t.logger.Error().Err(err).Msgf("fail to get the local State data")
+		return refresh.NewResponse("", common.Fail, blame.Blame{}), poolNotFound(req.PoolPubKey, err)
+	}
+	threshold, err := localSaveData.GetThreshold()
+	if err != nil {
+		return refresh.NewResponse("", common.Fail, blame.Blame{}), err
+	}
+	// the pool is reshared to its own committee
+	regroupReq := keyRegroup.Request{
+		PoolPubKey:   req.PoolPubKey,
+		OldPartyKeys: localSaveData.ParticipantKeys,
+		NewPartyKeys: localSaveData.ParticipantKeys,
+		BlockHeight:  req.BlockHeight,
+		Version:      req.Version,
+		Algo:         req.Algo,
+		Threshold:    threshold,
+		OldThreshold: threshold,
+	}
+
+	stopChan, releaseStopChan := t.ceremonyStopChan(ctx)
+	defer releaseStopChan()
+	var keyRefreshInstance keyRegroup.TssKeyRegroup
+	if req.Algo == "ecdsa" {
+		// every member keeps the pre-parameters of its key share
+		preParams, err := poolPreParams(localSaveData)
+		if err != nil {
+			return refresh.NewResponse("", common.Fail, blame.Blame{}), err
+		}
+		keyRefreshInstance = ecdsa.NewTssKeyRefresh(
+			t.p2pCommunication.GetLocalPeerID(),
+			t.conf,
+			t.localNodePubKey,
+			t.p2pCommunication.BroadcastMsgChan,
+			stopChan,
+			preParams,
+			msgID,
+			t.stateManager,
+			t.privateKey,
+			t.p2pCommunication)
+	} else {
+		keyRefreshInstance = eddsa.NewTssKeyRefresh(
+			t.p2pCommunication.GetLocalPeerID(),
+			t.conf,
+			t.localNodePubKey,
+			t.p2pCommunication.BroadcastMsgChan,
+			stopChan,
+			msgID,
+			t.stateManager,
+			t.privateKey,
+			t.p2pCommunication)
+	}
+
+	refreshMsgChannel := keyRefreshInstance.GetTssKeyGenChannels()
+	t.p2pCommunication.SetSubscribe(messages.TSSRefreshMsg, msgID, refreshMsgChannel)
+	t.p2pCommunication.SetSubscribe(messages.TSSRefreshVerMsg, msgID, refreshMsgChannel)
+	t.p2pCommunication.SetSubscribe(messages.TSSControlMsg, msgID, refreshMsgChannel)
+	t.p2pCommunication.SetSubscribe(messages.TSSTaskDone, msgID, refreshMsgChannel)
+	defer func() {
+		t.p2pCommunication.CancelSubscribe(messages.TSSRefreshMsg, msgID)
+		t.p2pCommunication.CancelSubscribe(messages.TSSRefreshVerMsg, msgID)
+		t.p2pCommunication.CancelSubscribe(messages.TSSControlMsg, msgID)
+		t.p2pCommunication.CancelSubscribe(messages.TSSTaskDone, msgID)
+	}()
+
+	sigChan := make(chan string)
+	blameMgr := keyRefreshInstance.GetTssCommonStruct().GetBlameMgr()
+	rounds := messages.ECDSAREGROUPROUNDS
+	if req.Algo == "eddsa" {
+		rounds = messages.EDDSAREGROUPROUNDS
+	}
+	keyRefreshInstance.GetTssCommonStruct().SetRoundObserver(roundObserver(ctx, rounds))
+	joinPartyStartTime := time.Now()
+	// every member holds both an old and a new share, so all of them have to join
+	onlinePeers, leader, errJoinParty := t.joinParty(ctx, msgID, req.Version, req.BlockHeight, localSaveData.ParticipantKeys, len(localSaveData.ParticipantKeys)-1, sigChan)
+	joinPartyTime := time.Since(joinPartyStartTime)
+	if errJoinParty != nil {
+		t.tssMetrics.RefreshJoinParty(joinPartyTime, false)
+		t.tssMetrics.UpdateRefresh(0, false)
+		if err := cancelledError(ctx, "key refresh"); err != nil {
+			return refresh.NewResponse("", common.Fail, blame.Blame{}), err
+		}
+		// this indicate we are processing the leaderless join party
+		if leader == "NONE" {
+			if onlinePeers == nil {
+				t.logger.Error().Err(errJoinParty).Msg("error before we start join party")
+				return refresh.NewResponse("", common.Fail, blame.NewBlame(blame.InternalError, []blame.Node{})),
+					fmt.Errorf("%w: %w", errJoinPartyNotStarted, errJoinParty)
+			}
+			blameNodes, err := blameMgr.NodeSyncBlame(localSaveData.ParticipantKeys, onlinePeers)
+			if err != nil {
+				t.logger.Err(errJoinParty).Msg("fail to get peers to blame")
+			}
+			t.logger.Error().Err(errJoinParty).Msgf("fail to form refresh party with online:%v", onlinePeers)
+			return refresh.NewResponse("", common.Fail, blameNodes), joinPartyError("key refresh", errJoinParty)
+		}
+
+		var blameLeader blame.Blame
+		blameNodes, err := blameMgr.NodeSyncBlame(localSaveData.ParticipantKeys, onlinePeers)
+		if err != nil {
+			t.logger.Err(errJoinParty).Msg("fail to get peers to blame")
+		}
+		leaderPubKey, err := conversion.GetPubKeyFromPeerID(leader)
+		if err != nil {
+			t.logger.Error().Err(errJoinParty).Msgf("fail to convert the peerID to public key with leader %s", leader)
+			blameLeader = blame.NewBlame(blame.TssSyncFail, []blame.Node{})
+		} else {
+			blameLeader = blame.NewBlame(blame.TssSyncFail, []blame.Node{{Pubkey: leaderPubKey}})
+		}
+		if len(onlinePeers) != 0 {
+			blameNodes.AddBlameNodes(blameLeader.BlameNodes...)
+		} else {
+			blameNodes = blameLeader
+		}
+		t.logger.Error().Err(errJoinParty).Msgf("fail to form refresh party with online:%v", onlinePeers)
+		return refresh.NewResponse("", common.Fail, blameNodes), joinPartyError("key refresh", errJoinParty)
+	}
+
+	t.tssMetrics.RefreshJoinParty(joinPartyTime, true)
+	t.logger.Debug().Msg("refresh party formed")
+
+	beforeRefresh := time.Now()
+	k, err := keyRefreshInstance.GenerateNewKey(regroupReq, localSaveData)
+	refreshTime := time.Since(beforeRefresh)
+	if err != nil {
+		t.tssMetrics.UpdateRefresh(refreshTime, false)
+		t.logger.Error().Err(err).Msg("err in refresh")
+		if err := cancelledError(ctx, "key refresh"); err != nil {
+			return refresh.NewResponse("", common.Fail, blame.Blame{}), err
+		}
+		blameNodes := *blameMgr.GetBlame()
+		return refresh.NewResponse("", common.Fail, blameNodes), ceremonyError("key refresh", err)
+	}
+	t.tssMetrics.UpdateRefresh(refreshTime, true)
+
+	var newPubKey string
+	if req.Algo == "ecdsa" {
+		newPubKey, err = conversion.GetTssPubKeyECDSA(k)
+	} else {
+		newPubKey, err = conversion.GetTssPubKeyEDDSA(k)
+	}
+	blameNodes := *blameMgr.GetBlame()
+	if err != nil {
+		t.logger.Error().Err(err).Msg("fail to get the pool pub key of the refreshed shares")
+		return refresh.NewResponse("", common.Fail, blameNodes), nil
+	}
+	if newPubKey != req.PoolPubKey {
+		return refresh.NewResponse(newPubKey, common.Fail, blameNodes), fmt.Errorf("the refreshed pool pub key %s doesn't match the pool %s", newPubKey, req.PoolPubKey)
+	}
+	return refresh.NewResponse(newPubKey, common.Success, blameNodes), nil
+}
@@ -0,0 +1,51 @@
+package tss
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/refresh"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+func newRefreshTestServer() *TssServer {
+	return &TssServer{
+		logger:       zerolog.Nop(),
+		scheduler:    newCeremonyScheduler(1, 0),
+		preParams:    newTestPreParamsPool(nil),
+		stateManager: storage.NewMemStateMgr(),
+	}
+}
+
+func TestRefreshInvalidAlgo(t *testing.T) {
+	server := newRefreshTestServer()
+	_, err := server.Refresh(refresh.NewRequest(testPoolPubKey, 10, "0.14.0", "rsa"))
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestRefreshUnknownPool(t *testing.T) {
+	server := newRefreshTestServer()
+	resp, err := server.Refresh(refresh.NewRequest(testPoolPubKey, 10, "0.14.0", "ecdsa"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+	assert.ErrorIs(t, err, ErrPoolNotFound)
+	assert.Equal(t, common.Fail, resp.Status)
+	assert.Equal(t, "", resp.PubKey)
+}
+
+func TestRefreshMsgID(t *testing.T) {
+	server := newRefreshTestServer()
+	first, err := server.requestToMsgId(refresh.NewRequest(testPoolPubKey, 10, "0.14.0", "ecdsa"))
+	assert.Nil(t, err)
+	again, err := server.requestToMsgId(refresh.NewRequest(testPoolPubKey, 10, "0.14.0", "ecdsa"))
+	assert.Nil(t, err)
+	assert.Equal(t, first, again)
+	// a periodic refresh of the same pool must not reuse the msgID
+	next, err := server.requestToMsgId(refresh.NewRequest(testPoolPubKey, 20, "0.14.0", "ecdsa"))
+	assert.Nil(t, err)
+	assert.NotEqual(t, first, next)
+}
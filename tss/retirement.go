@@ -0,0 +1,153 @@
+package tss
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/messages"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+)
+
+// pendingRetirement is the timer retiring the old key share of a regrouped pool once the retirement delay is over,
+// the pending retirement itself is persisted in the local state of the pool, so it survives a restart
+type pendingRetirement struct {
+	poolPubKey string
+	timer      *time.Timer
+}
+
+// scheduleRetirement retires the old key share of the pool once the regroup is confirmed, it returns the retirement
+// status to report in the regroup response
+func (t *TssServer) scheduleRetirement(poolPubKey string, algo messages.Algo) string {
+	policy := t.conf.RetirementPolicy
+	if policy == common.RetireNone {
+		return keyRegroup.RetirementKept
+	}
+	state, err := t.stateManager.GetLocalState(poolPubKey, algo)
+	if err != nil {
+		t.logger.Error().Err(err).Msgf("fail to load the old key share of pool %s, it is kept", poolPubKey)
+		return keyRegroup.RetirementKept
+	}
+	state.RetirementPolicy = string(policy)
+	state.RetireAt = 0
+	if t.conf.RetirementDelay > 0 {
+		state.RetireAt = time.Now().Add(t.conf.RetirementDelay).Unix()
+	}
+	if err := t.stateManager.SaveLocalState(state, algo); err != nil {
+		t.logger.Error().Err(err).Msgf("fail to persist the retirement of pool %s, the old key share is kept", poolPubKey)
+		return keyRegroup.RetirementKept
+	}
+	if t.conf.RetirementDelay > 0 {
+		t.startRetirementTimer(poolPubKey, t.conf.RetirementDelay)
+	}
+	t.logger.Info().Msgf("the old key share of pool %s will be retired (%s) once the regroup is confirmed", poolPubKey, policy)
+	return keyRegroup.RetirementPending
+}
+
+// startRetirementTimer retires the old key share of the pool after the given delay unless it is confirmed or cancelled
+func (t *TssServer) startRetirementTimer(poolPubKey string, delay time.Duration) {
+	pending := &pendingRetirement{
+		poolPubKey: poolPubKey,
+	}
+	t.retirementLock.Lock()
+	defer t.retirementLock.Unlock()
+	if old, ok := t.pendingRetirements[poolPubKey]; ok {
+		old.timer.Stop()
+	}
+	pending.timer = time.AfterFunc(delay, func() {
+		t.retirementLock.Lock()
+		current := t.pendingRetirements[poolPubKey]
+		t.retirementLock.Unlock()
+		// the retirement is confirmed or cancelled already
+		if current != pending {
+			return
+		}
+		if err := t.ConfirmRegroup(poolPubKey); err != nil {
+			t.logger.Error().Err(err).Msgf("fail to retire the old key share of pool %s", poolPubKey)
+		}
+	})
+	t.pendingRetirements[poolPubKey] = pending
+}
+
+// stopRetirementTimer stops the retirement timer of the pool if there is one
+func (t *TssServer) stopRetirementTimer(poolPubKey string) {
+	t.retirementLock.Lock()
+	defer t.retirementLock.Unlock()
+	if pending, ok := t.pendingRetirements[poolPubKey]; ok {
+		pending.timer.Stop()
+		delete(t.pendingRetirements, poolPubKey)
+	}
+}
+
+// cancelRetirement keeps the key share of the pool, it is used when we are a member of the pool again, the new key
+// share saved by the regroup doesn't carry the pending retirement
+func (t *TssServer) cancelRetirement(poolPubKey string) {
+	t.stopRetirementTimer(poolPubKey)
+}
+
+// restoreRetirements restarts the retirement timers of the pending retirements persisted before a restart
+func (t *TssServer) restoreRetirements() error {
+	states, err := t.stateManager.ListLocalStates()
+	if err != nil {
+		return fmt.Errorf("fail to list the local states: %w", err)
+	}
+	for _, el := range states {
+		if el.RetirementPolicy == "" || el.RetireAt == 0 {
+			continue
+		}
+		delay := time.Until(time.Unix(el.RetireAt, 0))
+		if delay < 0 {
+			delay = 0
+		}
+		t.logger.Info().Msgf("the old key share of pool %s will be retired (%s) in %s", el.PubKey, el.RetirementPolicy, delay)
+		t.startRetirementTimer(el.PubKey, delay)
+	}
+	return nil
+}
+
+// ConfirmRegroup confirms the regroup of the given pool, the old key share of this node is retired right away
+// according to the retirement policy persisted when the regroup finished
+func (t *TssServer) ConfirmRegroup(poolPubKey string) error {
+	if _, _, err := poolAlgo(poolPubKey); err != nil {
+		return err
+	}
+	t.stopRetirementTimer(poolPubKey)
+
+	// make sure we don't remove the share while a keygen or regroup is updating it
+	unlock, err := t.scheduler.lockKey(context.Background(), poolPubKey)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	algo, state, err := t.poolState(poolPubKey)
+	if err != nil {
+		return err
+	}
+	policy := common.RetirementPolicy(state.RetirementPolicy)
+	switch policy {
+	case common.RetireNone:
+		return fmt.Errorf("no retirement is pending for pool %s: %w", poolPubKey, ErrPoolNotFound)
+	case common.RetireArchive:
+		err = t.stateManager.ArchiveLocalState(poolPubKey, algo)
+	case common.RetireWipe:
+		err = t.stateManager.DeleteLocalState(poolPubKey, algo)
+	default:
+		err = fmt.Errorf("unknown retirement policy %s", policy)
+	}
+	if err != nil {
+		return fmt.Errorf("fail to retire the old key share of pool %s: %w", poolPubKey, err)
+	}
+	t.logger.Info().Msgf("the old key share of pool %s is retired (%s)", poolPubKey, policy)
+	return nil
+}
+
+// stopRetirements stops the retirement timers, they are restored from the local states on the next start
+func (t *TssServer) stopRetirements() {
+	t.retirementLock.Lock()
+	defer t.retirementLock.Unlock()
+	for _, pending := range t.pendingRetirements {
+		pending.timer.Stop()
+	}
+	t.pendingRetirements = make(map[string]*pendingRetirement)
+}
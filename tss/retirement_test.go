@@ -0,0 +1,136 @@
+package tss
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/messages"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+const testPoolPubKey = "AvC1l/VLW5u+w2NCbYIG7QRTQ6/uNJGBN9lSDWNcp/Fq"
+
+func testOldShare() storage.KeygenLocalState {
+	return storage.KeygenLocalState{
+		PubKey:          testPoolPubKey,
+		LocalData:       []byte("old share"),
+		ParticipantKeys: []string{"A", "B", "C"},
+		LocalPartyKey:   "A",
+	}
+}
+
+func newRetirementTestServer(t *testing.T, policy common.RetirementPolicy, delay time.Duration) *TssServer {
+	stateManager := storage.NewMemStateMgr()
+	assert.Nil(t, stateManager.SaveLocalState(testOldShare(), messages.ECDSAKEYREGROUP))
+	return newRetirementTestServerWithState(policy, delay, stateManager)
+}
+
+func newRetirementTestServerWithState(policy common.RetirementPolicy, delay time.Duration, stateManager storage.LocalStateManager) *TssServer {
+	return &TssServer{
+		conf: common.TssConfig{
+			RetirementPolicy: policy,
+			RetirementDelay:  delay,
+		},
+		logger:             zerolog.Nop(),
+		scheduler:          newCeremonyScheduler(1, 0),
+		stateManager:       stateManager,
+		retirementLock:     &sync.Mutex{},
+		pendingRetirements: make(map[string]*pendingRetirement),
+	}
+}
+
+func hasLocalState(t *TssServer) bool {
+	_, err := t.stateManager.GetLocalState(testPoolPubKey, messages.ECDSAKEYSIGN)
+	return err == nil
+}
+
+func TestRetirementKept(t *testing.T) {
+	server := newRetirementTestServer(t, common.RetireNone, 0)
+	assert.Equal(t, keyRegroup.RetirementKept, server.scheduleRetirement(testPoolPubKey, messages.ECDSAKEYREGROUP))
+	err := server.ConfirmRegroup(testPoolPubKey)
+	assert.True(t, errors.Is(err, ErrPoolNotFound))
+	assert.True(t, hasLocalState(server))
+}
+
+func TestRetirementConfirmed(t *testing.T) {
+	server := newRetirementTestServer(t, common.RetireWipe, 0)
+	assert.Equal(t, keyRegroup.RetirementPending, server.scheduleRetirement(testPoolPubKey, messages.ECDSAKEYREGROUP))
+	assert.True(t, hasLocalState(server))
+	assert.Nil(t, server.ConfirmRegroup(testPoolPubKey))
+	assert.False(t, hasLocalState(server))
+	pools, err := server.stateManager.ListLocalStates()
+	assert.Nil(t, err)
+	assert.Len(t, pools, 0)
+	// the retirement is done only once
+	assert.NotNil(t, server.ConfirmRegroup(testPoolPubKey))
+}
+
+func TestRetirementAfterDelay(t *testing.T) {
+	server := newRetirementTestServer(t, common.RetireArchive, 100*time.Millisecond)
+	assert.Equal(t, keyRegroup.RetirementPending, server.scheduleRetirement(testPoolPubKey, messages.ECDSAKEYREGROUP))
+	assert.True(t, hasLocalState(server))
+	assert.Eventually(t, func() bool {
+		return !hasLocalState(server)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRetirementCancelled(t *testing.T) {
+	server := newRetirementTestServer(t, common.RetireWipe, 100*time.Millisecond)
+	assert.Equal(t, keyRegroup.RetirementPending, server.scheduleRetirement(testPoolPubKey, messages.ECDSAKEYREGROUP))
+	// we are a member of the regrouped pool, so the new key share replaces the old one
+	newShare := testOldShare()
+	newShare.LocalData = []byte("new share")
+	assert.Nil(t, server.stateManager.SaveLocalState(newShare, messages.ECDSAKEYREGROUP))
+	server.cancelRetirement(testPoolPubKey)
+	time.Sleep(300 * time.Millisecond)
+	assert.True(t, hasLocalState(server))
+	err := server.ConfirmRegroup(testPoolPubKey)
+	assert.True(t, errors.Is(err, ErrPoolNotFound))
+}
+
+func TestRetirementSurvivesRestart(t *testing.T) {
+	server := newRetirementTestServer(t, common.RetireWipe, 0)
+	assert.Equal(t, keyRegroup.RetirementPending, server.scheduleRetirement(testPoolPubKey, messages.ECDSAKEYREGROUP))
+	server.stopRetirements()
+
+	// the pending retirement is picked up from the local state after the restart
+	restarted := newRetirementTestServerWithState(common.RetireNone, 0, server.stateManager)
+	assert.Nil(t, restarted.restoreRetirements())
+	assert.True(t, hasLocalState(restarted))
+	assert.Nil(t, restarted.ConfirmRegroup(testPoolPubKey))
+	assert.False(t, hasLocalState(restarted))
+}
+
+func TestRetirementDelaySurvivesRestart(t *testing.T) {
+	server := newRetirementTestServer(t, common.RetireArchive, time.Hour)
+	assert.Equal(t, keyRegroup.RetirementPending, server.scheduleRetirement(testPoolPubKey, messages.ECDSAKEYREGROUP))
+	server.stopRetirements()
+
+	// the retirement is overdue once we restart
+	state, err := server.stateManager.GetLocalState(testPoolPubKey, messages.ECDSAKEYREGROUP)
+	assert.Nil(t, err)
+	state.RetireAt = time.Now().Add(-time.Minute).Unix()
+	assert.Nil(t, server.stateManager.SaveLocalState(state, messages.ECDSAKEYREGROUP))
+	restarted := newRetirementTestServerWithState(common.RetireNone, 0, server.stateManager)
+	assert.Nil(t, restarted.restoreRetirements())
+	assert.Eventually(t, func() bool {
+		return !hasLocalState(restarted)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRetirementWipeOfKVStateMgr(t *testing.T) {
+	kv, err := storage.NewKVStateMgr(filepath.Join(t.TempDir(), "localstate.db"))
+	assert.Nil(t, err)
+	defer kv.Close()
+	// the kv state manager can't overwrite the deleted key shares, the server isn't created
+	_, err = NewTss(nil, 0, nil, "", "", common.TssConfig{RetirementPolicy: common.RetireWipe}, nil, "", messages.ECDSAKEYGEN, nil, Options{StateManager: kv})
+	assert.NotNil(t, err)
+}
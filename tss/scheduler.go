@@ -0,0 +1,104 @@
+package tss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCeremonyQueueFull is returned when a keygen, regroup or refresh can't be queued as too many are waiting already
+var ErrCeremonyQueueFull = errors.New("too many ceremonies are waiting")
+
+// ceremonyScheduler limits how many keygens, regroups and refreshes run at the same time, the ones waiting for a free
+// slot are queued. Ceremonies with the same key, which is the pool pub key or the msgID of a new pool, never run at
+// the same time.
+type ceremonyScheduler struct {
+	slots     chan struct{}
+	queueSize int
+	lock      *sync.Mutex
+	queued    int
+	// busy holds the keys in use, the channel is closed once the key is released
+	busy map[string]chan struct{}
+}
+
+func newCeremonyScheduler(maxConcurrent, queueSize int) *ceremonyScheduler {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+	return &ceremonyScheduler{
+		slots:     make(chan struct{}, maxConcurrent),
+		queueSize: queueSize,
+		lock:      &sync.Mutex{},
+		busy:      make(map[string]chan struct{}),
+	}
+}
+
+// lockKey waits until no other ceremony holds the given key, the returned func releases it
+func (s *ceremonyScheduler) lockKey(ctx context.Context, key string) (func(), error) {
+	for {
+		s.lock.Lock()
+		released, ok := s.busy[key]
+		if !ok {
+			released = make(chan struct{})
+			s.busy[key] = released
+			s.lock.Unlock()
+			return func() {
+				s.lock.Lock()
+				defer s.lock.Unlock()
+				delete(s.busy, key)
+				close(released)
+			}, nil
+		}
+		s.lock.Unlock()
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// acquire queues the ceremony until it holds the given key and a free slot, the returned func releases both
+func (s *ceremonyScheduler) acquire(ctx context.Context, key string) (func(), error) {
+	s.lock.Lock()
+	if s.queueSize > 0 && s.queued >= s.queueSize {
+		s.lock.Unlock()
+		return nil, fmt.Errorf("%d ceremonies are queued: %w", s.queueSize, ErrCeremonyQueueFull)
+	}
+	s.queued++
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		s.queued--
+		s.lock.Unlock()
+	}()
+
+	unlockKey, err := s.lockKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		unlockKey()
+		return nil, ctx.Err()
+	}
+	return func() {
+		<-s.slots
+		unlockKey()
+	}, nil
+}
+
+// queueCeremony waits until the ceremony with the given key may run, the returned func has to be called once it is over
+func (t *TssServer) queueCeremony(ctx context.Context, key, operation string) (func(), error) {
+	ReportProgress(ctx, Progress{Phase: PhaseQueued})
+	release, err := t.scheduler.acquire(ctx, key)
+	if err != nil {
+		if cancelled := cancelledError(ctx, operation); cancelled != nil {
+			return nil, cancelled
+		}
+		return nil, fmt.Errorf("fail to queue the %s: %w", operation, err)
+	}
+	return release, nil
+}
@@ -0,0 +1,95 @@
+package tss
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// acquired reports whether acquire returns within a short time
+func acquired(ctx context.Context, s *ceremonyScheduler, key string) (func(), bool) {
+	result := make(chan func(), 1)
+	go func() {
+		release, err := s.acquire(ctx, key)
+		if err != nil {
+			release = nil
+		}
+		result <- release
+	}()
+	select {
+	case release := <-result:
+		return release, release != nil
+	case <-time.After(200 * time.Millisecond):
+		return nil, false
+	}
+}
+
+func TestCeremonySchedulerSlots(t *testing.T) {
+	s := newCeremonyScheduler(2, 0)
+	releaseA, ok := acquired(context.Background(), s, "A")
+	assert.True(t, ok)
+	releaseB, ok := acquired(context.Background(), s, "B")
+	assert.True(t, ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := s.acquire(ctx, "C")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	releaseA()
+	releaseC, ok := acquired(context.Background(), s, "C")
+	assert.True(t, ok)
+	releaseB()
+	releaseC()
+	assert.Len(t, s.busy, 0)
+}
+
+func TestCeremonySchedulerSameKey(t *testing.T) {
+	s := newCeremonyScheduler(4, 0)
+	release, ok := acquired(context.Background(), s, "A")
+	assert.True(t, ok)
+
+	// the key is in use, even though there are free slots
+	second := make(chan func(), 1)
+	go func() {
+		release, err := s.acquire(context.Background(), "A")
+		assert.Nil(t, err)
+		second <- release
+	}()
+	_, ok = acquired(context.Background(), s, "B")
+	assert.True(t, ok)
+	select {
+	case <-second:
+		t.Fatal("the same key is acquired twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+	release()
+	(<-second)()
+}
+
+func TestCeremonySchedulerQueue(t *testing.T) {
+	s := newCeremonyScheduler(1, 1)
+	release, ok := acquired(context.Background(), s, "A")
+	assert.True(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan error, 1)
+	go func() {
+		_, err := s.acquire(ctx, "B")
+		queued <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	_, err := s.acquire(context.Background(), "C")
+	assert.True(t, errors.Is(err, ErrCeremonyQueueFull))
+
+	// the cancelled ceremony leaves the queue and releases its key
+	cancel()
+	assert.True(t, errors.Is(<-queued, context.Canceled))
+	release()
+	releaseB, ok := acquired(context.Background(), s, "B")
+	assert.True(t, ok)
+	releaseB()
+}
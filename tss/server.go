@@ -1,8 +1,12 @@
 package tss
 
 import (
+	"context"
+
 	"github.com/HyperCore-Team/go-tss/keygen"
 	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/refresh"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
 )
 
 // Server define the necessary functionality should be provide by a TSS Server implementation
@@ -11,5 +15,19 @@ type Server interface {
 	Stop()
 	GetLocalPeerID() string
 	Keygen(req keygen.Request) (keygen.Response, error)
+	KeygenWithContext(ctx context.Context, req keygen.Request) (keygen.Response, error)
 	KeySign(req keysign.Request) (keysign.Response, error)
+	KeySignWithContext(ctx context.Context, req keysign.Request) (keysign.Response, error)
+	KeySignBatch(req keysign.BatchRequest) (keysign.BatchResponse, error)
+	KeySignBatchWithContext(ctx context.Context, req keysign.BatchRequest) (keysign.BatchResponse, error)
+	KeyRegroup(req keyRegroup.Request) (keyRegroup.Response, error)
+	KeyRegroupWithContext(ctx context.Context, req keyRegroup.Request) (keyRegroup.Response, error)
+	ConfirmRegroup(poolPubKey string) error
+	Refresh(req refresh.Request) (refresh.Response, error)
+	RefreshWithContext(ctx context.Context, req refresh.Request) (refresh.Response, error)
+	ListPools() ([]PoolInfo, error)
+	GetPool(pubKey string) (PoolInfo, error)
+	DeletePool(pubKey string, archive bool) error
+	GetSignatures(msgID string) (SignatureInfo, error)
+	PreParamsInfo() PreParamsInfo
 }
@@ -0,0 +1,64 @@
+package tss
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/HyperCore-Team/go-tss/keysign"
+)
+
+// signaturesFolderName is the folder in the base folder which holds the signatures of the recent keysigns
+const signaturesFolderName = "signatures"
+
+// ErrSignaturesNotFound is returned for a msgID whose signatures aren't kept, the keysign didn't finish on this node
+// or its signatures expired
+var ErrSignaturesNotFound = errors.New("signatures not found")
+
+// SignatureInfo are the kept signatures of a finished keysign
+type SignatureInfo struct {
+	MsgID      string              `json:"msg_id"`
+	PoolPubKey string              `json:"pool_pub_key"`
+	Signatures []keysign.Signature `json:"signatures"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+// GetSignatures returns the kept signatures of the keysign with the given msgID
+func (t *TssServer) GetSignatures(msgID string) (SignatureInfo, error) {
+	rec, sigs, err := t.signatureNotifier.GetSignatures(msgID)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return SignatureInfo{}, fmt.Errorf("%w: %s", ErrSignaturesNotFound, msgID)
+		}
+		return SignatureInfo{}, err
+	}
+	resp, err := t.batchSignatures(sigs, rec.Messages, rec.Algo, keysign.Request{})
+	if err != nil {
+		return SignatureInfo{}, err
+	}
+	return SignatureInfo{
+		MsgID:      rec.MsgID,
+		PoolPubKey: rec.PoolPubKey,
+		Signatures: resp.Signatures,
+		CreatedAt:  time.Unix(rec.CreatedAt, 0).UTC(),
+	}, nil
+}
+
+// storedSignatures returns the signatures of the signature store like the cached ones, the participants aren't kept
+func (t *TssServer) storedSignatures(msgID string) (keysignCacheEntry, bool) {
+	if t.signatureNotifier == nil {
+		return keysignCacheEntry{}, false
+	}
+	rec, sigs, err := t.signatureNotifier.GetSignatures(msgID)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			t.logger.Error().Err(err).Msgf("fail to get the kept signatures of %s", msgID)
+		}
+		return keysignCacheEntry{}, false
+	}
+	if len(sigs) != len(rec.Messages) {
+		return keysignCacheEntry{}, false
+	}
+	return keysignCacheEntry{poolPubKey: rec.PoolPubKey, algo: rec.Algo, msgs: rec.Messages, sigs: sigs}, true
+}
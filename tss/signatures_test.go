@@ -0,0 +1,64 @@
+package tss
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tsslibcommon "github.com/HyperCore-Team/tss-lib/common"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	"github.com/HyperCore-Team/go-tss/messages"
+	"github.com/HyperCore-Team/go-tss/storage"
+)
+
+func TestStoredSignatures(t *testing.T) {
+	h, err := mocknet.New().GenPeer()
+	assert.Nil(t, err)
+	sn := keysign.NewSignatureNotifier(h, nil, messages.ECDSAKEYSIGN)
+	server := &TssServer{logger: zerolog.Nop(), signatureNotifier: sn}
+	req := keysign.NewRequest(testPoolPubKey, []string{base64.StdEncoding.EncodeToString([]byte("hello"))}, 10, testNodePubKeys[:3], "0.14.0", "ecdsa")
+
+	// nothing is kept without a store
+	_, ok := server.keptKeySign("msg", req)
+	assert.False(t, ok)
+	_, err = server.GetSignatures("msg")
+	assert.ErrorIs(t, err, ErrSignaturesNotFound)
+
+	store, err := storage.NewSignatureStore(filepath.Join(t.TempDir(), signaturesFolderName), 10, time.Hour)
+	assert.Nil(t, err)
+	sn.SetSignatureStore(store)
+	sig := &tsslibcommon.SignatureData{
+		R:                 []byte{1},
+		S:                 []byte{2},
+		SignatureRecovery: []byte{1},
+		Signature:         []byte{1, 2},
+	}
+	assert.Nil(t, sn.SaveSignatures("msg", testPoolPubKey, messages.ECDSAKEYSIGN, [][]byte{[]byte("hello")}, []*tsslibcommon.SignatureData{sig}))
+
+	resp, ok := server.keptKeySign("msg", req)
+	assert.True(t, ok)
+	assert.Equal(t, common.Success, resp.Status)
+	assert.Equal(t, []keysign.Signature{keysign.NewSignature(
+		base64.StdEncoding.EncodeToString([]byte("hello")), "AQ==", "Ag==", "AQ==", "AQI=",
+	)}, resp.Signatures)
+	// the msgID doesn't tell the pool, the signatures of another pool are never handed out
+	other := req
+	other.PoolPubKey = "Am2zsXR3KHkrsxI/o6M2W/pFbo1zGTaHr8ck4dRErm8y"
+	_, ok = server.keptKeySign("msg", other)
+	assert.False(t, ok)
+
+	info, err := server.GetSignatures("msg")
+	assert.Nil(t, err)
+	assert.Equal(t, "msg", info.MsgID)
+	assert.Equal(t, testPoolPubKey, info.PoolPubKey)
+	assert.Equal(t, resp.Signatures, info.Signatures)
+	assert.False(t, info.CreatedAt.IsZero())
+	_, err = server.GetSignatures("unknown")
+	assert.ErrorIs(t, err, ErrSignaturesNotFound)
+}
@@ -1,7 +1,9 @@
 package tss
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	maddr "github.com/multiformats/go-multiaddr"
@@ -21,6 +23,7 @@ import (
 	"github.com/rs/zerolog/log"
 	tcrypto "github.com/tendermint/tendermint/crypto"
 
+	"github.com/HyperCore-Team/go-tss/audit"
 	"github.com/HyperCore-Team/go-tss/common"
 	"github.com/HyperCore-Team/go-tss/conversion"
 	"github.com/HyperCore-Team/go-tss/keygen"
@@ -28,6 +31,7 @@ import (
 	"github.com/HyperCore-Team/go-tss/messages"
 	"github.com/HyperCore-Team/go-tss/monitor"
 	"github.com/HyperCore-Team/go-tss/p2p"
+	"github.com/HyperCore-Team/go-tss/refresh"
 	"github.com/HyperCore-Team/go-tss/storage"
 )
 
@@ -37,17 +41,38 @@ type TssServer struct {
 	logger            zerolog.Logger
 	p2pCommunication  *p2p.Communication
 	localNodePubKey   string
-	preParams         *bkeygen.LocalPreParams
-	tssKeyGenLocker   *sync.Mutex
+	whitelist         map[string]bool
+	preParams         *preParamsPool
+	scheduler         *ceremonyScheduler
 	stopChan          chan struct{}
+	startOnce         *sync.Once
 	partyCoordinator  *p2p.PartyCoordinator
 	stateManager      storage.LocalStateManager
 	signatureNotifier *keysign.SignatureNotifier
+	keysignCache      *keysignCache
 	privateKey        tcrypto.PrivKey
 	tssMetrics        *monitor.Metric
+	signingPolicy     SigningPolicy
+	auditLog          *audit.Log
+
+	retirementLock     *sync.Mutex
+	pendingRetirements map[string]*pendingRetirement
+}
+
+// Options holds the optional settings of the TssServer
+type Options struct {
+	// StatePassphrase is used to encrypt the key shares at rest, they are stored as plaintext if it is empty
+	StatePassphrase []byte
+	// StateManager stores the key shares and the address book, a FileStateMgr in the base folder is used if it is nil
+	StateManager storage.LocalStateManager
+	// SigningPolicy vets the keysign requests before the node joins the party, all the requests are signed if it is nil
+	SigningPolicy SigningPolicy
+	// AuditLog records every ceremony, nothing is recorded if it is nil
+	AuditLog *audit.Log
 }
 
-// NewTss create a new instance of Tss
+// NewTss create a new instance of Tss, the given preParams are used by the next ECDSA keygen only, the later ones take
+// the pre-parameters of the pool, which generates PreParamsPoolSize of them in the background
 func NewTss(
 	cmdBootstrapPeers []maddr.Multiaddr,
 	p2pPort int,
@@ -59,13 +84,36 @@ func NewTss(
 	externalIP string,
 	algo messages.Algo,
 	pubKeyWhitelist map[string]bool,
+	opts Options,
 ) (*TssServer, error) {
+	switch conf.RetirementPolicy {
+	case common.RetireNone, common.RetireArchive, common.RetireWipe:
+	default:
+		return nil, fmt.Errorf("invalid retirement policy %s", conf.RetirementPolicy)
+	}
+	// the kv state manager can't overwrite the key shares it deletes
+	if _, ok := opts.StateManager.(*storage.KVStateMgr); ok && conf.RetirementPolicy == common.RetireWipe {
+		return nil, fmt.Errorf("the %s retirement policy isn't supported by the kv state manager, use %s", common.RetireWipe, common.RetireArchive)
+	}
 	pkBytes := priKey.PubKey().Bytes()[:]
 	pubKey := base64.StdEncoding.EncodeToString(pkBytes)
 
-	stateManager, err := storage.NewFileStateMgr(baseFolder)
+	var stateManager storage.LocalStateManager
+	var err error
+	switch {
+	case opts.StateManager != nil:
+		stateManager = opts.StateManager
+		// the base folder is still used for the logs
+		if len(baseFolder) > 0 {
+			err = os.MkdirAll(baseFolder, os.ModePerm)
+		}
+	case len(opts.StatePassphrase) > 0:
+		stateManager, err = storage.NewEncryptedFileStateMgr(baseFolder, opts.StatePassphrase)
+	default:
+		stateManager, err = storage.NewFileStateMgr(baseFolder)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("fail to create file state manager")
+		return nil, fmt.Errorf("fail to create file state manager: %w", err)
 	}
 
 	var bootstrapPeers []maddr.Multiaddr
@@ -86,15 +134,39 @@ func NewTss(
 	// time.
 	// This code will generate those parameters using a concurrency limit equal
 	// to the number of available CPU cores.
-	if algo == messages.ECDSAKEYGEN || algo == messages.ECDSAKEYREGROUP {
-		if preParams == nil || !preParams.Validate() {
-			preParams, err = bkeygen.GeneratePreParams(conf.PreParamTimeout)
-			if err != nil {
-				return nil, fmt.Errorf("fail to generate pre parameters: %w", err)
-			}
+	var preParamsStore *storage.PreParamsStore
+	if len(baseFolder) > 0 {
+		preParamsFolder := filepath.Join(baseFolder, preParamsFolderName)
+		if len(opts.StatePassphrase) > 0 {
+			preParamsStore, err = storage.NewEncryptedPreParamsStore(preParamsFolder, opts.StatePassphrase)
+		} else {
+			preParamsStore, err = storage.NewPreParamsStore(preParamsFolder)
 		}
-		if !preParams.Validate() {
-			return nil, errors.New("invalid preparams")
+		if err != nil {
+			return nil, fmt.Errorf("fail to create the pre-parameters store: %w", err)
+		}
+	}
+	metrics := monitor.NewMetric()
+	if conf.EnableMonitor {
+		metrics.Enable()
+	}
+	preParamsPoolSize := conf.PreParamsPoolSize
+	if preParamsPoolSize == 0 {
+		preParamsPoolSize = defaultPreParamsPoolSize
+	}
+	preParamsPool, err := newPreParamsPool(preParamsPoolSize, conf.PreParamTimeout, preParamsStore, metrics,
+		log.With().Str("module", "preparams").Logger())
+	if err != nil {
+		return nil, err
+	}
+	if preParams != nil && preParams.Validate() {
+		// the given pre-parameters are owned by the caller, they are not persisted
+		if err := preParamsPool.add(preParams, false); err != nil {
+			return nil, err
+		}
+	} else if (algo == messages.ECDSAKEYGEN || algo == messages.ECDSAKEYREGROUP) && preParamsPool.available() == 0 {
+		if _, err := preParamsPool.generateOne(true); err != nil {
+			return nil, err
 		}
 	}
 
@@ -112,11 +184,13 @@ func NewTss(
 	}
 	pc := p2p.NewPartyCoordinator(comm.GetHost(), logFile, conf.PartyTimeout, pubKeyWhitelist)
 	sn := keysign.NewSignatureNotifier(comm.GetHost(), pubKeyWhitelist, algo)
-	metrics := monitor.NewMetric()
-	if conf.EnableMonitor {
-		metrics.Enable()
+	if len(baseFolder) > 0 && conf.SignatureCacheSize > 0 {
+		signatureStore, err := storage.NewSignatureStore(filepath.Join(baseFolder, signaturesFolderName), conf.SignatureCacheSize, conf.SignatureCacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create the signature store: %w", err)
+		}
+		sn.SetSignatureStore(signatureStore)
 	}
-
 	outputFile, err := os.Create(filepath.Join(baseFolder, "tss.server.log"))
 	if err != nil {
 		return nil, err
@@ -127,28 +201,44 @@ func NewTss(
 		logger:            log.With().Str("module", "tss").Logger().Output(outputFile),
 		p2pCommunication:  comm,
 		localNodePubKey:   pubKey,
-		preParams:         preParams,
-		tssKeyGenLocker:   &sync.Mutex{},
+		whitelist:         pubKeyWhitelist,
+		preParams:         preParamsPool,
+		scheduler:         newCeremonyScheduler(conf.MaxConcurrentCeremonies, conf.CeremonyQueueSize),
 		stopChan:          make(chan struct{}),
+		startOnce:         &sync.Once{},
 		partyCoordinator:  pc,
 		stateManager:      stateManager,
 		signatureNotifier: sn,
+		keysignCache:      newKeysignCache(conf.KeySignCacheWindow),
 		privateKey:        priKey,
 		tssMetrics:        metrics,
+		signingPolicy:     opts.SigningPolicy,
+		auditLog:          opts.AuditLog,
+
+		retirementLock:     &sync.Mutex{},
+		pendingRetirements: make(map[string]*pendingRetirement),
 	}
 
 	return &tssServer, nil
 }
 
-// Start Tss server
+// Start Tss server, only the first call starts the background workers
 func (t *TssServer) Start() error {
-	log.Info().Msg("Starting the TSS servers")
+	t.startOnce.Do(func() {
+		log.Info().Msg("Starting the TSS servers")
+		if err := t.restoreRetirements(); err != nil {
+			t.logger.Error().Err(err).Msg("fail to restore the pending retirements")
+		}
+		t.preParams.start()
+	})
 	return nil
 }
 
 // Stop Tss server
 func (t *TssServer) Stop() {
 	close(t.stopChan)
+	t.stopRetirements()
+	t.preParams.stop()
 	// stop the p2p and finish the p2p wait group
 	err := t.p2pCommunication.Stop()
 	if err != nil {
@@ -198,12 +288,22 @@ func (t *TssServer) requestToMsgId(request interface{}) (string, error) {
 	case keysign.Request:
 		sort.Strings(value.Messages)
 		dat = []byte(strings.Join(value.Messages, ","))
+		// the same messages signed by different child keys are different keysigns
+		if value.DerivationPath != "" {
+			dat = append(dat, []byte(value.DerivationPath)...)
+		}
+		if value.HashMode != common.HashModeNone {
+			dat = append(dat, []byte(value.HashMode)...)
+		}
 		keys = value.SignerPubKeys
 	case keyRegroup.Request:
 		keys = value.NewPartyKeys
+	case refresh.Request:
+		// the committee is taken from the local state of the pool, the block height tells the periodic refreshes apart
+		dat = []byte(fmt.Sprintf("refresh%s%d", value.PoolPubKey, value.BlockHeight))
 	default:
 		t.logger.Error().Msg("unknown request type")
-		return "", errors.New("unknown request type")
+		return "", invalidRequest(errors.New("unknown request type"))
 	}
 	keyAccumulation := ""
 	sort.Strings(keys)
@@ -211,10 +311,16 @@ func (t *TssServer) requestToMsgId(request interface{}) (string, error) {
 		keyAccumulation += el
 	}
 	dat = append(dat, []byte(keyAccumulation)...)
-	return common.MsgToHashString(dat)
+	msgID, err := common.MsgToHashString(dat)
+	if err != nil {
+		return "", invalidRequest(err)
+	}
+	return msgID, nil
 }
 
-func (t *TssServer) joinParty(msgID, version string, blockHeight int64, participants []string, threshold int, sigChan chan string) ([]peer.ID, string, error) {
+func (t *TssServer) joinParty(ctx context.Context, msgID, version string, blockHeight int64, participants []string, threshold int, sigChan chan string) ([]peer.ID, string, error) {
+	ReportProgress(ctx, Progress{Phase: PhaseJoinParty})
+	joinPartyStartTime := time.Now()
 	oldJoinParty, err := conversion.VersionLTCheck(version, messages.NEWJOINPARTYVERSION)
 	if err != nil {
 		return nil, "", fmt.Errorf("fail to parse the version with error:%w", err)
@@ -229,7 +335,8 @@ func (t *TssServer) joinParty(msgID, version string, blockHeight int64, particip
 		for _, el := range peerIDs {
 			peersIDStr = append(peersIDStr, el.String())
 		}
-		onlines, err := t.partyCoordinator.JoinPartyWithRetry(msgID, peersIDStr)
+		onlines, err := t.partyCoordinator.JoinPartyWithRetryContext(ctx, msgID, peersIDStr)
+		auditFromContext(ctx).joinedParty(participants, "", time.Since(joinPartyStartTime))
 		return onlines, "NONE", err
 	} else {
 		t.logger.Info().Msg("we apply the join party with a leader")
@@ -247,8 +354,41 @@ func (t *TssServer) joinParty(msgID, version string, blockHeight int64, particip
 			peersIDStr = append(peersIDStr, el.String())
 		}
 
-		return t.partyCoordinator.JoinPartyWithLeader(msgID, blockHeight, peersIDStr, threshold, sigChan)
+		onlines, leader, err := t.partyCoordinator.JoinPartyWithLeaderContext(ctx, msgID, blockHeight, peersIDStr, threshold, sigChan)
+		auditFromContext(ctx).joinedParty(participants, leader, time.Since(joinPartyStartTime))
+		return onlines, leader, err
+	}
+}
+
+// ceremonyStopChan returns the stop channel of a single keygen, keysign or regroup, it is closed once the given
+// context is done or the server is stopped, release has to be called once the ceremony is over
+func (t *TssServer) ceremonyStopChan(ctx context.Context) (chan struct{}, func()) {
+	stopChan := make(chan struct{})
+	released := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stopChan)
+		case <-t.stopChan:
+			close(stopChan)
+		case <-released:
+		}
+	}()
+	var once sync.Once
+	return stopChan, func() {
+		once.Do(func() {
+			close(released)
+		})
+	}
+}
+
+// cancelledError returns the error reported by a ceremony aborted because its context is done, it is nil if the
+// context is not done
+func cancelledError(ctx context.Context, operation string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s is cancelled: %w", operation, err)
 	}
+	return nil
 }
 
 // GetLocalPeerID return the local peer
@@ -256,19 +396,33 @@ func (t *TssServer) GetLocalPeerID() string {
 	return t.p2pCommunication.GetLocalPeerID()
 }
 
+// GetPreParams returns the pre-parameters the next ECDSA keygen takes, it is nil if the pool is empty
 func (t *TssServer) GetPreParams() *btsskeygen.LocalPreParams {
-	return t.preParams
+	return t.preParams.peek()
 }
 
+// GeneratePreParams generates a set of pre-parameters and adds it to the pool
 func (t *TssServer) GeneratePreParams() error {
-	newPreParams, err := bkeygen.GeneratePreParams(t.conf.PreParamTimeout)
-	if err != nil {
-		return fmt.Errorf("fail to generate pre parameters: %w", err)
-	}
+	_, err := t.preParams.generateOne(true)
+	return err
+}
+
+// PreParamsInfo describes the pre-parameters pool
+func (t *TssServer) PreParamsInfo() PreParamsInfo {
+	return t.preParams.info()
+}
 
-	if !newPreParams.Validate() {
-		return errors.New("invalid preparams")
+// takePreParams hands the pre-parameters to a single ECDSA keygen or regroup, as two key shares must never share a
+// Paillier key
+func (t *TssServer) takePreParams() (*bkeygen.LocalPreParams, error) {
+	return t.preParams.take()
+}
+
+// poolPreParams returns the pre-parameters the key share of the pool is created with
+func poolPreParams(state storage.KeygenLocalState) (*bkeygen.LocalPreParams, error) {
+	var localData bkeygen.LocalPartySaveData
+	if err := json.Unmarshal(state.LocalData, &localData); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal the local saved data: %w", err)
 	}
-	t.preParams = newPreParams
-	return nil
+	return &localData.LocalPreParams, nil
 }
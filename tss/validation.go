@@ -0,0 +1,65 @@
+package tss
+
+import (
+	"fmt"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/keygen"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+)
+
+// validator is a request which checks its own fields
+type validator interface {
+	Validate() error
+}
+
+// validateRequest checks the fields of the request, then checks its parties are whitelisted and include the local node
+// where it has to take part, nothing is sent to the parties of an invalid request
+func (t *TssServer) validateRequest(req validator) error {
+	if err := req.Validate(); err != nil {
+		return invalidRequest(err)
+	}
+	var errs common.FieldErrors
+	switch r := req.(type) {
+	case keygen.Request:
+		t.checkWhitelist(&errs, "keys", r.Keys)
+		if !t.isPartOfKeysignParty(r.Keys) {
+			errs.Addf("keys", "the local node %s isn't one of the keys", t.localNodePubKey)
+		}
+	case keysign.Request:
+		t.checkWhitelist(&errs, "signer_pub_keys", r.SignerPubKeys)
+	case keyRegroup.Request:
+		t.checkWhitelist(&errs, "old_party_keys", r.OldPartyKeys)
+		t.checkWhitelist(&errs, "new_party_keys", r.NewPartyKeys)
+		if !t.isPartOfKeysignParty(r.OldPartyKeys) && !t.isPartOfKeysignParty(r.NewPartyKeys) {
+			errs.Addf("new_party_keys", "the local node %s is in neither the old nor the new committee", t.localNodePubKey)
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return invalidRequest(err)
+	}
+	return nil
+}
+
+// checkWhitelist reports the keys of the nodes which aren't in the whitelist, every node is allowed if the whitelist
+// is empty, the local node isn't in it necessarily
+func (t *TssServer) checkWhitelist(errs *common.FieldErrors, field string, keys []string) {
+	if len(t.whitelist) == 0 {
+		return
+	}
+	for i, el := range keys {
+		if el == t.localNodePubKey {
+			continue
+		}
+		peerID, err := conversion.GetPeerIDFromPubKey(el)
+		if err != nil {
+			errs.Addf(fmt.Sprintf("%s[%d]", field, i), "invalid node pub key %q", el)
+			continue
+		}
+		if _, ok := t.whitelist[peerID.String()]; !ok {
+			errs.Addf(fmt.Sprintf("%s[%d]", field, i), "the node %s isn't whitelisted", el)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package tss
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HyperCore-Team/go-tss/common"
+	"github.com/HyperCore-Team/go-tss/conversion"
+	"github.com/HyperCore-Team/go-tss/keygen"
+	"github.com/HyperCore-Team/go-tss/keysign"
+	keyRegroup "github.com/HyperCore-Team/go-tss/regroup"
+)
+
+var testNodePubKeys = []string{
+	"D2Ou8kohzWyVESbCOE/yXHmCAaCbB2R1jDWRpECf1JY=",
+	"8v5YUvEtN8vpNKejH1dmVi4BoEZX+c5EHoqQCXQM/WE=",
+	"Zlgbrnmk6xDkamTs004bZgUYbpiE5dV4rSg+MfSk4gU=",
+	"jzTMn5m27Cmt6EuCAuKnIzxNbVYY4EIywP0a9grmSok=",
+}
+
+func TestValidateRequest(t *testing.T) {
+	// the last node isn't whitelisted, the local node isn't in the whitelist either
+	whitelist := make(map[string]bool)
+	for _, el := range testNodePubKeys[1:3] {
+		peerID, err := conversion.GetPeerIDFromPubKey(el)
+		assert.Nil(t, err)
+		whitelist[peerID.String()] = true
+	}
+	server := &TssServer{
+		logger:          zerolog.Nop(),
+		localNodePubKey: testNodePubKeys[0],
+		whitelist:       whitelist,
+	}
+	msg := base64.StdEncoding.EncodeToString([]byte("hello"))
+	testCases := []struct {
+		name   string
+		req    validator
+		fields []string
+	}{
+		{
+			name: "keygen",
+			req:  keygen.NewRequest(testNodePubKeys[:3], 10, "0.14.0", "ecdsa"),
+		},
+		{
+			name:   "keygen without the local node",
+			req:    keygen.NewRequest(testNodePubKeys[1:3], 10, "0.14.0", "ecdsa"),
+			fields: []string{"keys"},
+		},
+		{
+			name:   "keygen with a node which isn't whitelisted",
+			req:    keygen.NewRequest(testNodePubKeys, 10, "0.14.0", "ecdsa"),
+			fields: []string{"keys[3]"},
+		},
+		{
+			name:   "keygen of an invalid request",
+			req:    keygen.NewRequest(testNodePubKeys[:3], 10, "0.14.0", "rsa"),
+			fields: []string{"algo"},
+		},
+		{
+			name: "keysign without the local node",
+			req:  keysign.NewRequest(testPoolPubKey, []string{msg}, 10, testNodePubKeys[1:3], "0.14.0", "ecdsa"),
+		},
+		{
+			name:   "keysign with a signer which isn't whitelisted",
+			req:    keysign.NewRequest(testPoolPubKey, []string{msg}, 10, testNodePubKeys[2:], "0.14.0", "ecdsa"),
+			fields: []string{"signer_pub_keys[1]"},
+		},
+		{
+			name: "regroup to a new committee",
+			req:  keyRegroup.NewRequest("", testNodePubKeys[1:3], testNodePubKeys[:3], 10, "0.14.0", "ecdsa"),
+		},
+		{
+			name:   "regroup without the local node",
+			req:    keyRegroup.NewRequest(testPoolPubKey, testNodePubKeys[1:3], testNodePubKeys[1:3], 10, "0.14.0", "ecdsa"),
+			fields: []string{"new_party_keys"},
+		},
+		{
+			name:   "regroup to a node which isn't whitelisted",
+			req:    keyRegroup.NewRequest(testPoolPubKey, testNodePubKeys[:3], testNodePubKeys[1:], 10, "0.14.0", "ecdsa"),
+			fields: []string{"new_party_keys[2]"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := server.validateRequest(tc.req)
+			if len(tc.fields) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+			var fieldErrs common.FieldErrors
+			assert.True(t, errors.As(err, &fieldErrs))
+			var fields []string
+			for _, el := range fieldErrs {
+				fields = append(fields, el.Field)
+			}
+			assert.Equal(t, tc.fields, fields)
+		})
+	}
+
+	// every node is allowed if the whitelist is empty
+	server.whitelist = nil
+	assert.Nil(t, server.validateRequest(keygen.NewRequest(testNodePubKeys, 10, "0.14.0", "ecdsa")))
+}